@@ -0,0 +1,247 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/scheduler"
+)
+
+// AdminService exposes an HTTP API to control the scheduled jobs and to run backfills in the running service.
+// Every request must have the `Authorization: Bearer <Admin.ApiToken>` header.
+type AdminService struct {
+	config     config.AdminConfig
+	log        *logging.Logger
+	tasks      *TaskManager
+	mut        sync.Mutex
+	nextID     int
+	schedulers map[int]*scheduler.Scheduler
+	// ctx is the context of the running service, tasks are cancelled with it
+	ctx context.Context
+}
+
+func NewAdminService(cfg config.AdminConfig, tasks *TaskManager, log *logging.Logger) *AdminService {
+	return &AdminService{
+		config:     cfg,
+		log:        log.Named("admin"),
+		tasks:      tasks,
+		schedulers: map[int]*scheduler.Scheduler{},
+	}
+}
+
+// RegisterScheduler makes jobs of the scheduler available in the API.
+// The returned function unregisters the scheduler, e.g. when it stops.
+func (a *AdminService) RegisterScheduler(s *scheduler.Scheduler) func() {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	id := a.nextID
+	a.nextID++
+	a.schedulers[id] = s
+
+	return func() {
+		a.mut.Lock()
+		defer a.mut.Unlock()
+		delete(a.schedulers, id)
+	}
+}
+
+func (a *AdminService) Run(ctx context.Context) error {
+	if len(a.config.ApiToken) < 1 {
+		return errors.New("admin API token is not set")
+	}
+
+	a.mut.Lock()
+	a.ctx = ctx
+	a.mut.Unlock()
+
+	srv := &http.Server{
+		Addr:           fmt.Sprintf(":%d", a.config.Port),
+		Handler:        a.authenticate(a.handler()),
+		ReadTimeout:    10 * time.Second,
+		WriteTimeout:   10 * time.Second,
+		MaxHeaderBytes: 1 << 20,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		a.log.Info("Starting admin server", zap.Int("port", a.config.Port))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("failed to run admin server: %w", err)
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown admin server: %w", err)
+	}
+
+	return nil
+}
+
+func (a *AdminService) authenticate(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + a.config.ApiToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), expected) != 1 {
+			a.log.Warn("Unauthorized admin request", zap.String("path", r.URL.Path), zap.String("remote", r.RemoteAddr))
+			a.writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handler routes:
+//
+//	GET  /jobs
+//	POST /jobs/{name}/pause
+//	POST /jobs/{name}/resume
+//	POST /jobs/{name}/trigger
+//	GET  /tasks
+//	POST /tasks
+//	GET  /tasks/{id}
+//	POST /tasks/{id}/cancel
+func (a *AdminService) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+		switch {
+		case len(path) == 1 && path[0] == "jobs" && r.Method == http.MethodGet:
+			a.writeJSON(w, http.StatusOK, a.jobStatuses())
+		case len(path) == 3 && path[0] == "jobs" && r.Method == http.MethodPost:
+			a.jobAction(w, path[1], path[2])
+		case len(path) == 1 && path[0] == "tasks" && r.Method == http.MethodGet:
+			a.writeJSON(w, http.StatusOK, a.tasks.List())
+		case len(path) == 1 && path[0] == "tasks" && r.Method == http.MethodPost:
+			a.startTask(w, r)
+		case len(path) == 2 && path[0] == "tasks" && r.Method == http.MethodGet:
+			a.writeTask(w, http.StatusOK)(a.tasks.Get(path[1]))
+		case len(path) == 3 && path[0] == "tasks" && path[2] == "cancel" && r.Method == http.MethodPost:
+			a.writeTask(w, http.StatusOK)(a.tasks.Cancel(path[1]))
+		default:
+			a.writeError(w, http.StatusNotFound, fmt.Errorf("unknown endpoint %s %s", r.Method, r.URL.Path))
+		}
+	}
+}
+
+func (a *AdminService) jobStatuses() []scheduler.JobStatus {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	statuses := []scheduler.JobStatus{}
+	for id := 0; id < a.nextID; id++ {
+		if s, ok := a.schedulers[id]; ok {
+			statuses = append(statuses, s.Statuses()...)
+		}
+	}
+
+	return statuses
+}
+
+func (a *AdminService) jobAction(w http.ResponseWriter, name string, action string) {
+	a.mut.Lock()
+	var jobScheduler *scheduler.Scheduler
+	for _, s := range a.schedulers {
+		if s.Has(name) {
+			jobScheduler = s
+			break
+		}
+	}
+	a.mut.Unlock()
+
+	if jobScheduler == nil {
+		a.writeError(w, http.StatusNotFound, fmt.Errorf("%w: %s", scheduler.ErrJobNotFound, name))
+		return
+	}
+
+	var err error
+	switch action {
+	case "pause":
+		err = jobScheduler.Pause(name)
+	case "resume":
+		err = jobScheduler.Resume(name)
+	case "trigger":
+		err = jobScheduler.Trigger(name)
+	default:
+		a.writeError(w, http.StatusNotFound, fmt.Errorf("unknown job action %q: expected one of pause, resume, trigger", action))
+		return
+	}
+	if err != nil {
+		a.writeError(w, http.StatusNotFound, fmt.Errorf("failed to %s job %s: %w", action, name, err))
+		return
+	}
+
+	a.log.Info("Admin job action", zap.String("job", name), zap.String("action", action))
+	a.writeJSON(w, http.StatusOK, map[string]string{"Job": name, "Action": action})
+}
+
+func (a *AdminService) startTask(w http.ResponseWriter, r *http.Request) {
+	var req TaskRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		a.writeError(w, http.StatusBadRequest, fmt.Errorf("failed to decode task request: %w", err))
+		return
+	}
+
+	a.mut.Lock()
+	ctx := a.ctx
+	a.mut.Unlock()
+
+	task, err := a.tasks.Start(ctx, req)
+	if errors.Is(err, ErrUpdatesUnavailable) || errors.Is(err, ErrNotLeader) {
+		a.writeError(w, http.StatusServiceUnavailable, err)
+		return
+	}
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	a.writeJSON(w, http.StatusAccepted, task)
+}
+
+func (a *AdminService) writeTask(w http.ResponseWriter, status int) func(task Task, err error) {
+	return func(task Task, err error) {
+		if err != nil {
+			a.writeError(w, http.StatusNotFound, err)
+			return
+		}
+		a.writeJSON(w, status, task)
+	}
+}
+
+func (a *AdminService) writeError(w http.ResponseWriter, status int, err error) {
+	a.writeJSON(w, status, map[string]string{"Error": err.Error()})
+}
+
+func (a *AdminService) writeJSON(w http.ResponseWriter, status int, body any) {
+	response, err := json.MarshalIndent(body, "", "    ")
+	if err != nil {
+		a.log.Error("Failed to marshal admin response", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(response); err != nil {
+		a.log.Error("Failed to write admin response", zap.Error(err))
+	}
+}
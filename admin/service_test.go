@@ -0,0 +1,268 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/scheduler"
+)
+
+const testApiToken = "secret"
+
+func newTestAdminService(tasks *TaskManager) (*AdminService, *scheduler.Scheduler) {
+	a := NewAdminService(config.AdminConfig{ApiToken: testApiToken}, tasks, logging.NewTestLogger())
+	a.ctx = context.Background()
+
+	jobs := scheduler.NewScheduler(logging.NewTestLogger())
+	jobs.Add("block_signers", config.JobConfig{Interval: time.Hour}, func(ctx context.Context) error { return nil })
+	a.RegisterScheduler(jobs)
+
+	return a, jobs
+}
+
+func serveAdmin(a *AdminService, method string, path string, token string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if len(token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	a.authenticate(a.handler()).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestAdminServiceHandler(t *testing.T) {
+	testScenarios := []struct {
+		name           string
+		method         string
+		path           string
+		token          string
+		body           string
+		leader         bool
+		expectedStatus int
+		expectedPaused bool
+	}{
+		{
+			name:           "missing token",
+			method:         http.MethodGet,
+			path:           "/jobs",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "invalid token",
+			method:         http.MethodPost,
+			path:           "/jobs/block_signers/pause",
+			token:          "invalid",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "list jobs",
+			method:         http.MethodGet,
+			path:           "/jobs",
+			token:          testApiToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "pause job",
+			method:         http.MethodPost,
+			path:           "/jobs/block_signers/pause",
+			token:          testApiToken,
+			expectedStatus: http.StatusOK,
+			expectedPaused: true,
+		},
+		{
+			name:           "resume job",
+			method:         http.MethodPost,
+			path:           "/jobs/block_signers/resume",
+			token:          testApiToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "trigger job",
+			method:         http.MethodPost,
+			path:           "/jobs/block_signers/trigger",
+			token:          testApiToken,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown job",
+			method:         http.MethodPost,
+			path:           "/jobs/missing/pause",
+			token:          testApiToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown job action",
+			method:         http.MethodPost,
+			path:           "/jobs/block_signers/stop",
+			token:          testApiToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "unknown endpoint",
+			method:         http.MethodDelete,
+			path:           "/jobs",
+			token:          testApiToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "start task",
+			method:         http.MethodPost,
+			path:           "/tasks",
+			token:          testApiToken,
+			body:           `{"Type": "block_signers", "FromBlock": 1, "ToBlock": 10}`,
+			leader:         true,
+			expectedStatus: http.StatusAccepted,
+		},
+		{
+			name:           "start task on standby",
+			method:         http.MethodPost,
+			path:           "/tasks",
+			token:          testApiToken,
+			body:           `{"Type": "block_signers", "FromBlock": 1, "ToBlock": 10}`,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "start task of unknown type",
+			method:         http.MethodPost,
+			path:           "/tasks",
+			token:          testApiToken,
+			body:           `{"Type": "asset_prices"}`,
+			leader:         true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "start task with invalid body",
+			method:         http.MethodPost,
+			path:           "/tasks",
+			token:          testApiToken,
+			body:           `{"Type":`,
+			leader:         true,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown task",
+			method:         http.MethodGet,
+			path:           "/tasks/missing",
+			token:          testApiToken,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "cancel unknown task",
+			method:         http.MethodPost,
+			path:           "/tasks/missing/cancel",
+			token:          testApiToken,
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			leader := tc.leader
+			tasks := NewTaskManager(newFakeUpdateService(), nil, logging.NewTestLogger()).
+				WithLeaderCheck(func() bool { return leader })
+			a, jobs := newTestAdminService(tasks)
+			if tc.path == "/jobs/block_signers/resume" {
+				require.NoError(t, jobs.Pause("block_signers"))
+			}
+
+			rec := serveAdmin(a, tc.method, tc.path, tc.token, tc.body)
+			assert.Equal(t, tc.expectedStatus, rec.Code, rec.Body.String())
+			assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			statuses := jobs.Statuses()
+			require.Len(t, statuses, 1)
+			assert.Equal(t, tc.expectedPaused, statuses[0].Paused)
+			tasks.CancelAll()
+		})
+	}
+}
+
+func TestAdminServiceJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a := NewAdminService(config.AdminConfig{ApiToken: testApiToken}, NewTaskManager(nil, nil, logging.NewTestLogger()), logging.NewTestLogger())
+	ran := make(chan struct{}, 1)
+	jobs := scheduler.NewScheduler(logging.NewTestLogger())
+	jobs.Add("comet_txs", config.JobConfig{Interval: time.Hour, InitialDelay: time.Hour}, func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	})
+	other := scheduler.NewScheduler(logging.NewTestLogger())
+	other.Add("node_scanner_cores", config.JobConfig{Interval: time.Hour}, func(ctx context.Context) error { return nil })
+	a.RegisterScheduler(jobs)
+	unregister := a.RegisterScheduler(other)
+	go jobs.Run(ctx)
+
+	// Jobs of all the registered schedulers are listed
+	rec := serveAdmin(a, http.MethodGet, "/jobs", testApiToken, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var statuses []scheduler.JobStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "comet_txs", statuses[0].Name)
+	assert.Equal(t, "node_scanner_cores", statuses[1].Name)
+
+	// The paused job runs only when triggered
+	require.Equal(t, http.StatusOK, serveAdmin(a, http.MethodPost, "/jobs/comet_txs/pause", testApiToken, "").Code)
+	require.Equal(t, http.StatusOK, serveAdmin(a, http.MethodPost, "/jobs/comet_txs/trigger", testApiToken, "").Code)
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("Triggered job did not run")
+	}
+
+	// Jobs of the unregistered scheduler are not available
+	unregister()
+	assert.Equal(t, http.StatusNotFound, serveAdmin(a, http.MethodPost, "/jobs/node_scanner_cores/pause", testApiToken, "").Code)
+	rec = serveAdmin(a, http.MethodGet, "/jobs", testApiToken, "")
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Paused)
+}
+
+func TestAdminServiceTasks(t *testing.T) {
+	updateService := newFakeUpdateService()
+	a, _ := newTestAdminService(NewTaskManager(updateService, nil, logging.NewTestLogger()))
+
+	rec := serveAdmin(a, http.MethodPost, "/tasks", testApiToken, `{"Type": "comet_txs", "FromBlock": 1, "ToBlock": 10}`)
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var task Task
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &task))
+	assert.Equal(t, config.CometTxsJob, task.Type)
+	assert.Equal(t, TaskStatusRunning, task.Status)
+
+	rec = serveAdmin(a, http.MethodGet, "/tasks/"+task.ID, testApiToken, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveAdmin(a, http.MethodGet, "/tasks", testApiToken, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var tasks []Task
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &tasks))
+	require.Len(t, tasks, 1)
+	assert.Equal(t, task.ID, tasks[0].ID)
+
+	rec = serveAdmin(a, http.MethodPost, "/tasks/"+task.ID+"/cancel", testApiToken, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Eventually(t, func() bool {
+		rec := serveAdmin(a, http.MethodGet, "/tasks/"+task.ID, testApiToken, "")
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &task))
+		return task.Status == TaskStatusCancelled
+	}, time.Second, time.Millisecond)
+
+	// Backfills are not available without the DataNodeDBExtension
+	a, _ = newTestAdminService(NewTaskManager(nil, nil, logging.NewTestLogger()))
+	rec = serveAdmin(a, http.MethodPost, "/tasks", testApiToken, `{"Type": "comet_txs"}`)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
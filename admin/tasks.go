@@ -0,0 +1,255 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/services/update"
+)
+
+const (
+	TaskStatusRunning   = "running"
+	TaskStatusSucceeded = "succeeded"
+	TaskStatusFailed    = "failed"
+	TaskStatusCancelled = "cancelled"
+
+	// maxFinishedTasks is the number of finished tasks kept in memory for the reporting
+	maxFinishedTasks = 100
+)
+
+var (
+	ErrTaskNotFound       = errors.New("task not found")
+	ErrUnknownTaskType    = errors.New("unknown task type")
+	ErrUpdatesUnavailable = errors.New("updates are not available, DataNodeDBExtension is disabled")
+	ErrNotLeader          = errors.New("tasks run only on the leader, this instance is a standby")
+)
+
+type updateService interface {
+	UpdateBlockSignersWithProgress(ctx context.Context, fromBlock int64, toBlock int64, progress update.ProgressFunc) error
+	UpdateCometTxsWithProgress(ctx context.Context, fromBlock int64, toBlock int64, progress update.ProgressFunc) error
+	UpdateNetworkHistorySegmentsRange(ctx context.Context, apiURLs []string, fromBlock int64, toBlock int64, progress update.ProgressFunc) error
+}
+
+// TaskRequest describes a backfill for the given range of blocks. The range is inclusive,
+// 0 means the same defaults as used by the `update` commands.
+type TaskRequest struct {
+	Type      string
+	FromBlock int64
+	ToBlock   int64
+	// DataNodes is used only by the network_history_segments task, defaults to Monitoring.DataNode
+	DataNodes []string `json:",omitempty"`
+}
+
+// Task is a backfill running in the background next to the scheduled jobs.
+type Task struct {
+	ID         string
+	Type       string
+	FromBlock  int64
+	ToBlock    int64
+	DataNodes  []string `json:",omitempty"`
+	Status     string
+	Processed  int64
+	Total      int64
+	StartedAt  time.Time
+	FinishedAt *time.Time `json:",omitempty"`
+	Error      string     `json:",omitempty"`
+
+	cancel context.CancelFunc
+}
+
+type TaskManager struct {
+	log           *logging.Logger
+	mut           sync.Mutex
+	updateService updateService
+	dataNodeURLs  func() []string
+	isLeader      func() bool
+	tasks         map[string]*Task
+}
+
+func NewTaskManager(updateService updateService, dataNodeURLs func() []string, log *logging.Logger) *TaskManager {
+	return &TaskManager{
+		log:           log,
+		updateService: updateService,
+		dataNodeURLs:  dataNodeURLs,
+		tasks:         map[string]*Task{},
+	}
+}
+
+// WithLeaderCheck makes the task manager start tasks only when isLeader returns true, so in the high availability
+// mode only the leader writes to the database.
+func (tm *TaskManager) WithLeaderCheck(isLeader func() bool) *TaskManager {
+	tm.isLeader = isLeader
+	return tm
+}
+
+// Start runs the task in the background until it is finished, cancelled or the context is done.
+func (tm *TaskManager) Start(ctx context.Context, req TaskRequest) (Task, error) {
+	if tm.updateService == nil {
+		return Task{}, ErrUpdatesUnavailable
+	}
+	if tm.isLeader != nil && !tm.isLeader() {
+		return Task{}, ErrNotLeader
+	}
+	if req.FromBlock < 0 || req.ToBlock < 0 || (req.ToBlock > 0 && req.FromBlock > req.ToBlock) {
+		return Task{}, fmt.Errorf("invalid block range [%d, %d]", req.FromBlock, req.ToBlock)
+	}
+
+	var run func(ctx context.Context, progress update.ProgressFunc) error
+	switch req.Type {
+	case config.BlockSignersJob:
+		run = func(ctx context.Context, progress update.ProgressFunc) error {
+			return tm.updateService.UpdateBlockSignersWithProgress(ctx, req.FromBlock, req.ToBlock, progress)
+		}
+	case config.CometTxsJob:
+		run = func(ctx context.Context, progress update.ProgressFunc) error {
+			return tm.updateService.UpdateCometTxsWithProgress(ctx, req.FromBlock, req.ToBlock, progress)
+		}
+	case config.NetworkHistorySegmentsJob:
+		if len(req.DataNodes) < 1 && tm.dataNodeURLs != nil {
+			req.DataNodes = tm.dataNodeURLs()
+		}
+		if len(req.DataNodes) < 1 {
+			return Task{}, errors.New("no data-nodes to fetch the network history segments from")
+		}
+		run = func(ctx context.Context, progress update.ProgressFunc) error {
+			return tm.updateService.UpdateNetworkHistorySegmentsRange(ctx, req.DataNodes, req.FromBlock, req.ToBlock, progress)
+		}
+	default:
+		return Task{}, fmt.Errorf("%w %q: expected one of %s, %s, %s", ErrUnknownTaskType, req.Type,
+			config.BlockSignersJob, config.CometTxsJob, config.NetworkHistorySegmentsJob)
+	}
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	task := &Task{
+		ID:        uuid.New().String(),
+		Type:      req.Type,
+		FromBlock: req.FromBlock,
+		ToBlock:   req.ToBlock,
+		DataNodes: req.DataNodes,
+		Status:    TaskStatusRunning,
+		StartedAt: time.Now(),
+		cancel:    cancel,
+	}
+
+	tm.mut.Lock()
+	tm.tasks[task.ID] = task
+	tm.pruneFinished()
+	result := *task
+	tm.mut.Unlock()
+
+	logger := tm.log.With(zap.String("task", task.ID), zap.String("type", task.Type))
+	logger.Info("Starting task", zap.Int64("from-block", req.FromBlock), zap.Int64("to-block", req.ToBlock))
+
+	go func() {
+		defer cancel()
+		err := run(taskCtx, func(processed int64, total int64) {
+			tm.mut.Lock()
+			task.Processed = processed
+			task.Total = total
+			tm.mut.Unlock()
+		})
+
+		finishedAt := time.Now()
+		tm.mut.Lock()
+		defer tm.mut.Unlock()
+		task.FinishedAt = &finishedAt
+		switch {
+		case err == nil:
+			task.Status = TaskStatusSucceeded
+			logger.Info("Task succeeded", zap.Duration("duration", finishedAt.Sub(task.StartedAt)))
+		case taskCtx.Err() != nil:
+			task.Status = TaskStatusCancelled
+			task.Error = err.Error()
+			logger.Info("Task cancelled", zap.Error(err))
+		default:
+			task.Status = TaskStatusFailed
+			task.Error = err.Error()
+			logger.Error("Task failed", zap.Error(err))
+		}
+	}()
+
+	return result, nil
+}
+
+// Cancel stops the running task. It does nothing for the finished tasks.
+func (tm *TaskManager) Cancel(id string) (Task, error) {
+	tm.mut.Lock()
+	defer tm.mut.Unlock()
+
+	task, ok := tm.tasks[id]
+	if !ok {
+		return Task{}, ErrTaskNotFound
+	}
+	task.cancel()
+
+	return *task, nil
+}
+
+// CancelAll stops all the running tasks, e.g. when the instance is no longer the leader.
+func (tm *TaskManager) CancelAll() {
+	tm.mut.Lock()
+	defer tm.mut.Unlock()
+
+	for _, task := range tm.tasks {
+		if task.FinishedAt == nil {
+			task.cancel()
+		}
+	}
+}
+
+func (tm *TaskManager) Get(id string) (Task, error) {
+	tm.mut.Lock()
+	defer tm.mut.Unlock()
+
+	task, ok := tm.tasks[id]
+	if !ok {
+		return Task{}, ErrTaskNotFound
+	}
+
+	return *task, nil
+}
+
+// List returns all the tracked tasks, the newest first.
+func (tm *TaskManager) List() []Task {
+	tm.mut.Lock()
+	defer tm.mut.Unlock()
+
+	tasks := make([]Task, 0, len(tm.tasks))
+	for _, task := range tm.tasks {
+		tasks = append(tasks, *task)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].StartedAt.After(tasks[j].StartedAt)
+	})
+
+	return tasks
+}
+
+// pruneFinished removes the oldest finished tasks above the limit. Must be called with the mutex held.
+func (tm *TaskManager) pruneFinished() {
+	finished := []*Task{}
+	for _, task := range tm.tasks {
+		if task.FinishedAt != nil {
+			finished = append(finished, task)
+		}
+	}
+	if len(finished) <= maxFinishedTasks {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].FinishedAt.Before(*finished[j].FinishedAt)
+	})
+	for _, task := range finished[:len(finished)-maxFinishedTasks] {
+		delete(tm.tasks, task.ID)
+	}
+}
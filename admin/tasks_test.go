@@ -0,0 +1,248 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/services/update"
+)
+
+// fakeUpdateService runs every update until it is released, or its context is cancelled.
+type fakeUpdateService struct {
+	release  chan error
+	apiURLs  chan []string
+	progress int64
+}
+
+func newFakeUpdateService() *fakeUpdateService {
+	return &fakeUpdateService{
+		release: make(chan error, 1),
+		apiURLs: make(chan []string, 1),
+	}
+}
+
+func (f *fakeUpdateService) run(ctx context.Context, fromBlock int64, toBlock int64, progress update.ProgressFunc) error {
+	progress(f.progress, toBlock-fromBlock+1)
+	select {
+	case err := <-f.release:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (f *fakeUpdateService) UpdateBlockSignersWithProgress(ctx context.Context, fromBlock int64, toBlock int64, progress update.ProgressFunc) error {
+	return f.run(ctx, fromBlock, toBlock, progress)
+}
+
+func (f *fakeUpdateService) UpdateCometTxsWithProgress(ctx context.Context, fromBlock int64, toBlock int64, progress update.ProgressFunc) error {
+	return f.run(ctx, fromBlock, toBlock, progress)
+}
+
+func (f *fakeUpdateService) UpdateNetworkHistorySegmentsRange(ctx context.Context, apiURLs []string, fromBlock int64, toBlock int64, progress update.ProgressFunc) error {
+	f.apiURLs <- apiURLs
+	return f.run(ctx, fromBlock, toBlock, progress)
+}
+
+func waitForTaskStatus(t *testing.T, tm *TaskManager, id string, status string) Task {
+	t.Helper()
+
+	var task Task
+	require.Eventually(t, func() bool {
+		var err error
+		task, err = tm.Get(id)
+		require.NoError(t, err)
+		return task.Status == status
+	}, time.Second, time.Millisecond)
+
+	return task
+}
+
+func TestTaskManagerStart(t *testing.T) {
+	testScenarios := []struct {
+		name        string
+		updates     bool
+		isLeader    func() bool
+		dataNodes   func() []string
+		req         TaskRequest
+		expectedErr error
+		anyErr      bool
+		apiURLs     []string
+	}{
+		{
+			name:    "block signers",
+			updates: true,
+			req:     TaskRequest{Type: config.BlockSignersJob, FromBlock: 10, ToBlock: 19},
+		},
+		{
+			name:    "comet txs",
+			updates: true,
+			req:     TaskRequest{Type: config.CometTxsJob, FromBlock: 10, ToBlock: 19},
+		},
+		{
+			name:      "network history segments from the configured data-nodes",
+			updates:   true,
+			dataNodes: func() []string { return []string{"http://node-1"} },
+			req:       TaskRequest{Type: config.NetworkHistorySegmentsJob, FromBlock: 10, ToBlock: 19},
+			apiURLs:   []string{"http://node-1"},
+		},
+		{
+			name:      "network history segments from the requested data-nodes",
+			updates:   true,
+			dataNodes: func() []string { return []string{"http://node-1"} },
+			req:       TaskRequest{Type: config.NetworkHistorySegmentsJob, FromBlock: 10, ToBlock: 19, DataNodes: []string{"http://node-2"}},
+			apiURLs:   []string{"http://node-2"},
+		},
+		{
+			name:      "network history segments without data-nodes",
+			updates:   true,
+			dataNodes: func() []string { return nil },
+			req:       TaskRequest{Type: config.NetworkHistorySegmentsJob},
+			anyErr:    true,
+		},
+		{
+			name:        "unknown type",
+			updates:     true,
+			req:         TaskRequest{Type: "asset_prices"},
+			expectedErr: ErrUnknownTaskType,
+		},
+		{
+			name:    "invalid range",
+			updates: true,
+			req:     TaskRequest{Type: config.BlockSignersJob, FromBlock: 20, ToBlock: 10},
+			anyErr:  true,
+		},
+		{
+			name:        "updates unavailable",
+			updates:     false,
+			req:         TaskRequest{Type: config.BlockSignersJob},
+			expectedErr: ErrUpdatesUnavailable,
+		},
+		{
+			name:        "standby instance",
+			updates:     true,
+			isLeader:    func() bool { return false },
+			req:         TaskRequest{Type: config.BlockSignersJob},
+			expectedErr: ErrNotLeader,
+		},
+		{
+			name:     "leader instance",
+			updates:  true,
+			isLeader: func() bool { return true },
+			req:      TaskRequest{Type: config.BlockSignersJob, FromBlock: 10, ToBlock: 19},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			updateService := newFakeUpdateService()
+			tm := NewTaskManager(nil, tc.dataNodes, logging.NewTestLogger())
+			if tc.updates {
+				tm = NewTaskManager(updateService, tc.dataNodes, logging.NewTestLogger())
+			}
+			if tc.isLeader != nil {
+				tm.WithLeaderCheck(tc.isLeader)
+			}
+
+			task, err := tm.Start(context.Background(), tc.req)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Empty(t, tm.List())
+				return
+			}
+			if tc.anyErr {
+				assert.Error(t, err)
+				assert.Empty(t, tm.List())
+				return
+			}
+			require.NoError(t, err)
+			assert.NotEmpty(t, task.ID)
+			assert.Equal(t, tc.req.Type, task.Type)
+			assert.Equal(t, TaskStatusRunning, task.Status)
+			if tc.apiURLs != nil {
+				assert.Equal(t, tc.apiURLs, <-updateService.apiURLs)
+			}
+
+			updateService.release <- nil
+			finished := waitForTaskStatus(t, tm, task.ID, TaskStatusSucceeded)
+			assert.NotNil(t, finished.FinishedAt)
+			assert.Empty(t, finished.Error)
+		})
+	}
+}
+
+func TestTaskManagerLifecycle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	updateService := newFakeUpdateService()
+	updateService.progress = 4
+	tm := NewTaskManager(updateService, nil, logging.NewTestLogger())
+
+	// Progress is reported while the task is running
+	running, err := tm.Start(ctx, TaskRequest{Type: config.BlockSignersJob, FromBlock: 1, ToBlock: 10})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		task, err := tm.Get(running.ID)
+		require.NoError(t, err)
+		return task.Processed == 4 && task.Total == 10
+	}, time.Second, time.Millisecond)
+
+	// Cancelled task
+	cancelled, err := tm.Cancel(running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, running.ID, cancelled.ID)
+	task := waitForTaskStatus(t, tm, running.ID, TaskStatusCancelled)
+	assert.NotEmpty(t, task.Error)
+	assert.NotNil(t, task.FinishedAt)
+
+	// Cancelling the finished task does not change it
+	_, err = tm.Cancel(running.ID)
+	require.NoError(t, err)
+	task, err = tm.Get(running.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusCancelled, task.Status)
+
+	// Failed task
+	failing, err := tm.Start(ctx, TaskRequest{Type: config.CometTxsJob, FromBlock: 1, ToBlock: 10})
+	require.NoError(t, err)
+	updateService.release <- errors.New("data-node unavailable")
+	task = waitForTaskStatus(t, tm, failing.ID, TaskStatusFailed)
+	assert.Equal(t, "data-node unavailable", task.Error)
+
+	// CancelAll stops the running tasks only
+	first, err := tm.Start(ctx, TaskRequest{Type: config.BlockSignersJob})
+	require.NoError(t, err)
+	second, err := tm.Start(ctx, TaskRequest{Type: config.CometTxsJob})
+	require.NoError(t, err)
+	tm.CancelAll()
+	waitForTaskStatus(t, tm, first.ID, TaskStatusCancelled)
+	waitForTaskStatus(t, tm, second.ID, TaskStatusCancelled)
+	task, err = tm.Get(failing.ID)
+	require.NoError(t, err)
+	assert.Equal(t, TaskStatusFailed, task.Status)
+
+	// Cancelling the service context cancels the tasks
+	last, err := tm.Start(ctx, TaskRequest{Type: config.BlockSignersJob})
+	require.NoError(t, err)
+	cancel()
+	waitForTaskStatus(t, tm, last.ID, TaskStatusCancelled)
+
+	tasks := tm.List()
+	require.Len(t, tasks, 5)
+	for idx := 1; idx < len(tasks); idx++ {
+		assert.False(t, tasks[idx].StartedAt.After(tasks[idx-1].StartedAt), "tasks must be listed the newest first")
+	}
+
+	_, err = tm.Get("missing")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+	_, err = tm.Cancel("missing")
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"strconv"
 
-	"golang.org/x/exp/slices"
+	"github.com/vegaprotocol/vega-monitoring/config"
 )
 
 //
@@ -37,7 +37,7 @@ func (c *CometClient) GetTxsForBlock(ctx context.Context, block int64) ([]CometT
 	if err != nil {
 		return nil, err
 	}
-	return RemoveExcludedTxTypes(txList), nil
+	return RemoveExcludedTxTypes(txList, c.TxFilter()), nil
 }
 
 func (c *CometClient) GetTxsForBlockRange(ctx context.Context, fromBlock int64, toBlock int64) ([]CometTx, error) {
@@ -45,48 +45,16 @@ func (c *CometClient) GetTxsForBlockRange(ctx context.Context, fromBlock int64,
 	if err != nil {
 		return nil, err
 	}
-	return RemoveExcludedTxTypes(txList), nil
+	return RemoveExcludedTxTypes(txList, c.TxFilter()), nil
 }
 
-var (
-	EXCLUDE_COMMAND_TYPES = []string{
-		"Submit Order",
-		"Cancel Order",
-		"Amend Order",
-		"Withdraw",
-		"Proposal",
-		"Vote on Proposal",
-		// "Register new Node",
-		// "Node Vote",
-		"Node Signature",
-		"Liquidity Provision Order",
-		"Cancel LiquidityProvision Order",
-		"Amend LiquidityProvision Order",
-		// "Chain Event",
-		"Submit Oracle Data",
-		"Delegate",
-		"Undelegate",
-		"Key Rotate Submission",
-		// "State Variable Proposal",
-		"Transfer Funds",
-		"Cancel Transfer Funds",
-		// "Validator Heartbeat",
-		"Ethereum Key Rotate Submission",
-		"Protocol Upgrade",
-		// "Issue Signatures",
-		// "Batch Market Instructions",
+// RemoveExcludedTxTypes returns the txs kept by the filter. When the filter is nil, the default filter is used.
+func RemoveExcludedTxTypes(txs []CometTx, filter *TxFilter) []CometTx {
+	if filter == nil {
+		filter = NewTxFilter(config.CometTxFilterConfig{})
 	}
-)
 
-func RemoveExcludedTxTypes(txs []CometTx) []CometTx {
-	result := []CometTx{}
-	for _, tx := range txs {
-		if slices.Contains(EXCLUDE_COMMAND_TYPES, tx.Command) {
-			continue
-		}
-		result = append(result, tx)
-	}
-	return result
+	return filter.Apply(txs)
 }
 
 func (c *CometClient) GetTxsForBlockNotFiltered(ctx context.Context, block int64) ([]CometTx, error) {
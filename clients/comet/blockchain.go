@@ -0,0 +1,104 @@
+package comet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// maxBlockMetasPerRequest is the maximum number of block metas returned by the /blockchain endpoint
+const maxBlockMetasPerRequest = 20
+
+type blockchainResponse struct {
+	Result struct {
+		BlockMetas []struct {
+			BlockSize string `json:"block_size"`
+			NumTxs    string `json:"num_txs"`
+			Header    struct {
+				Height          string `json:"height"`
+				Time            string `json:"time"`
+				ProposerAddress string `json:"proposer_address"`
+			} `json:"header"`
+		} `json:"block_metas"`
+	} `json:"result"`
+}
+
+// BlockMeta is the header and the size of a block.
+type BlockMeta struct {
+	Height          int64
+	Time            time.Time
+	ProposerAddress string
+	// Size is the size of the block in bytes
+	Size   int64
+	NumTxs int64
+}
+
+// GetBlockMetasRange returns the block metas from the /blockchain endpoint, ordered by height. Both blocks are
+// inclusive.
+func (c *CometClient) GetBlockMetasRange(ctx context.Context, fromBlock int64, toBlock int64) ([]BlockMeta, error) {
+	result := []BlockMeta{}
+	for batchFirstBlock := fromBlock; batchFirstBlock <= toBlock; batchFirstBlock += maxBlockMetasPerRequest {
+		batchLastBlock := min(batchFirstBlock+maxBlockMetasPerRequest-1, toBlock)
+
+		response, err := c.requestBlockchain(ctx, batchFirstBlock, batchLastBlock)
+		if err != nil {
+			return nil, err
+		}
+		metas, err := parseBlockchainResponse(response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse block metas from %d to %d: %w", batchFirstBlock, batchLastBlock, err)
+		}
+		result = append(result, metas...)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Height < result[j].Height })
+
+	return result, nil
+}
+
+func (c *CometClient) requestBlockchain(ctx context.Context, minHeight int64, maxHeight int64) (blockchainResponse, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return blockchainResponse{}, fmt.Errorf("failed rate limiter for get blockchain from %d to %d. %w", minHeight, maxHeight, err)
+	}
+
+	var payload blockchainResponse
+	if err := c.get(ctx, fmt.Sprintf("/blockchain?minHeight=%d&maxHeight=%d", minHeight, maxHeight), &payload); err != nil {
+		return blockchainResponse{}, fmt.Errorf("failed to get blockchain from %d to %d. %w", minHeight, maxHeight, err)
+	}
+
+	return payload, nil
+}
+
+func parseBlockchainResponse(response blockchainResponse) ([]BlockMeta, error) {
+	result := make([]BlockMeta, 0, len(response.Result.BlockMetas))
+	for _, meta := range response.Result.BlockMetas {
+		height, err := strconv.ParseInt(meta.Header.Height, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Height '%s' to int: %w", meta.Header.Height, err)
+		}
+		blockTime, err := time.Parse(time.RFC3339, meta.Header.Time)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Time '%s' of block %d: %w", meta.Header.Time, height, err)
+		}
+		size, err := strconv.ParseInt(meta.BlockSize, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse block size '%s' of block %d: %w", meta.BlockSize, height, err)
+		}
+		numTxs, err := strconv.ParseInt(meta.NumTxs, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse num txs '%s' of block %d: %w", meta.NumTxs, height, err)
+		}
+
+		result = append(result, BlockMeta{
+			Height:          height,
+			Time:            blockTime,
+			ProposerAddress: meta.Header.ProposerAddress,
+			Size:            size,
+			NumTxs:          numTxs,
+		})
+	}
+
+	return result, nil
+}
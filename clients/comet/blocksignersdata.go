@@ -6,11 +6,23 @@ import (
 	"time"
 )
 
+// blockIDFlagCommit marks the signature of a validator, which voted for the committed block
+const blockIDFlagCommit = 2
+
 type BlockSignersData struct {
 	Height          int64
 	Time            time.Time
 	ProposerAddress string
 	SignerAddresses []string
+	// Round is the consensus round, in which the block was committed
+	Round int32
+	Votes []CommitVote
+}
+
+// CommitVote is the precommit of a validator for the committed block.
+type CommitVote struct {
+	ValidatorAddress string
+	Timestamp        time.Time
 }
 
 func newBlockSignersData(response commitResponse) (blockSignersData BlockSignersData, err error) {
@@ -27,10 +39,26 @@ func newBlockSignersData(response commitResponse) (blockSignersData BlockSigners
 	}
 	blockSignersData.ProposerAddress = response.Result.SignedHeader.Header.ProposerAddress
 
+	blockSignersData.Round = response.Result.SignedHeader.Commit.Round
+
 	for _, signature := range response.Result.SignedHeader.Commit.Signatures {
 		if len(signature.ValidatorAddress) > 0 {
 			blockSignersData.SignerAddresses = append(blockSignersData.SignerAddresses, signature.ValidatorAddress)
 		}
+		// Votes for nil have the timestamp too, but they do not say when the block was accepted
+		if signature.BlockIDFlag != blockIDFlagCommit {
+			continue
+		}
+		var voteTime time.Time
+		voteTime, err = time.Parse(time.RFC3339, signature.Timestamp)
+		if err != nil {
+			err = fmt.Errorf("failed to parse signature Timestamp '%s' of %s, %w", signature.Timestamp, signature.ValidatorAddress, err)
+			return
+		}
+		blockSignersData.Votes = append(blockSignersData.Votes, CommitVote{
+			ValidatorAddress: signature.ValidatorAddress,
+			Timestamp:        voteTime,
+		})
 	}
 
 	return
@@ -2,9 +2,12 @@ package comet
 
 import (
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+	"code.vegaprotocol.io/vega/logging"
 	"github.com/vegaprotocol/vega-monitoring/config"
 	"golang.org/x/time/rate"
 )
@@ -14,17 +17,42 @@ type CometClient struct {
 	config             *config.CometBFTConfig
 	rateLimiter        *rate.Limiter
 	validatorByAddress map[string]ValidatorData // local cache
+	validatorsMut      sync.RWMutex
+	txFilter           atomic.Pointer[TxFilter]
+	endpoints          *endpointPool
+	log                *logging.Logger
 }
 
 func NewCometClient(config *config.CometBFTConfig) *CometClient {
-	return &CometClient{
+	client := &CometClient{
 		config:      config,
 		rateLimiter: rate.NewLimiter(rate.Every(50*time.Millisecond), 1),
 		httpClient: &http.Client{
 			Timeout: 8 * time.Second,
 		},
 		validatorByAddress: map[string]ValidatorData{},
+		endpoints:          newEndpointPool(config.Endpoints(), config.HealthCheckInterval, config.MaxLagBlocks),
 	}
+	client.SetTxFilter(config.TxFilter)
+
+	return client
+}
+
+// WithLogger logs the failovers between the endpoints.
+func (c *CometClient) WithLogger(log *logging.Logger) *CometClient {
+	c.log = log
+
+	return c
+}
+
+// SetTxFilter replaces the filter applied to the comet txs, e.g. after the config reload.
+func (c *CometClient) SetTxFilter(cfg config.CometTxFilterConfig) {
+	c.txFilter.Store(NewTxFilter(cfg))
+}
+
+// TxFilter returns the filter applied to the comet txs.
+func (c *CometClient) TxFilter() *TxFilter {
+	return c.txFilter.Load()
 }
 
 type ValidatorData struct {
@@ -0,0 +1,82 @@
+package comet
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+
+	commandspb "code.vegaprotocol.io/vega/protos/vega/commands/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// DecodedTx is a raw tx from the block decoded with the Vega protobuf command definitions. Err is set, when the tx
+// could not be decoded, e.g. a command unknown to this version or a corrupt payload.
+type DecodedTx struct {
+	Height    int64
+	HeightIdx int
+	InputData *commandspb.InputData
+	Err       error
+}
+
+// GetDecodedTxs returns the txs of the block at the given indexes, in the same order. Only these txs are decoded,
+// and a tx, which cannot be decoded, is returned with Err set, so it does not fail the other txs.
+func (c *CometClient) GetDecodedTxs(ctx context.Context, block int64, heightIdxs []int) ([]DecodedTx, error) {
+	response, err := c.requestBlock(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseBlockResponse(response, heightIdxs)
+}
+
+// DecodeTx decodes the input data of a raw Vega transaction.
+func DecodeTx(raw []byte) (*commandspb.InputData, error) {
+	tx := &commandspb.Transaction{}
+	if err := proto.Unmarshal(raw, tx); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
+	}
+
+	inputData := &commandspb.InputData{}
+	if err := proto.Unmarshal(tx.GetInputData(), inputData); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal transaction input data: %w", err)
+	}
+
+	return inputData, nil
+}
+
+func parseBlockResponse(response blockResponse, heightIdxs []int) ([]DecodedTx, error) {
+	height, err := strconv.ParseInt(response.Result.Block.Header.Height, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Height '%s' to int: %w", response.Result.Block.Header.Height, err)
+	}
+
+	encodedTxs := response.Result.Block.Data.Txs
+	result := make([]DecodedTx, 0, len(heightIdxs))
+	for _, idx := range heightIdxs {
+		tx := DecodedTx{
+			Height:    height,
+			HeightIdx: idx,
+		}
+		if idx < 0 || idx >= len(encodedTxs) {
+			tx.Err = fmt.Errorf("missing tx %d in block %d, the block has %d txs", idx, height, len(encodedTxs))
+		} else {
+			tx.InputData, tx.Err = decodeBlockTx(encodedTxs[idx])
+			if tx.Err != nil {
+				tx.Err = fmt.Errorf("failed to decode tx %d in block %d: %w", idx, height, tx.Err)
+			}
+		}
+		result = append(result, tx)
+	}
+
+	return result, nil
+}
+
+func decodeBlockTx(encodedTx string) (*commandspb.InputData, error) {
+	raw, err := base64.StdEncoding.DecodeString(encodedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64: %w", err)
+	}
+
+	return DecodeTx(raw)
+}
@@ -0,0 +1,121 @@
+package comet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	commandspb "code.vegaprotocol.io/vega/protos/vega/commands/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlockResponse(t *testing.T) {
+	testScenarios := []struct {
+		name    string
+		fixture string
+		height  int64
+		check   func(t *testing.T, txs []DecodedTx)
+	}{
+		{
+			name:    "chain event and node vote",
+			fixture: "block_chain_event_node_vote.json",
+			height:  20000001,
+			check: func(t *testing.T, txs []DecodedTx) {
+				require.Len(t, txs, 3)
+
+				// Commands without a typed table are decoded, but not used
+				assert.Nil(t, txs[0].InputData.GetChainEvent())
+				assert.Equal(t, uint64(7), txs[0].InputData.GetNonce())
+
+				event := txs[1].InputData.GetChainEvent()
+				require.NotNil(t, event)
+				assert.Equal(t, "0x6d4c1f2b2c6d1e0f8a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f", event.GetTxId())
+				assert.Equal(t, uint64(18446744073709551000), event.GetNonce())
+				assert.NotNil(t, event.GetErc20())
+
+				vote := txs[2].InputData.GetNodeVote()
+				require.NotNil(t, vote)
+				assert.Equal(t, "3c1a2b4d5e6f", vote.GetReference())
+				assert.Equal(t, commandspb.NodeVote_TYPE_FUNDS_DEPOSITED, vote.GetType())
+			},
+		},
+		{
+			name:    "state variable proposal and issue signatures",
+			fixture: "block_state_variable_issue_signatures.json",
+			height:  20000002,
+			check: func(t *testing.T, txs []DecodedTx) {
+				require.Len(t, txs, 2)
+
+				proposal := txs[0].InputData.GetStateVariableProposal().GetProposal()
+				require.NotNil(t, proposal)
+				assert.Equal(t, "market-1_probability_of_trading", proposal.GetStateVarId())
+				assert.Equal(t, "time_trigger_1710072000", proposal.GetEventId())
+				assert.Len(t, proposal.GetKvb(), 2)
+
+				signatures := txs[1].InputData.GetIssueSignatures()
+				require.NotNil(t, signatures)
+				assert.Equal(t, "0x1f2e3d4c5b6a79881726354453627180f9e8d7c6", signatures.GetSubmitter())
+				assert.Equal(t, commandspb.NodeSignatureKind_NODE_SIGNATURE_KIND_ERC20_MULTISIG_SIGNER_ADDED, signatures.GetKind())
+				assert.Equal(t, "a7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f4a3b2c1d0e9f8a7b6", signatures.GetValidatorNodeId())
+			},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join("testdata", tc.fixture))
+			require.NoError(t, err)
+
+			var response blockResponse
+			require.NoError(t, json.Unmarshal(data, &response))
+
+			heightIdxs := make([]int, 0, len(response.Result.Block.Data.Txs))
+			for idx := range response.Result.Block.Data.Txs {
+				heightIdxs = append(heightIdxs, idx)
+			}
+			txs, err := parseBlockResponse(response, heightIdxs)
+			require.NoError(t, err)
+			for idx, tx := range txs {
+				require.NoError(t, tx.Err)
+				assert.Equal(t, tc.height, tx.Height)
+				assert.Equal(t, idx, tx.HeightIdx)
+			}
+			tc.check(t, txs)
+		})
+	}
+}
+
+func TestParseBlockResponseSkipsUndecodableTxs(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "block_chain_event_node_vote.json"))
+	require.NoError(t, err)
+	var response blockResponse
+	require.NoError(t, json.Unmarshal(data, &response))
+	// Invalid base64 and invalid protobuf, e.g. a corrupt payload
+	response.Result.Block.Data.Txs = append(response.Result.Block.Data.Txs, "not base64!", "//8=")
+
+	txs, err := parseBlockResponse(response, []int{4, 1, 3, 7})
+	require.NoError(t, err)
+	require.Len(t, txs, 4)
+
+	assert.Equal(t, 4, txs[0].HeightIdx)
+	assert.Error(t, txs[0].Err)
+	assert.Nil(t, txs[0].InputData)
+
+	// Only the requested txs are decoded, and the undecodable ones do not fail the others
+	assert.Equal(t, 1, txs[1].HeightIdx)
+	require.NoError(t, txs[1].Err)
+	assert.NotNil(t, txs[1].InputData.GetChainEvent())
+
+	assert.Equal(t, 3, txs[2].HeightIdx)
+	assert.ErrorContains(t, txs[2].Err, "base64")
+
+	assert.Equal(t, 7, txs[3].HeightIdx)
+	assert.ErrorContains(t, txs[3].Err, "missing tx 7")
+}
+
+func TestDecodeTxInvalid(t *testing.T) {
+	_, err := DecodeTx([]byte{0xff, 0xff, 0xff})
+	assert.Error(t, err)
+}
@@ -0,0 +1,311 @@
+package comet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"go.uber.org/zap"
+)
+
+// EndpointStatus is the health of a CometBFT endpoint at the last check.
+type EndpointStatus struct {
+	URL          string
+	Healthy      bool
+	Preferred    bool
+	LatestHeight int64
+	LastError    string
+	CheckedAt    time.Time
+}
+
+// EndpointReporter receives the health of the endpoints and the failover events.
+type EndpointReporter interface {
+	UpdateCometEndpoints(statuses []EndpointStatus)
+	ObserveCometFailover(from string, to string)
+	ObserveCometQuorumMismatch(height int64)
+}
+
+// WithEndpointReporter reports the health of the endpoints after every check, and the failover events.
+func (c *CometClient) WithEndpointReporter(reporter EndpointReporter) *CometClient {
+	c.endpoints.mut.Lock()
+	defer c.endpoints.mut.Unlock()
+	c.endpoints.reporter = reporter
+
+	return c
+}
+
+// EndpointStatuses returns the health of the endpoints at the last check, in the order of preference.
+func (c *CometClient) EndpointStatuses() []EndpointStatus {
+	return c.endpoints.statuses()
+}
+
+type endpoint struct {
+	url          string
+	priority     int
+	healthy      bool
+	latestHeight int64
+	lastError    string
+	checkedAt    time.Time
+}
+
+// endpointPool tracks the health of the endpoints and orders them by preference. The healthy endpoints within
+// maxLag blocks of the most up to date endpoint come first, in the configured order. Then the lagging endpoints
+// by height, and the unhealthy endpoints last.
+type endpointPool struct {
+	mut           sync.Mutex
+	refreshMut    sync.Mutex
+	endpoints     []*endpoint
+	preferred     string
+	checkInterval time.Duration
+	maxLag        int64
+	lastCheck     time.Time
+	reporter      EndpointReporter
+}
+
+func newEndpointPool(urls []string, checkInterval time.Duration, maxLag int64) *endpointPool {
+	pool := &endpointPool{
+		checkInterval: checkInterval,
+		maxLag:        maxLag,
+	}
+	for idx, url := range urls {
+		pool.endpoints = append(pool.endpoints, &endpoint{url: url, priority: idx, healthy: true})
+	}
+	if len(urls) > 0 {
+		pool.preferred = urls[0]
+	}
+
+	return pool
+}
+
+// get sends the GET request to the endpoints in the order of preference, until one of them succeeds.
+func (c *CometClient) get(ctx context.Context, path string, payload any) error {
+	c.refreshEndpoints(ctx)
+
+	var errs []error
+	for _, url := range c.endpoints.ordered() {
+		err := c.getFrom(ctx, url, path, payload)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+		if ctx.Err() != nil {
+			break
+		}
+		c.endpoints.markFailed(url, err, c.log)
+	}
+
+	if len(errs) == 0 {
+		return errors.New("no CometBFT endpoint configured")
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *CometClient) getFrom(ctx context.Context, url string, path string, payload any) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request for %s: %w", url+path, err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s: %w", url+path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to request %s: unexpected status code %d", url+path, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(payload); err != nil {
+		return fmt.Errorf("failed to parse response of %s: %w", url+path, err)
+	}
+
+	return nil
+}
+
+// refreshEndpoints checks the status of all the endpoints, when the last check is older than the check interval.
+// A single endpoint is never checked, as there is no other endpoint to fail over to.
+func (c *CometClient) refreshEndpoints(ctx context.Context) {
+	pool := c.endpoints
+	if len(pool.endpoints) < 2 {
+		return
+	}
+	// Requests do not wait for the check running in parallel
+	if !pool.refreshMut.TryLock() {
+		return
+	}
+	defer pool.refreshMut.Unlock()
+
+	pool.mut.Lock()
+	due := time.Since(pool.lastCheck) >= pool.checkInterval
+	pool.mut.Unlock()
+	if !due {
+		return
+	}
+
+	type result struct {
+		height int64
+		err    error
+	}
+	results := make([]result, len(pool.endpoints))
+	var wg sync.WaitGroup
+	for idx, e := range pool.endpoints {
+		wg.Add(1)
+		go func(idx int, url string) {
+			defer wg.Done()
+			var payload statusResponseRaw
+			if err := c.getFrom(ctx, url, "/status", &payload); err != nil {
+				results[idx] = result{err: err}
+				return
+			}
+			if payload.Result.SyncInfo.CatchingUp {
+				results[idx] = result{err: errors.New("node is catching up")}
+				return
+			}
+			status, err := parseStatusResponse(payload)
+			if err != nil {
+				results[idx] = result{err: err}
+				return
+			}
+			results[idx] = result{height: status.SyncInfo.LatestBlockHeight}
+		}(idx, e.url)
+	}
+	wg.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	pool.mut.Lock()
+	now := time.Now()
+	for idx, e := range pool.endpoints {
+		e.checkedAt = now
+		e.healthy = results[idx].err == nil
+		e.lastError = ""
+		if results[idx].err != nil {
+			e.lastError = results[idx].err.Error()
+		} else {
+			e.latestHeight = results[idx].height
+		}
+	}
+	pool.lastCheck = now
+	pool.mut.Unlock()
+
+	pool.updatePreferred("endpoint health check", c.log)
+}
+
+// markFailed moves the endpoint to the end of the order until the next health check. A single endpoint is
+// always used, so its state is not tracked.
+func (p *endpointPool) markFailed(url string, err error, log *logging.Logger) {
+	if len(p.endpoints) < 2 {
+		return
+	}
+
+	p.mut.Lock()
+	for _, e := range p.endpoints {
+		if e.url == url {
+			e.healthy = false
+			e.lastError = err.Error()
+		}
+	}
+	p.mut.Unlock()
+
+	p.updatePreferred("request failed", log)
+}
+
+// updatePreferred reports the failover, when the most preferred endpoint has changed.
+func (p *endpointPool) updatePreferred(reason string, log *logging.Logger) {
+	ordered := p.ordered()
+
+	p.mut.Lock()
+	from := p.preferred
+	to := ""
+	if len(ordered) > 0 {
+		to = ordered[0]
+	}
+	p.preferred = to
+	reporter := p.reporter
+	p.mut.Unlock()
+
+	if from != to {
+		if log != nil {
+			log.Warn("CometBFT endpoint failover", zap.String("from", from), zap.String("to", to), zap.String("reason", reason))
+		}
+		if reporter != nil {
+			reporter.ObserveCometFailover(from, to)
+		}
+	}
+	if reporter != nil {
+		reporter.UpdateCometEndpoints(p.statuses())
+	}
+}
+
+// ordered returns the urls of the endpoints in the order of preference.
+func (p *endpointPool) ordered() []string {
+	sorted := p.sorted()
+	urls := make([]string, 0, len(sorted))
+	for _, e := range sorted {
+		urls = append(urls, e.url)
+	}
+
+	return urls
+}
+
+func (p *endpointPool) statuses() []EndpointStatus {
+	sorted := p.sorted()
+	result := make([]EndpointStatus, 0, len(sorted))
+	for idx, e := range sorted {
+		result = append(result, EndpointStatus{
+			URL:          e.url,
+			Healthy:      e.healthy,
+			Preferred:    idx == 0,
+			LatestHeight: e.latestHeight,
+			LastError:    e.lastError,
+			CheckedAt:    e.checkedAt,
+		})
+	}
+
+	return result
+}
+
+// sorted returns copies of the endpoints in the order of preference.
+func (p *endpointPool) sorted() []endpoint {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	var bestHeight int64
+	sorted := make([]endpoint, 0, len(p.endpoints))
+	for _, e := range p.endpoints {
+		if e.healthy {
+			bestHeight = max(bestHeight, e.latestHeight)
+		}
+		sorted = append(sorted, *e)
+	}
+
+	rank := func(e endpoint) int {
+		switch {
+		case !e.healthy:
+			return 2
+		case bestHeight-e.latestHeight > p.maxLag:
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		rankI, rankJ := rank(sorted[i]), rank(sorted[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+		if rankI == 1 && sorted[i].latestHeight != sorted[j].latestHeight {
+			return sorted[i].latestHeight > sorted[j].latestHeight
+		}
+		return sorted[i].priority < sorted[j].priority
+	})
+
+	return sorted
+}
@@ -0,0 +1,109 @@
+package comet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+func TestEndpointPoolOrdered(t *testing.T) {
+	type state struct {
+		healthy bool
+		height  int64
+	}
+
+	testScenarios := []struct {
+		name     string
+		states   []state
+		expected []string
+	}{
+		{
+			name:     "all healthy keeps configured order",
+			states:   []state{{true, 100}, {true, 98}, {true, 101}},
+			expected: []string{"a", "b", "c"},
+		},
+		{
+			name:     "unhealthy last",
+			states:   []state{{false, 100}, {true, 100}, {true, 100}},
+			expected: []string{"b", "c", "a"},
+		},
+		{
+			name:     "lagging after up to date",
+			states:   []state{{true, 90}, {true, 80}, {true, 100}},
+			expected: []string{"c", "a", "b"},
+		},
+		{
+			name:     "unhealthy height is ignored",
+			states:   []state{{true, 90}, {false, 200}, {true, 94}},
+			expected: []string{"a", "c", "b"},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			pool := newEndpointPool([]string{"a", "b", "c"}, 0, 5)
+			for idx, s := range tc.states {
+				pool.endpoints[idx].healthy = s.healthy
+				pool.endpoints[idx].latestHeight = s.height
+			}
+			assert.Equal(t, tc.expected, pool.ordered())
+		})
+	}
+}
+
+func TestCompareQuorumHashes(t *testing.T) {
+	failed := errors.New("connection refused")
+
+	testScenarios := []struct {
+		name     string
+		hashes   []string
+		errs     []error
+		errorIs  error
+		errorMsg string
+	}{
+		{
+			name:   "all agree",
+			hashes: []string{"AA", "AA", "AA"},
+			errs:   []error{nil, nil, nil},
+		},
+		{
+			name:   "enough agree",
+			hashes: []string{"AA", "", "AA"},
+			errs:   []error{nil, failed, nil},
+		},
+		{
+			name:    "mismatch",
+			hashes:  []string{"AA", "BB", "AA"},
+			errs:    []error{nil, nil, nil},
+			errorIs: ErrQuorumMismatch,
+		},
+		{
+			name:     "not enough responses",
+			hashes:   []string{"AA", "", ""},
+			errs:     []error{nil, failed, failed},
+			errorMsg: "only 1 of 2 required endpoints responded",
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewCometClient(&config.CometBFTConfig{
+				ApiURL:  "a",
+				ApiURLs: []string{"b", "c"},
+				Quorum:  config.CometQuorumConfig{Enabled: true, MinEndpoints: 2},
+			})
+			err := client.compareQuorumHashes(10, []string{"a", "b", "c"}, tc.hashes, tc.errs)
+			switch {
+			case tc.errorIs != nil:
+				assert.ErrorIs(t, err, tc.errorIs)
+			case len(tc.errorMsg) > 0:
+				assert.ErrorContains(t, err, tc.errorMsg)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package comet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrQuorumMismatch = errors.New("CometBFT endpoints returned different block hashes")
+
+// QuorumEnabled returns true, when the block hashes are cross-checked between the endpoints.
+func (c *CometClient) QuorumEnabled() bool {
+	return c.config.Quorum.Enabled
+}
+
+// CheckQuorum requests the commit of the block from all the endpoints, and checks they agree on the block hash.
+// The block hashes chain the blocks, so the agreement on the last block of a range covers the whole range.
+func (c *CometClient) CheckQuorum(ctx context.Context, block int64) error {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return fmt.Errorf("failed rate limiter for quorum check for block: %d. %w", block, err)
+	}
+
+	endpoints := c.config.Endpoints()
+	hashes := make([]string, len(endpoints))
+	errs := make([]error, len(endpoints))
+
+	var wg sync.WaitGroup
+	for idx, url := range endpoints {
+		wg.Add(1)
+		go func(idx int, url string) {
+			defer wg.Done()
+			var payload commitResponse
+			if err := c.getFrom(ctx, url, commitPath(block), &payload); err != nil {
+				errs[idx] = err
+				return
+			}
+			hashes[idx] = payload.Result.SignedHeader.Commit.BlockID.Hash
+		}(idx, url)
+	}
+	wg.Wait()
+
+	return c.compareQuorumHashes(block, endpoints, hashes, errs)
+}
+
+func (c *CometClient) compareQuorumHashes(block int64, endpoints []string, hashes []string, errs []error) error {
+	votes := map[string][]string{}
+	for idx, hash := range hashes {
+		if errs[idx] == nil && len(hash) > 0 {
+			votes[hash] = append(votes[hash], endpoints[idx])
+		}
+	}
+
+	if len(votes) > 1 {
+		c.endpoints.mut.Lock()
+		reporter := c.endpoints.reporter
+		c.endpoints.mut.Unlock()
+		if reporter != nil {
+			reporter.ObserveCometQuorumMismatch(block)
+		}
+		return fmt.Errorf("%w for block %d: %v", ErrQuorumMismatch, block, votes)
+	}
+
+	responses := 0
+	for _, urls := range votes {
+		responses = len(urls)
+	}
+	if responses < c.config.Quorum.MinEndpoints {
+		return fmt.Errorf(
+			"quorum check for block %d failed, only %d of %d required endpoints responded: %w",
+			block, responses, c.config.Quorum.MinEndpoints, errors.Join(errs...),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package comet
+
+import (
+	"context"
+	"fmt"
+)
+
+type blockResponse struct {
+	Result struct {
+		Block struct {
+			Header struct {
+				Height string `json:"height"`
+				Time   string `json:"time"`
+			} `json:"header"`
+			Data struct {
+				// Txs are base64 encoded
+				Txs []string `json:"txs"`
+			} `json:"data"`
+		} `json:"block"`
+	} `json:"result"`
+}
+
+func (c *CometClient) requestBlock(ctx context.Context, block int64) (blockResponse, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return blockResponse{}, fmt.Errorf("failed rate limiter for get block: %d. %w", block, err)
+	}
+
+	path := "/block"
+	if block > 0 {
+		path = fmt.Sprintf("/block?height=%d", block)
+	}
+
+	var payload blockResponse
+	if err := c.get(ctx, path, &payload); err != nil {
+		return blockResponse{}, fmt.Errorf("failed to get block: %d. %w", block, err)
+	}
+
+	return payload, nil
+}
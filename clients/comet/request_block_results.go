@@ -2,9 +2,7 @@ package comet
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"sync"
 
 	"github.com/hashicorp/go-multierror"
@@ -42,24 +40,14 @@ func (c *CometClient) requestBlockResults(ctx context.Context, block int64) (blo
 		return blockResultsResponse{}, fmt.Errorf("failed rate limiter for get block results for block: %d. %w", block, err)
 	}
 
-	url := fmt.Sprintf("%s/block_results", c.config.ApiURL)
+	path := "/block_results"
 	if block > 0 {
-		url = fmt.Sprintf("%s/block_results?height=%d", c.config.ApiURL, block)
-	}
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return blockResultsResponse{}, fmt.Errorf("faailed to create request for %s: %w", url, err)
+		path = fmt.Sprintf("/block_results?height=%d", block)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return blockResultsResponse{}, fmt.Errorf("failed to get block results for block: %d. %w", block, err)
-	}
-	defer resp.Body.Close()
-
 	var payload blockResultsResponse
-	if err = json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return blockResultsResponse{}, fmt.Errorf("failed to parse response for get block results for block: %d. %w", block, err)
+	if err := c.get(ctx, path, &payload); err != nil {
+		return blockResultsResponse{}, fmt.Errorf("failed to get block results for block: %d. %w", block, err)
 	}
 
 	return payload, nil
@@ -2,9 +2,7 @@ package comet
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"sync"
 
 	"github.com/hashicorp/go-multierror"
@@ -19,8 +17,13 @@ type commitResponse struct {
 				ProposerAddress string `json:"proposer_address"`
 			} `json:"header"`
 			Commit struct {
-				Height     string `json:"height"`
+				Height  string `json:"height"`
+				Round   int32  `json:"round"`
+				BlockID struct {
+					Hash string `json:"hash"`
+				} `json:"block_id"`
 				Signatures []struct {
+					BlockIDFlag      int    `json:"block_id_flag"`
 					ValidatorAddress string `json:"validator_address"`
 					Timestamp        string `json:"timestamp"`
 				} `json:"signatures"`
@@ -33,29 +36,21 @@ func (c *CometClient) requestCommit(ctx context.Context, block int64) (commitRes
 	if err := c.rateLimiter.Wait(ctx); err != nil {
 		return commitResponse{}, fmt.Errorf("Failed rate limiter for Get Commit Data for block: %d. %w", block, err)
 	}
-	url := fmt.Sprintf("%s/commit", c.config.ApiURL)
-	if block > 0 {
-		url = fmt.Sprintf("%s/commit?height=%d", c.config.ApiURL, block)
-	}
-
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return commitResponse{}, fmt.Errorf("failed to create request for url %s: %w", url, err)
-	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return commitResponse{}, fmt.Errorf("Failed to Get Commit Data for block: %d. %w", block, err)
-	}
-	defer resp.Body.Close()
 	var payload commitResponse
-	if err = json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return commitResponse{}, fmt.Errorf("Failed to parse response for Get Commit Data for block: %d. %w", block, err)
+	if err := c.get(ctx, commitPath(block), &payload); err != nil {
+		return commitResponse{}, fmt.Errorf("Failed to Get Commit Data for block: %d. %w", block, err)
 	}
 
 	return payload, nil
 }
 
+func commitPath(block int64) string {
+	if block > 0 {
+		return fmt.Sprintf("/commit?height=%d", block)
+	}
+	return "/commit"
+}
+
 func (c *CometClient) requestCommitRange(ctx context.Context, startBlock int64, endBlock int64) ([]commitResponse, error) {
 	var mut sync.Mutex
 	result := []commitResponse{}
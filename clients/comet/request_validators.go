@@ -2,11 +2,11 @@ package comet
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 )
 
+const validatorsPerPage = 100
+
 type validatorsResponse struct {
 	Result struct {
 		BlockHeight string `json:"block_height"`
@@ -28,23 +28,15 @@ func (c *CometClient) requestValidators(ctx context.Context, block int64) (valid
 	if err := c.rateLimiter.Wait(context.Background()); err != nil {
 		return validatorsResponse{}, fmt.Errorf("Failed rate limiter for Get Validators for block: %d. %w", block, err)
 	}
-	url := fmt.Sprintf("%s/validators", c.config.ApiURL)
+	// The default page size is 30, request the max page size to get the whole validator set
+	path := fmt.Sprintf("/validators?per_page=%d", validatorsPerPage)
 	if block > 0 {
-		url = fmt.Sprintf("%s/validators?height=%d", c.config.ApiURL, block)
-	}
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return validatorsResponse{}, fmt.Errorf("failed to create validators request for %s url: %w", url, err)
+		path = fmt.Sprintf("/validators?height=%d&per_page=%d", block, validatorsPerPage)
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return validatorsResponse{}, fmt.Errorf("Failed to Get Validators for block: %d. %w", block, err)
-	}
-	defer resp.Body.Close()
 	var payload validatorsResponse
-	if err = json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return validatorsResponse{}, fmt.Errorf("Failed to parse response for Get Validators for block: %d. %w", block, err)
+	if err := c.get(ctx, path, &payload); err != nil {
+		return validatorsResponse{}, fmt.Errorf("Failed to Get Validators for block: %d. %w", block, err)
 	}
 
 	return payload, nil
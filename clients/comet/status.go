@@ -2,9 +2,7 @@ package comet
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"net/http"
 	"strconv"
 	"time"
 )
@@ -48,18 +46,15 @@ func (c *CometClient) Status(ctx context.Context) (*StatusResponse, error) {
 		return nil, fmt.Errorf("rate limiter failed to wait for get status: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/status", c.config.ApiURL)
-	resp, err := http.Get(url)
-	if err != nil {
+	var payload statusResponseRaw
+	if err := c.get(ctx, "/status", &payload); err != nil {
 		return nil, fmt.Errorf("failed to response for the /status comet endpoint:%w", err)
 	}
-	defer resp.Body.Close()
 
-	var payload statusResponseRaw
-	if err = json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-		return nil, fmt.Errorf("failed to parse response for the /status comet endpoint: %w", err)
-	}
+	return parseStatusResponse(payload)
+}
 
+func parseStatusResponse(payload statusResponseRaw) (*StatusResponse, error) {
 	latestBlockHeight, err := strconv.ParseInt(payload.Result.SyncInfo.LatestBlockHeight, 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse latest block height for the /status comet endpoint: %w", err)
@@ -0,0 +1,135 @@
+package comet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	newBlockQuery = "tm.event='NewBlock'"
+	// streamReadTimeout closes the stream, when no block has been received for this long
+	streamReadTimeout = 30 * time.Second
+)
+
+// NewBlockFunc is called for every block received from the stream.
+type NewBlockFunc func(height int64)
+
+type subscribeRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	ID      int    `json:"id"`
+	Params  struct {
+		Query string `json:"query"`
+	} `json:"params"`
+}
+
+type newBlockEvent struct {
+	Result struct {
+		Query string `json:"query"`
+		Data  struct {
+			Value struct {
+				Block struct {
+					Header struct {
+						Height string `json:"height"`
+					} `json:"header"`
+				} `json:"block"`
+			} `json:"value"`
+		} `json:"data"`
+	} `json:"result"`
+	Error *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Data    string `json:"data"`
+	} `json:"error"`
+}
+
+// SubscribeNewBlocks subscribes to NewBlock events over the CometBFT websocket, and calls onBlock for every block.
+// It blocks until the context is cancelled or the stream fails. The stream fails, when no block is received within
+// streamReadTimeout.
+func (c *CometClient) SubscribeNewBlocks(ctx context.Context, onBlock NewBlockFunc) error {
+	url := c.websocketURL()
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", url, err)
+	}
+	defer conn.Close()
+
+	// Unblock the read, when the context is cancelled
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	request := subscribeRequest{JSONRPC: "2.0", Method: "subscribe", ID: 1}
+	request.Params.Query = newBlockQuery
+	if err := conn.WriteJSON(request); err != nil {
+		return fmt.Errorf("failed to subscribe to new blocks: %w", err)
+	}
+
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(streamReadTimeout)); err != nil {
+			return fmt.Errorf("failed to set read deadline: %w", err)
+		}
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to read from the stream: %w", err)
+		}
+
+		height, ok, err := parseNewBlockEvent(message)
+		if err != nil {
+			return err
+		}
+		if ok {
+			onBlock(height)
+		}
+	}
+}
+
+// parseNewBlockEvent returns the height of the block in the event. ok is false for other messages, e.g. the
+// confirmation of the subscription.
+func parseNewBlockEvent(message []byte) (height int64, ok bool, err error) {
+	var event newBlockEvent
+	if err := json.Unmarshal(message, &event); err != nil {
+		return 0, false, fmt.Errorf("failed to parse stream message: %w", err)
+	}
+	if event.Error != nil {
+		return 0, false, fmt.Errorf("stream error %d: %s %s", event.Error.Code, event.Error.Message, event.Error.Data)
+	}
+	if event.Result.Query != newBlockQuery {
+		return 0, false, nil
+	}
+
+	height, err = strconv.ParseInt(event.Result.Data.Value.Block.Header.Height, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse Height '%s' to int: %w", event.Result.Data.Value.Block.Header.Height, err)
+	}
+
+	return height, true, nil
+}
+
+// websocketURL returns the configured websocket endpoint, or the /websocket endpoint of the preferred endpoint.
+func (c *CometClient) websocketURL() string {
+	if len(c.config.Stream.WebsocketURL) > 0 {
+		return c.config.Stream.WebsocketURL
+	}
+
+	url := ""
+	if endpoints := c.endpoints.ordered(); len(endpoints) > 0 {
+		url = endpoints[0]
+	}
+	switch {
+	case strings.HasPrefix(url, "https://"):
+		url = "wss://" + strings.TrimPrefix(url, "https://")
+	case strings.HasPrefix(url, "http://"):
+		url = "ws://" + strings.TrimPrefix(url, "http://")
+	}
+
+	return url + "/websocket"
+}
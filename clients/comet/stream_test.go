@@ -0,0 +1,76 @@
+package comet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+func TestParseNewBlockEvent(t *testing.T) {
+	testScenarios := []struct {
+		name     string
+		message  string
+		height   int64
+		ok       bool
+		errorMsg string
+	}{
+		{
+			name:    "subscription confirmation",
+			message: `{"jsonrpc":"2.0","id":1,"result":{}}`,
+		},
+		{
+			name:    "new block",
+			message: `{"jsonrpc":"2.0","id":1,"result":{"query":"tm.event='NewBlock'","data":{"type":"tendermint/event/NewBlock","value":{"block":{"header":{"height":"20000001"}}}}}}`,
+			height:  20000001,
+			ok:      true,
+		},
+		{
+			name:     "error",
+			message:  `{"jsonrpc":"2.0","id":1,"error":{"code":-32603,"message":"Internal error","data":"max_subscriptions_per_client 5 reached"}}`,
+			errorMsg: "max_subscriptions_per_client 5 reached",
+		},
+		{
+			name:     "invalid height",
+			message:  `{"jsonrpc":"2.0","id":1,"result":{"query":"tm.event='NewBlock'","data":{"value":{"block":{"header":{"height":"x"}}}}}}`,
+			errorMsg: "failed to parse Height 'x' to int",
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			height, ok, err := parseNewBlockEvent([]byte(tc.message))
+			if len(tc.errorMsg) > 0 {
+				assert.ErrorContains(t, err, tc.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.height, height)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestWebsocketURL(t *testing.T) {
+	testScenarios := []struct {
+		name   string
+		config config.CometBFTConfig
+		url    string
+	}{
+		{name: "http", config: config.CometBFTConfig{ApiURL: "http://localhost:26657"}, url: "ws://localhost:26657/websocket"},
+		{name: "https with path", config: config.CometBFTConfig{ApiURL: "https://example.com/comet/"}, url: "wss://example.com/comet/websocket"},
+		{
+			name:   "configured",
+			config: config.CometBFTConfig{ApiURL: "http://localhost:26657", Stream: config.CometStreamConfig{WebsocketURL: "ws://other:26657/websocket"}},
+			url:    "ws://other:26657/websocket",
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewCometClient(&tc.config)
+			assert.Equal(t, tc.url, client.websocketURL())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package comet
+
+import (
+	"strings"
+)
+
+// Result codes of the Vega ABCI application
+const (
+	AbciTxnOK                     = 0
+	AbciTxnValidationFailure      = 51
+	AbciTxnDecodingFailure        = 60
+	AbciTxnInternalError          = 70
+	AbciTxnPartialProcessingError = 71
+	AbciUnknownCommandError       = 80
+	AbciSpamError                 = 89
+)
+
+type TxErrorCategory string
+
+const (
+	TxErrorNone                TxErrorCategory = ""
+	TxErrorSpamRejection       TxErrorCategory = "spam_rejection"
+	TxErrorPoWFailure          TxErrorCategory = "pow_failure"
+	TxErrorInvalidSignature    TxErrorCategory = "invalid_signature"
+	TxErrorInsufficientBalance TxErrorCategory = "insufficient_balance"
+	TxErrorDecodingFailure     TxErrorCategory = "decoding_failure"
+	TxErrorUnknownCommand      TxErrorCategory = "unknown_command"
+	TxErrorPartialProcessing   TxErrorCategory = "partial_processing"
+	TxErrorInternalError       TxErrorCategory = "internal_error"
+	TxErrorValidationFailure   TxErrorCategory = "validation_failure"
+	TxErrorOther               TxErrorCategory = "other"
+)
+
+// txErrorInfoPatterns are checked in order against the lower-cased info of the failed tx,
+// so more specific patterns go first. The patterns match the error strings of the Vega core,
+// e.g. "invalid tx signature", the InsufficientAssetBalance order error or "insufficient funds to pay fees"
+var txErrorInfoPatterns = []struct {
+	pattern  string
+	category TxErrorCategory
+}{
+	{pattern: "proof of work", category: TxErrorPoWFailure},
+	{pattern: "proof-of-work", category: TxErrorPoWFailure},
+	{pattern: "invalid signature", category: TxErrorInvalidSignature},
+	{pattern: "invalid tx signature", category: TxErrorInvalidSignature},
+	{pattern: "insufficientassetbalance", category: TxErrorInsufficientBalance},
+	{pattern: "insufficient_asset_balance", category: TxErrorInsufficientBalance},
+	{pattern: "insufficient funds", category: TxErrorInsufficientBalance},
+	{pattern: "not enough funds", category: TxErrorInsufficientBalance},
+	{pattern: "spam", category: TxErrorSpamRejection},
+}
+
+// ClassifyTxError returns the error category of the tx from its result code and info.
+// The info is checked first, as the same code is used for different errors. TxErrorNone is
+// returned for successful txs.
+func ClassifyTxError(code int, info *string) TxErrorCategory {
+	if code == AbciTxnOK {
+		return TxErrorNone
+	}
+
+	if info != nil {
+		lowerInfo := strings.ToLower(*info)
+		for _, p := range txErrorInfoPatterns {
+			if strings.Contains(lowerInfo, p.pattern) {
+				return p.category
+			}
+		}
+	}
+
+	switch code {
+	case AbciSpamError:
+		return TxErrorSpamRejection
+	case AbciTxnDecodingFailure:
+		return TxErrorDecodingFailure
+	case AbciUnknownCommandError:
+		return TxErrorUnknownCommand
+	case AbciTxnPartialProcessingError:
+		return TxErrorPartialProcessing
+	case AbciTxnInternalError:
+		return TxErrorInternalError
+	case AbciTxnValidationFailure:
+		return TxErrorValidationFailure
+	}
+
+	return TxErrorOther
+}
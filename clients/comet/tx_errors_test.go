@@ -0,0 +1,42 @@
+package comet_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+)
+
+func TestClassifyTxError(t *testing.T) {
+	info := func(s string) *string { return &s }
+
+	testScenarios := []struct {
+		name     string
+		code     int
+		info     *string
+		category comet.TxErrorCategory
+	}{
+		{name: "success", code: 0, info: info("anything"), category: comet.TxErrorNone},
+		{name: "spam code", code: 89, category: comet.TxErrorSpamRejection},
+		{name: "spam info", code: 51, info: info("Party is banned from submitting transactions: spam policy"), category: comet.TxErrorSpamRejection},
+		{name: "pow failure", code: 89, info: info("failed to verify proof of work"), category: comet.TxErrorPoWFailure},
+		{name: "invalid signature", code: 60, info: info("invalid tx signature"), category: comet.TxErrorInvalidSignature},
+		{name: "insufficient balance", code: 51, info: info("InsufficientAssetBalance"), category: comet.TxErrorInsufficientBalance},
+		{name: "insufficient funds", code: 51, info: info("insufficient funds to pay fees"), category: comet.TxErrorInsufficientBalance},
+		{name: "not enough funds", code: 51, info: info("not enough funds to transfer"), category: comet.TxErrorInsufficientBalance},
+		{name: "unrelated signature error", code: 51, info: info("signature bundle not found"), category: comet.TxErrorValidationFailure},
+		{name: "unrelated balance error", code: 51, info: info("balance account does not exist"), category: comet.TxErrorValidationFailure},
+		{name: "decoding failure", code: 60, info: info("unable to decode"), category: comet.TxErrorDecodingFailure},
+		{name: "unknown command", code: 80, category: comet.TxErrorUnknownCommand},
+		{name: "partial processing", code: 71, category: comet.TxErrorPartialProcessing},
+		{name: "internal error", code: 70, info: info(""), category: comet.TxErrorInternalError},
+		{name: "validation failure", code: 51, info: info("market does not exist"), category: comet.TxErrorValidationFailure},
+		{name: "unknown code", code: 1, category: comet.TxErrorOther},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.category, comet.ClassifyTxError(tc.code, tc.info))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package comet
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+// TxFilterIncludeAll in the Include list stores all command types, except the excluded ones.
+const TxFilterIncludeAll = config.CometTxFilterIncludeAll
+
+// TxFilter selects the comet txs, which are stored, and their attributes.
+type TxFilter struct {
+	config     config.CometTxFilterConfig
+	attributes map[string]map[string]struct{} // map[command]map[attribute-key]
+}
+
+// NewTxFilter creates the filter from the config. When both Include and Exclude are empty,
+// the config.DefaultCometTxExclude list is used.
+func NewTxFilter(cfg config.CometTxFilterConfig) *TxFilter {
+	effective := config.CometTxFilterConfig{
+		Include: sortedUnique(cfg.Include),
+		Exclude: sortedUnique(cfg.Exclude),
+	}
+	if len(effective.Include) == 0 && len(effective.Exclude) == 0 {
+		effective.Exclude = sortedUnique(config.DefaultCometTxExclude)
+	}
+	for _, attrs := range cfg.Attributes {
+		effective.Attributes = append(effective.Attributes, config.CometTxAttributes{
+			Command: attrs.Command,
+			Keys:    sortedUnique(attrs.Keys),
+		})
+	}
+	slices.SortFunc(effective.Attributes, func(a, b config.CometTxAttributes) int {
+		return strings.Compare(a.Command, b.Command)
+	})
+
+	filter := &TxFilter{
+		config:     effective,
+		attributes: map[string]map[string]struct{}{},
+	}
+	for _, attrs := range effective.Attributes {
+		keys, ok := filter.attributes[attrs.Command]
+		if !ok {
+			keys = map[string]struct{}{}
+			filter.attributes[attrs.Command] = keys
+		}
+		for _, key := range attrs.Keys {
+			keys[key] = struct{}{}
+		}
+	}
+
+	return filter
+}
+
+// Config returns the effective config of the filter, with sorted lists.
+func (f *TxFilter) Config() config.CometTxFilterConfig {
+	return f.config
+}
+
+// Hash identifies the filter. Filters with the same effective config have the same hash.
+func (f *TxFilter) Hash() string {
+	data, _ := json.Marshal(f.config)
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+// Keep returns true if txs with the command type are stored.
+func (f *TxFilter) Keep(command string) bool {
+	return f.config.Keeps(command)
+}
+
+// Apply returns the txs kept by the filter, with only the allowed attributes.
+func (f *TxFilter) Apply(txs []CometTx) []CometTx {
+	result := []CometTx{}
+	for _, tx := range txs {
+		if !f.Keep(tx.Command) {
+			continue
+		}
+		if keys, ok := f.attributes[tx.Command]; ok && tx.Attributes != nil {
+			attributes := map[string]string{}
+			for key, value := range tx.Attributes {
+				if _, ok := keys[key]; ok {
+					attributes[key] = value
+				}
+			}
+			if len(attributes) == 0 {
+				attributes = nil
+			}
+			tx.Attributes = attributes
+		}
+		result = append(result, tx)
+	}
+
+	return result
+}
+
+func sortedUnique(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	result := slices.Clone(values)
+	slices.Sort(result)
+
+	return slices.Compact(result)
+}
@@ -0,0 +1,109 @@
+package comet_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+func TestTxFilter(t *testing.T) {
+	txs := []comet.CometTx{
+		{Command: "Submit Order", HeightIdx: 0},
+		{Command: "Chain Event", HeightIdx: 1, Attributes: map[string]string{"block": "1", "tx_hash": "0x1"}},
+		{Command: "Transfer Funds", HeightIdx: 2},
+	}
+
+	testScenarios := []struct {
+		name       string
+		config     config.CometTxFilterConfig
+		commands   []string
+		attributes map[string]string
+	}{
+		{
+			name:       "default exclude list",
+			config:     config.CometTxFilterConfig{},
+			commands:   []string{"Chain Event"},
+			attributes: map[string]string{"block": "1", "tx_hash": "0x1"},
+		},
+		{
+			name:       "include",
+			config:     config.CometTxFilterConfig{Include: []string{"Transfer Funds", "Chain Event"}},
+			commands:   []string{"Chain Event", "Transfer Funds"},
+			attributes: map[string]string{"block": "1", "tx_hash": "0x1"},
+		},
+		{
+			name:       "include all except excluded",
+			config:     config.CometTxFilterConfig{Include: []string{"*"}, Exclude: []string{"Submit Order"}},
+			commands:   []string{"Chain Event", "Transfer Funds"},
+			attributes: map[string]string{"block": "1", "tx_hash": "0x1"},
+		},
+		{
+			name: "attributes allow-list",
+			config: config.CometTxFilterConfig{
+				Include:    []string{"Chain Event"},
+				Attributes: []config.CometTxAttributes{{Command: "Chain Event", Keys: []string{"tx_hash"}}},
+			},
+			commands:   []string{"Chain Event"},
+			attributes: map[string]string{"tx_hash": "0x1"},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			result := comet.NewTxFilter(tc.config).Apply(txs)
+
+			commands := []string{}
+			for _, tx := range result {
+				commands = append(commands, tx.Command)
+				if tx.Command == "Chain Event" {
+					assert.Equal(t, tc.attributes, tx.Attributes)
+				}
+			}
+			assert.Equal(t, tc.commands, commands)
+		})
+	}
+
+	t.Run("include with the default config", func(t *testing.T) {
+		// The example from the README must store all the included commands
+		cfg := config.NewDefaultConfig().CometBFT.TxFilter
+		cfg.Include = []string{"Chain Event", "Transfer Funds", "Submit Oracle Data"}
+		filter := comet.NewTxFilter(cfg)
+
+		for _, command := range cfg.Include {
+			assert.True(t, filter.Keep(command), command)
+		}
+		assert.False(t, filter.Keep("Submit Order"))
+	})
+
+	t.Run("same hash for the same effective config", func(t *testing.T) {
+		assert.Equal(t,
+			comet.NewTxFilter(config.CometTxFilterConfig{}).Hash(),
+			comet.NewTxFilter(config.CometTxFilterConfig{Exclude: config.DefaultCometTxExclude}).Hash(),
+		)
+	})
+}
+
+func TestTxFilterKeep(t *testing.T) {
+	testScenarios := []struct {
+		name    string
+		config  config.CometTxFilterConfig
+		command string
+		keep    bool
+	}{
+		{name: "default exclude list keeps", config: config.CometTxFilterConfig{}, command: "Validator Heartbeat", keep: true},
+		{name: "default exclude list drops", config: config.CometTxFilterConfig{}, command: "Submit Order", keep: false},
+		{name: "not included", config: config.CometTxFilterConfig{Include: []string{"Chain Event"}}, command: "Validator Heartbeat", keep: false},
+		{name: "include all", config: config.CometTxFilterConfig{Include: []string{"*"}}, command: "Submit Order", keep: true},
+		{name: "excluded", config: config.CometTxFilterConfig{Exclude: []string{"Validator Heartbeat"}}, command: "Validator Heartbeat", keep: false},
+		{name: "exclude replaces the default list", config: config.CometTxFilterConfig{Exclude: []string{"Chain Event"}}, command: "Submit Order", keep: true},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.keep, tc.config.Keeps(tc.command))
+			assert.Equal(t, tc.keep, comet.NewTxFilter(tc.config).Keep(tc.command))
+		})
+	}
+}
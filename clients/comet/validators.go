@@ -9,23 +9,33 @@ import (
 )
 
 func (c *CometClient) GetValidatorForAddressAtBlock(ctx context.Context, address string, block int64) (*ValidatorData, error) {
-	if val, ok := c.validatorByAddress[address]; ok {
+	if val, ok := c.cachedValidator(address); ok {
 		return &val, nil
 	}
 	if err := c.pullMoreValidatorData(ctx, block); err != nil {
 		return nil, fmt.Errorf("failed to get validator data for %s address, failed to pull more validator data, %w", address, err)
 	}
-	if val, ok := c.validatorByAddress[address]; ok {
+	if val, ok := c.cachedValidator(address); ok {
 		return &val, nil
 	}
 	return nil, fmt.Errorf("failed to get validator data for %s address", address)
 }
 
+func (c *CometClient) cachedValidator(address string) (ValidatorData, bool) {
+	c.validatorsMut.RLock()
+	defer c.validatorsMut.RUnlock()
+
+	val, ok := c.validatorByAddress[address]
+	return val, ok
+}
+
 func (c *CometClient) pullMoreValidatorData(ctx context.Context, block int64) error {
 	response, err := c.requestValidators(ctx, block)
 	if err != nil {
 		return err
 	}
+	c.validatorsMut.Lock()
+	defer c.validatorsMut.Unlock()
 	for _, val := range response.Result.Validators {
 		if _, ok := c.validatorByAddress[val.Address]; !ok {
 			c.validatorByAddress[val.Address] = ValidatorData{
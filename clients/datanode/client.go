@@ -21,6 +21,10 @@ type DataNodeClient struct {
 	httpClient  *http.Client
 	apiURL      string
 	rateLimiter *rate.Limiter
+
+	// segmentsHTTPClient has no timeout, because the list of segments is large. The requests are bounded by
+	// the context instead.
+	segmentsHTTPClient *http.Client
 }
 
 func NewDataNodeClient(apiURL string) *DataNodeClient {
@@ -30,5 +34,6 @@ func NewDataNodeClient(apiURL string) *DataNodeClient {
 		httpClient: &http.Client{
 			Timeout: 2 * time.Second,
 		},
+		segmentsHTTPClient: &http.Client{},
 	}
 }
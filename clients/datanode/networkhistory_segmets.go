@@ -14,6 +14,8 @@ type NetworkHistorySegment struct {
 	Height    int64  `db:"height"`
 	SegmentId string `db:"segment_id"`
 	DataNode  string `db:"data_node"`
+	// VegaTime is the time of the block at the height. It is set only for the segments read from the store
+	VegaTime time.Time `db:"vega_time"`
 }
 
 func (c *DataNodeClient) GetNetworkHistorySegments(ctx context.Context, fromBlock, toBlock int64) ([]*NetworkHistorySegment, error) {
@@ -61,12 +63,18 @@ func (c *DataNodeClient) requestNetworkHistorySegments(ctx context.Context) (net
 	if err := c.rateLimiter.Wait(innerCtx); err != nil {
 		return networkHistorySegmentsResponse{}, errors.Join(errWaitingForRateLimiter, fmt.Errorf("failed to get network history segments for %s: %w", c.apiURL, err))
 	}
-	url := fmt.Sprintf(networkHistorySegmentURL, c.apiURL)
-	resp, err := http.Get(url)
+	req, err := http.NewRequestWithContext(innerCtx, http.MethodGet, fmt.Sprintf(networkHistorySegmentURL, c.apiURL), nil)
+	if err != nil {
+		return networkHistorySegmentsResponse{}, fmt.Errorf("failed to create new request with context: %w", err)
+	}
+	resp, err := c.segmentsHTTPClient.Do(req)
 	if err != nil {
 		return networkHistorySegmentsResponse{}, fmt.Errorf("failed to call get for network history segments to %s: %w", c.apiURL, err)
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return networkHistorySegmentsResponse{}, fmt.Errorf("invalid response code for network history segments request to %s: expected %d, got %d", c.apiURL, http.StatusOK, resp.StatusCode)
+	}
 	var payload networkHistorySegmentsResponse
 	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
 		return networkHistorySegmentsResponse{}, fmt.Errorf("failed to unmarshal http request for %s: %w", c.apiURL, err)
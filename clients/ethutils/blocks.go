@@ -0,0 +1,73 @@
+package ethutils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// BlockNumberAtTime returns the number of the last block with the time not after at, found by a binary search
+// over the block headers. fromBlock is a lower bound of the result, e.g. the result for an earlier time, which
+// narrows the search. The node must be an archival node to find old blocks.
+func (c *EthClient) BlockNumberAtTime(ctx context.Context, at time.Time, fromBlock uint64) (uint64, error) {
+	latest, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest block header: %w", err)
+	}
+	target := uint64(at.Unix())
+	if latest.Time <= target {
+		return latest.Number.Uint64(), nil
+	}
+
+	low := min(fromBlock, latest.Number.Uint64())
+	lowTime, err := c.headerTime(ctx, low)
+	if err != nil {
+		return 0, err
+	}
+	if lowTime > target && low > 0 {
+		low = 0
+		if lowTime, err = c.headerTime(ctx, low); err != nil {
+			return 0, err
+		}
+	}
+	if lowTime > target {
+		return 0, fmt.Errorf("time %s is before the first block", at)
+	}
+
+	return searchBlockAtTime(ctx, low, latest.Number.Uint64(), target, c.headerTime)
+}
+
+func (c *EthClient) headerTime(ctx context.Context, number uint64) (uint64, error) {
+	header, err := c.client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get header of block %d: %w", number, err)
+	}
+
+	return header.Time, nil
+}
+
+// searchBlockAtTime returns the last block in [low, high) with the time not after target. The time of low must
+// not be after target, and the time of high must be after target.
+func searchBlockAtTime(
+	ctx context.Context,
+	low uint64,
+	high uint64,
+	target uint64,
+	headerTime func(ctx context.Context, number uint64) (uint64, error),
+) (uint64, error) {
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		midTime, err := headerTime(ctx, mid)
+		if err != nil {
+			return 0, err
+		}
+		if midTime <= target {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+
+	return low, nil
+}
@@ -0,0 +1,39 @@
+package ethutils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchBlockAtTime(t *testing.T) {
+	// Blocks every 12 seconds, with a missed slot after block 5
+	blockTimes := []uint64{1000, 1012, 1024, 1036, 1048, 1060, 1084, 1096, 1108, 1120}
+	headerTime := func(_ context.Context, number uint64) (uint64, error) {
+		return blockTimes[number], nil
+	}
+
+	testScenarios := []struct {
+		name     string
+		low      uint64
+		target   uint64
+		expected uint64
+	}{
+		{name: "exact block time", target: 1048, expected: 4},
+		{name: "between blocks", target: 1050, expected: 4},
+		{name: "in the missed slot", target: 1080, expected: 5},
+		{name: "first block", target: 1005, expected: 0},
+		{name: "before the last block", target: 1119, expected: 8},
+		{name: "from the lower bound", low: 6, target: 1100, expected: 7},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := searchBlockAtTime(context.Background(), tc.low, uint64(len(blockTimes)-1), tc.target, headerTime)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+package ethutils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/ethutils/IERC20"
+)
+
+// DefaultMulticall3Address is the address of the Multicall3 contract, which is deployed at the same address
+// on most EVM chains.
+const DefaultMulticall3Address = "0xcA11bde05977b3631167028862bE2a173976CA11"
+
+const multicall3ABI = `[{
+	"inputs": [{
+		"components": [
+			{"internalType": "address", "name": "target", "type": "address"},
+			{"internalType": "bool", "name": "allowFailure", "type": "bool"},
+			{"internalType": "bytes", "name": "callData", "type": "bytes"}
+		],
+		"internalType": "struct Multicall3.Call3[]", "name": "calls", "type": "tuple[]"
+	}],
+	"name": "aggregate3",
+	"outputs": [{
+		"components": [
+			{"internalType": "bool", "name": "success", "type": "bool"},
+			{"internalType": "bytes", "name": "returnData", "type": "bytes"}
+		],
+		"internalType": "struct Multicall3.Result[]", "name": "returnData", "type": "tuple[]"
+	}],
+	"stateMutability": "payable",
+	"type": "function"
+}]`
+
+var (
+	multicall3ABIObject = mustParseABI(multicall3ABI)
+	erc20ABIObject      = mustParseABI(IERC20.IERC20MetaData.ABI)
+)
+
+func mustParseABI(definition string) abi.ABI {
+	result, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid abi: %s", err))
+	}
+	return result
+}
+
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// TokenBalance is the balance of the token read by the multicall. Err is set, when the balanceOf call of the
+// token failed.
+type TokenBalance struct {
+	TokenAddress string
+	Balance      *big.Int
+	Err          error
+}
+
+// GetAssetPoolBalancesForTokens reads the balances of all tokens in the asset pool with a single Multicall3
+// aggregate3 call at the latest block, so the balances are a snapshot of one block. It returns the balances in
+// the order of the tokens, and the number of the block.
+func (c *EthClient) GetAssetPoolBalancesForTokens(
+	ctx context.Context,
+	hexTokenAddresses []string,
+	assetPoolAddress string,
+	multicallAddress string,
+) ([]TokenBalance, uint64, error) {
+	blockNumber, err := c.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get block number: %w", err)
+	}
+
+	balances, err := c.GetAssetPoolBalancesForTokensAtBlock(ctx, hexTokenAddresses, assetPoolAddress, multicallAddress, blockNumber)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return balances, blockNumber, nil
+}
+
+// GetAssetPoolBalancesForTokensAtBlock reads the balances of all tokens in the asset pool with a single Multicall3
+// aggregate3 call at the given block. Reading old blocks requires an archival node, and the Multicall3 contract
+// deployed at the block.
+func (c *EthClient) GetAssetPoolBalancesForTokensAtBlock(
+	ctx context.Context,
+	hexTokenAddresses []string,
+	assetPoolAddress string,
+	multicallAddress string,
+	blockNumber uint64,
+) ([]TokenBalance, error) {
+	callData, err := packBalanceOfAggregate3(hexTokenAddresses, assetPoolAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	multicall := common.HexToAddress(multicallAddress)
+	response, err := c.client.CallContract(ctx, ethereum.CallMsg{
+		To:   &multicall,
+		Data: callData,
+	}, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call multicall %s at block %d: %w", multicallAddress, blockNumber, err)
+	}
+
+	balances, err := unpackBalanceOfAggregate3(hexTokenAddresses, response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read multicall %s response at block %d: %w", multicallAddress, blockNumber, err)
+	}
+
+	return balances, nil
+}
+
+// packBalanceOfAggregate3 returns the call data of the aggregate3 call with balanceOf(holder) of every token.
+// A failing token does not fail the other tokens.
+func packBalanceOfAggregate3(hexTokenAddresses []string, holderAddress string) ([]byte, error) {
+	balanceOfData, err := erc20ABIObject.Pack("balanceOf", common.HexToAddress(holderAddress))
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack balanceOf: %w", err)
+	}
+
+	calls := make([]multicall3Call, 0, len(hexTokenAddresses))
+	for _, tokenAddress := range hexTokenAddresses {
+		calls = append(calls, multicall3Call{
+			Target:       common.HexToAddress(tokenAddress),
+			AllowFailure: true,
+			CallData:     balanceOfData,
+		})
+	}
+
+	data, err := multicall3ABIObject.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack aggregate3: %w", err)
+	}
+
+	return data, nil
+}
+
+// unpackBalanceOfAggregate3 returns the balances from the response of the aggregate3 call packed by
+// packBalanceOfAggregate3.
+func unpackBalanceOfAggregate3(hexTokenAddresses []string, response []byte) ([]TokenBalance, error) {
+	var results []multicall3Result
+	if err := multicall3ABIObject.UnpackIntoInterface(&results, "aggregate3", response); err != nil {
+		return nil, fmt.Errorf("failed to unpack aggregate3: %w", err)
+	}
+	if len(results) != len(hexTokenAddresses) {
+		return nil, fmt.Errorf("expected %d results, got %d", len(hexTokenAddresses), len(results))
+	}
+
+	balances := make([]TokenBalance, 0, len(results))
+	for idx, result := range results {
+		balance := TokenBalance{TokenAddress: hexTokenAddresses[idx]}
+		if !result.Success {
+			balance.Err = fmt.Errorf("balanceOf call for token %s failed", balance.TokenAddress)
+			balances = append(balances, balance)
+			continue
+		}
+
+		values, err := erc20ABIObject.Unpack("balanceOf", result.ReturnData)
+		if err != nil {
+			balance.Err = fmt.Errorf("failed to unpack balanceOf for token %s: %w", balance.TokenAddress, err)
+			balances = append(balances, balance)
+			continue
+		}
+		value, ok := values[0].(*big.Int)
+		if !ok {
+			balance.Err = fmt.Errorf("invalid balanceOf response for token %s", balance.TokenAddress)
+			balances = append(balances, balance)
+			continue
+		}
+
+		balance.Balance = value
+		balances = append(balances, balance)
+	}
+
+	return balances, nil
+}
@@ -0,0 +1,211 @@
+package ethutils
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testAssetPool = "0xA226E2A13e07e750EfBD2E5839C5c3Be80fE7D4d"
+	testTokenA    = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	testTokenB    = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	// testTokenC has no balanceOf, so its call fails in the multicall.
+	testTokenC = "0x0000000000000000000000000000000000000c0c"
+)
+
+type testCallArgs struct {
+	To    *common.Address `json:"to"`
+	Data  hexutil.Bytes   `json:"data"`
+	Input hexutil.Bytes   `json:"input"`
+}
+
+// testEthNode is the eth namespace of a node with the Multicall3 contract deployed, which serves the balances of
+// the asset pool per block.
+type testEthNode struct {
+	mu        sync.Mutex
+	head      uint64
+	multicall common.Address
+	balances  map[uint64]map[common.Address]*big.Int
+	callAt    []uint64
+}
+
+func (n *testEthNode) BlockNumber() hexutil.Uint64 {
+	return hexutil.Uint64(n.head)
+}
+
+func (n *testEthNode) Call(args testCallArgs, blockNumber string) (hexutil.Bytes, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	block, err := hexutil.DecodeUint64(blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("call must be pinned to a block number, got %q", blockNumber)
+	}
+	n.callAt = append(n.callAt, block)
+
+	balances, ok := n.balances[block]
+	if !ok {
+		return nil, fmt.Errorf("missing trie node for block %d", block)
+	}
+	if args.To == nil || *args.To != n.multicall {
+		// No contract at the address, so the call returns no data.
+		return hexutil.Bytes{}, nil
+	}
+
+	input := args.Data
+	if len(input) == 0 {
+		input = args.Input
+	}
+	method, err := multicall3ABIObject.MethodById(input)
+	if err != nil || method.Name != "aggregate3" {
+		return nil, fmt.Errorf("execution reverted")
+	}
+	values, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, err
+	}
+	var calls []multicall3Call
+	if err := method.Inputs.Copy(&calls, values); err != nil {
+		return nil, err
+	}
+
+	results := make([]multicall3Result, 0, len(calls))
+	for _, call := range calls {
+		balance, ok := balances[call.Target]
+		if !ok {
+			results = append(results, multicall3Result{Success: false})
+			continue
+		}
+		holder, err := erc20ABIObject.Methods["balanceOf"].Inputs.Unpack(call.CallData[4:])
+		if err != nil {
+			return nil, err
+		}
+		if holder[0].(common.Address) != common.HexToAddress(testAssetPool) {
+			balance = big.NewInt(0)
+		}
+		returnData, err := erc20ABIObject.Methods["balanceOf"].Outputs.Pack(balance)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, multicall3Result{Success: true, ReturnData: returnData})
+	}
+
+	return method.Outputs.Pack(results)
+}
+
+func newTestEthNode(t *testing.T) (*testEthNode, *EthClient) {
+	t.Helper()
+
+	node := &testEthNode{
+		head:      20,
+		multicall: common.HexToAddress(DefaultMulticall3Address),
+		balances: map[uint64]map[common.Address]*big.Int{
+			19: {
+				common.HexToAddress(testTokenA): big.NewInt(1900),
+				common.HexToAddress(testTokenB): big.NewInt(19),
+			},
+			20: {
+				common.HexToAddress(testTokenA): big.NewInt(2000),
+				common.HexToAddress(testTokenB): big.NewInt(20),
+			},
+		},
+	}
+
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("eth", node))
+	rpcClient := rpc.DialInProc(server)
+	t.Cleanup(func() {
+		rpcClient.Close()
+		server.Stop()
+	})
+
+	return node, &EthClient{
+		log:    logging.NewTestLogger(),
+		client: ethclient.NewClient(rpcClient),
+	}
+}
+
+func TestGetAssetPoolBalancesForTokens(t *testing.T) {
+	node, client := newTestEthNode(t)
+	tokens := []string{testTokenA, testTokenC, testTokenB}
+
+	balances, blockNumber, err := client.GetAssetPoolBalancesForTokens(context.Background(), tokens, testAssetPool, DefaultMulticall3Address)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(20), blockNumber)
+	assert.Equal(t, []uint64{20}, node.callAt, "the multicall must be pinned to the block it reports")
+	require.Len(t, balances, len(tokens))
+	assert.Equal(t, testTokenA, balances[0].TokenAddress)
+	assert.Equal(t, big.NewInt(2000), balances[0].Balance)
+	assert.NoError(t, balances[0].Err)
+	assert.Equal(t, testTokenC, balances[1].TokenAddress)
+	assert.Nil(t, balances[1].Balance)
+	assert.Error(t, balances[1].Err)
+	assert.Equal(t, testTokenB, balances[2].TokenAddress)
+	assert.Equal(t, big.NewInt(20), balances[2].Balance)
+	assert.NoError(t, balances[2].Err)
+}
+
+func TestGetAssetPoolBalancesForTokensAtBlock(t *testing.T) {
+	tokens := []string{testTokenA, testTokenB, testTokenC}
+
+	testScenarios := []struct {
+		name        string
+		blockNumber uint64
+		multicall   string
+		expectedErr bool
+		balances    []*big.Int
+		failed      []bool
+	}{
+		{
+			name:        "balances at the pinned block",
+			blockNumber: 19,
+			multicall:   DefaultMulticall3Address,
+			balances:    []*big.Int{big.NewInt(1900), big.NewInt(19), nil},
+			failed:      []bool{false, false, true},
+		},
+		{
+			name:        "block not available on the node",
+			blockNumber: 5,
+			multicall:   DefaultMulticall3Address,
+			expectedErr: true,
+		},
+		{
+			name:        "no multicall contract at the address",
+			blockNumber: 20,
+			multicall:   "0x0000000000000000000000000000000000000001",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			node, client := newTestEthNode(t)
+
+			balances, err := client.GetAssetPoolBalancesForTokensAtBlock(context.Background(), tokens, testAssetPool, tc.multicall, tc.blockNumber)
+			assert.Equal(t, []uint64{tc.blockNumber}, node.callAt)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, balances, len(tokens))
+			for idx, balance := range balances {
+				assert.Equal(t, tokens[idx], balance.TokenAddress)
+				assert.Equal(t, tc.balances[idx], balance.Balance)
+				assert.Equal(t, tc.failed[idx], balance.Err != nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package ethutils
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackBalanceOfAggregate3(t *testing.T) {
+	tokens := []string{
+		"0xdAC17F958D2ee523a2206206994597C13D831ec7",
+		"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+	}
+	assetPool := "0xA226E2A13e07e750EfBD2E5839C5c3Be80fE7D4d"
+
+	data, err := packBalanceOfAggregate3(tokens, assetPool)
+	require.NoError(t, err)
+
+	method := multicall3ABIObject.Methods["aggregate3"]
+	assert.Equal(t, method.ID, data[:4])
+
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(t, err)
+	var calls []multicall3Call
+	require.NoError(t, method.Inputs.Copy(&calls, args))
+	require.Len(t, calls, len(tokens))
+
+	balanceOfData, err := erc20ABIObject.Pack("balanceOf", common.HexToAddress(assetPool))
+	require.NoError(t, err)
+	for idx, call := range calls {
+		assert.Equal(t, common.HexToAddress(tokens[idx]), call.Target)
+		assert.True(t, call.AllowFailure)
+		assert.Equal(t, balanceOfData, call.CallData)
+	}
+}
+
+func TestUnpackBalanceOfAggregate3(t *testing.T) {
+	tokens := []string{"0x01", "0x02", "0x03"}
+	balanceData, err := erc20ABIObject.Methods["balanceOf"].Outputs.Pack(big.NewInt(123456789))
+	require.NoError(t, err)
+
+	testScenarios := []struct {
+		name        string
+		results     []multicall3Result
+		expectedErr bool
+		balances    []*big.Int
+		failed      []bool
+	}{
+		{
+			name: "all calls succeed or fail independently",
+			results: []multicall3Result{
+				{Success: true, ReturnData: balanceData},
+				{Success: false},
+				{Success: true, ReturnData: []byte{}},
+			},
+			balances: []*big.Int{big.NewInt(123456789), nil, nil},
+			failed:   []bool{false, true, true},
+		},
+		{
+			name:        "number of results does not match the tokens",
+			results:     []multicall3Result{{Success: true, ReturnData: balanceData}},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := multicall3ABIObject.Methods["aggregate3"].Outputs.Pack(tc.results)
+			require.NoError(t, err)
+
+			balances, err := unpackBalanceOfAggregate3(tokens, response)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, balances, len(tokens))
+			for idx, balance := range balances {
+				assert.Equal(t, tokens[idx], balance.TokenAddress)
+				assert.Equal(t, tc.balances[idx], balance.Balance)
+				assert.Equal(t, tc.failed[idx], balance.Err != nil)
+			}
+		})
+	}
+}
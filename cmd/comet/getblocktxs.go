@@ -13,6 +13,7 @@ import (
 
 type GetBlockTxsArgs struct {
 	*CometArgs
+	TxFilterArgs
 	Block int64
 }
 
@@ -34,6 +35,7 @@ var getBlockTxsCmd = &cobra.Command{
 func init() {
 	CometCmd.AddCommand(getBlockTxsCmd)
 	getBlockTxsArgs.CometArgs = &cometArgs
+	getBlockTxsArgs.TxFilterArgs.addFlags(getBlockTxsCmd)
 
 	getBlockTxsCmd.PersistentFlags().Int64Var(&getBlockTxsArgs.Block, "block", 0, "Number of block to get data for (0 last block)")
 }
@@ -54,6 +56,9 @@ func RunGetBlockTxs(args GetBlockTxsArgs) error {
 	if err != nil {
 		return err
 	}
+	if filter := args.txFilter(cfg); filter != nil {
+		txsList = filter.Apply(txsList)
+	}
 
 	byteTxsList, err := json.MarshalIndent(txsList, "", "\t")
 	if err != nil {
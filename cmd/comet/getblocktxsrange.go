@@ -14,6 +14,7 @@ import (
 
 type GetBlockTxsRangeArgs struct {
 	*CometArgs
+	TxFilterArgs
 	FromBlock int64
 	ToBlock   int64
 }
@@ -36,6 +37,7 @@ var getBlockTxsRangeCmd = &cobra.Command{
 func init() {
 	CometCmd.AddCommand(getBlockTxsRangeCmd)
 	getBlockTxsRangeArgs.CometArgs = &cometArgs
+	getBlockTxsRangeArgs.TxFilterArgs.addFlags(getBlockTxsRangeCmd)
 
 	getBlockTxsRangeCmd.PersistentFlags().Int64Var(&getBlockTxsRangeArgs.FromBlock, "from-block", 1, "First block to get")
 	if err := getBlockTxsRangeCmd.MarkPersistentFlagRequired("from-block"); err != nil {
@@ -59,10 +61,13 @@ func RunGetBlockTxsRange(args GetBlockTxsRangeArgs) error {
 		return fmt.Errorf("Required --api-url flag or config.toml file")
 	}
 
-	txsList, err := client.GetTxsForBlockRange(context.Background(), args.FromBlock, args.ToBlock)
+	txsList, err := client.GetTxsForBlockRangeNotFiltered(context.Background(), args.FromBlock, args.ToBlock)
 	if err != nil {
 		return err
 	}
+	if filter := args.txFilter(cfg); filter != nil {
+		txsList = filter.Apply(txsList)
+	}
 
 	byteTxsList, err := json.MarshalIndent(txsList, "", "\t")
 	if err != nil {
@@ -2,7 +2,9 @@ package comet
 
 import (
 	"github.com/spf13/cobra"
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
 	rootCmd "github.com/vegaprotocol/vega-monitoring/cmd"
+	"github.com/vegaprotocol/vega-monitoring/config"
 )
 
 type CometArgs struct {
@@ -10,6 +12,39 @@ type CometArgs struct {
 	ApiURL string
 }
 
+// TxFilterArgs override the CometBFT.TxFilter config in the commands returning txs.
+type TxFilterArgs struct {
+	Include  []string
+	Exclude  []string
+	NoFilter bool
+}
+
+func (args *TxFilterArgs) addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSliceVar(&args.Include, "include", nil, "Command types to return, \"*\" for all. Overrides CometBFT.TxFilter.Include and Exclude")
+	cmd.PersistentFlags().StringSliceVar(&args.Exclude, "exclude", nil, "Command types not to return. Overrides CometBFT.TxFilter.Include and Exclude")
+	cmd.PersistentFlags().BoolVar(&args.NoFilter, "no-filter", false, "Return all txs with all attributes")
+}
+
+// txFilter returns the filter from the config, if there is one, overridden by the flags.
+// It returns nil when the filter is disabled.
+func (args *TxFilterArgs) txFilter(cfg *config.Config) *comet.TxFilter {
+	if args.NoFilter {
+		return nil
+	}
+
+	filterConfig := config.CometTxFilterConfig{}
+	if cfg != nil {
+		filterConfig = cfg.CometBFT.TxFilter
+	}
+	// The flags replace both lists of the config, so an explicit Include is not limited by the configured Exclude
+	if args.Include != nil || args.Exclude != nil {
+		filterConfig.Include = args.Include
+		filterConfig.Exclude = args.Exclude
+	}
+
+	return comet.NewTxFilter(filterConfig)
+}
+
 var cometArgs CometArgs
 
 var CometCmd = &cobra.Command{
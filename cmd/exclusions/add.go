@@ -0,0 +1,97 @@
+package exclusions
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vegaprotocol/vega-monitoring/cmd"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type AddArgs struct {
+	*ExclusionsArgs
+	Kind      string
+	ID        string
+	Network   string
+	Reason    string
+	Author    string
+	ExpiresAt string
+}
+
+var addArgs AddArgs
+
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Exclude a withdrawal or a deposit from the network balances",
+	Long:  `Exclude a withdrawal or a deposit from the network balances. An existing exclusion with the same kind, id and network is replaced`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := RunAdd(addArgs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ExclusionsCmd.AddCommand(addCmd)
+	addArgs.ExclusionsArgs = &exclusionsArgs
+
+	addCmd.PersistentFlags().StringVar(&addArgs.Kind, "kind", "", "Kind of the excluded entry: WITHDRAWAL or DEPOSIT")
+	addCmd.PersistentFlags().StringVar(&addArgs.ID, "id", "", "Hex encoded id of the withdrawal or the deposit")
+	addCmd.PersistentFlags().StringVar(&addArgs.Network, "network", "", "Chain ID of the Vega network the exclusion applies to. All networks when empty")
+	addCmd.PersistentFlags().StringVar(&addArgs.Reason, "reason", "", "Why the entry is excluded")
+	addCmd.PersistentFlags().StringVar(&addArgs.Author, "author", os.Getenv("USER"), "Who excluded the entry")
+	addCmd.PersistentFlags().StringVar(&addArgs.ExpiresAt, "expires-at", "", "Time in RFC3339 format, after which the exclusion is ignored. Never expires when empty")
+}
+
+func RunAdd(args AddArgs) error {
+	exclusion := entities.BalanceExclusion{
+		Kind:   entities.BalanceExclusionKind(strings.ToUpper(args.Kind)),
+		ID:     strings.ToLower(strings.TrimPrefix(args.ID, "0x")),
+		Reason: args.Reason,
+		Author: args.Author,
+	}
+	if err := exclusion.Kind.IsValid(); err != nil {
+		return err
+	}
+	if _, err := hex.DecodeString(exclusion.ID); err != nil || len(exclusion.ID) == 0 {
+		return fmt.Errorf("--id must be a hex encoded id, got '%s'", args.ID)
+	}
+	if len(exclusion.Reason) == 0 {
+		return errors.New("--reason must not be empty")
+	}
+	if len(exclusion.Author) == 0 {
+		return errors.New("--author must not be empty")
+	}
+	if len(args.Network) > 0 {
+		exclusion.Network = &args.Network
+	}
+	if len(args.ExpiresAt) > 0 {
+		expiresAt, err := time.Parse(time.RFC3339, args.ExpiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to parse --expires-at: %w", err)
+		}
+		exclusion.ExpiresAt = &expiresAt
+	}
+
+	svc, err := cmd.SetupServices(args.ConfigFilePath, args.Debug)
+	if err != nil {
+		return err
+	}
+	if svc.StoreService == nil {
+		return errors.New("DataNodeDBExtension must be enabled to manage balance exclusions")
+	}
+
+	if err := svc.StoreService.NewBalanceExclusions().Add(context.Background(), exclusion); err != nil {
+		return err
+	}
+	fmt.Printf("Excluded %s %s from the network balances\n", exclusion.Kind, exclusion.ID)
+
+	return nil
+}
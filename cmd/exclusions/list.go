@@ -0,0 +1,72 @@
+package exclusions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/vegaprotocol/vega-monitoring/cmd"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print withdrawals and deposits excluded from the network balances",
+	Long:  `Print withdrawals and deposits excluded from the network balances, including the expired exclusions`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := RunList(exclusionsArgs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ExclusionsCmd.AddCommand(listCmd)
+}
+
+func RunList(args ExclusionsArgs) error {
+	svc, err := cmd.SetupServices(args.ConfigFilePath, args.Debug)
+	if err != nil {
+		return err
+	}
+	if svc.StoreService == nil {
+		return errors.New("DataNodeDBExtension must be enabled to manage balance exclusions")
+	}
+
+	exclusions, err := svc.StoreService.NewBalanceExclusions().List(context.Background())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tID\tNETWORK\tAUTHOR\tCREATED AT\tEXPIRES AT\tREASON\t")
+	for _, exclusion := range exclusions {
+		network := "all"
+		if exclusion.Network != nil {
+			network = *exclusion.Network
+		}
+		expiresAt := "never"
+		if exclusion.ExpiresAt != nil {
+			expiresAt = exclusion.ExpiresAt.Format(time.RFC3339)
+			if exclusion.ExpiresAt.Before(now) {
+				expiresAt += " (expired)"
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t\n",
+			exclusion.Kind,
+			exclusion.ID,
+			network,
+			exclusion.Author,
+			exclusion.CreatedAt.Format(time.RFC3339),
+			expiresAt,
+			exclusion.Reason,
+		)
+	}
+
+	return w.Flush()
+}
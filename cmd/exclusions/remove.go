@@ -0,0 +1,78 @@
+package exclusions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/vegaprotocol/vega-monitoring/cmd"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type RemoveArgs struct {
+	*ExclusionsArgs
+	Kind    string
+	ID      string
+	Network string
+}
+
+var removeArgs RemoveArgs
+
+var removeCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Include an excluded withdrawal or deposit in the network balances again",
+	Long:  `Include an excluded withdrawal or deposit in the network balances again`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := RunRemove(removeArgs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ExclusionsCmd.AddCommand(removeCmd)
+	removeArgs.ExclusionsArgs = &exclusionsArgs
+
+	removeCmd.PersistentFlags().StringVar(&removeArgs.Kind, "kind", "", "Kind of the excluded entry: WITHDRAWAL or DEPOSIT")
+	removeCmd.PersistentFlags().StringVar(&removeArgs.ID, "id", "", "Hex encoded id of the withdrawal or the deposit")
+	removeCmd.PersistentFlags().StringVar(&removeArgs.Network, "network", "", "Chain ID of the Vega network of the exclusion. The exclusion for all networks when empty")
+}
+
+func RunRemove(args RemoveArgs) error {
+	kind := entities.BalanceExclusionKind(strings.ToUpper(args.Kind))
+	if err := kind.IsValid(); err != nil {
+		return err
+	}
+	id := strings.ToLower(strings.TrimPrefix(args.ID, "0x"))
+	if len(id) == 0 {
+		return errors.New("--id must not be empty")
+	}
+
+	svc, err := cmd.SetupServices(args.ConfigFilePath, args.Debug)
+	if err != nil {
+		return err
+	}
+	if svc.StoreService == nil {
+		return errors.New("DataNodeDBExtension must be enabled to manage balance exclusions")
+	}
+
+	var network *string
+	if len(args.Network) > 0 {
+		network = &args.Network
+	}
+
+	removed, err := svc.StoreService.NewBalanceExclusions().Remove(context.Background(), kind, id, network)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("%s %s is not excluded", kind, id)
+	}
+	fmt.Printf("Removed the exclusion of %s %s\n", kind, id)
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package exclusions
+
+import (
+	"github.com/spf13/cobra"
+	rootCmd "github.com/vegaprotocol/vega-monitoring/cmd"
+)
+
+type ExclusionsArgs struct {
+	*rootCmd.RootArgs
+}
+
+var exclusionsArgs ExclusionsArgs
+
+var ExclusionsCmd = &cobra.Command{
+	Use:   "balance-exclusions",
+	Short: "Manage withdrawals and deposits excluded from the network balances",
+	Long:  `Manage withdrawals and deposits excluded from the network balances. The exclusions are stored in the metrics.balance_exclusions table of the data-node database`,
+}
+
+func init() {
+	exclusionsArgs.RootArgs = &rootCmd.Args
+}
@@ -7,6 +7,7 @@ import (
 	"code.vegaprotocol.io/vega/logging"
 	"github.com/spf13/cobra"
 	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/scraper"
 	"github.com/vegaprotocol/vega-monitoring/sqlstore"
 )
 
@@ -39,7 +40,13 @@ func setupDB(logger *logging.Logger, cfg *config.Config) error {
 		return fmt.Errorf("failed to migrate to the latest schema: %w", err)
 	}
 
-	if err := sqlstore.SetRetentionPolicies(cfg.SQLStore.GetConnectionConfig(), cfg.DataNodeDBExtension.BaseRetentionPolicy, cfg.DataNodeDBExtension.RetentionPolicy, logger); err != nil {
+	if err := sqlstore.SetRetentionPolicies(
+		cfg.SQLStore.GetConnectionConfig(),
+		cfg.DataNodeDBExtension.BaseRetentionPolicy,
+		cfg.DataNodeDBExtension.RetentionPolicy,
+		logger,
+		scraper.RetentionTables()...,
+	); err != nil {
 		return fmt.Errorf("failed to set retention policies: %w", err)
 	}
 
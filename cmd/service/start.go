@@ -6,14 +6,19 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"reflect"
 	"sync"
 	"syscall"
 	"time"
 
+	"code.vegaprotocol.io/vega/logging"
 	"github.com/spf13/cobra"
 	"github.com/vegaprotocol/vega-monitoring/cmd"
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/entities"
 	"github.com/vegaprotocol/vega-monitoring/metamonitoring"
 	"github.com/vegaprotocol/vega-monitoring/pprof"
+	"github.com/vegaprotocol/vega-monitoring/scraper"
 	"go.uber.org/zap"
 )
 
@@ -52,9 +57,34 @@ func startService(args StartArgs) {
 		log.Fatalf("Failed to setup Services %+v\n", err)
 	}
 
-	if svc.Config.DataNodeDBExtension.Enabled {
+	if err := svc.Config.Validate(); err != nil {
+		log.Fatalf("Invalid config %+v\n", err)
+	}
+	if err := scraper.ValidateConfig(svc.Config); err != nil {
+		log.Fatalf("Invalid config %+v\n", err)
+	}
+
+	if svc.Config.DataNodeDBExtension.Enabled && svc.LeaderElection != nil {
+		svc.Log.Info("Starting DataNode DB Extension services when elected as leader", zap.Bool("HighAvailability.Enabled", true))
+		shutdown_wg.Add(1)
+		go func() {
+			defer shutdown_wg.Done()
+			svc.LeaderElection.Run(ctx, func(leaderCtx context.Context) error {
+				var leaderWg sync.WaitGroup
+				svc.Log.Info("Elected as leader, starting DataNode DB Extension services")
+				if err := startDataNodeDBExtension(&svc, &leaderWg, leaderCtx); err != nil {
+					return err
+				}
+				leaderWg.Wait()
+				svc.Log.Info("Stopped DataNode DB Extension services")
+				return nil
+			})
+		}()
+	} else if svc.Config.DataNodeDBExtension.Enabled {
 		svc.Log.Debug("Starting DataNode DB Extension services", zap.Bool("DataNodeDBExtension.Enabled", true))
-		startDataNodeDBExtension(&svc, &shutdown_wg, ctx)
+		if err := startDataNodeDBExtension(&svc, &shutdown_wg, ctx); err != nil {
+			log.Fatalf("Failed to start DataNode DB Extension services %+v\n", err)
+		}
 	} else {
 		svc.Log.Info("Not starting DataNode DB Extension services", zap.Bool("DataNodeDBExtension.Enabled", false))
 	}
@@ -78,12 +108,36 @@ func startService(args StartArgs) {
 			if err != nil {
 				svc.Log.Fatal("Failed to create the health-check service", zap.Error(err))
 			}
+			if svc.LeaderElection != nil {
+				healthCheckSvc.WithRole(svc.LeaderElection)
+			}
+			if svc.Config.DataNodeDBExtension.Enabled {
+				healthCheckSvc.WithScrapers(func() []entities.MonitoringServiceType {
+					services := scraper.EnabledServices(config.Current())
+					if svc.Config.CometBFT.Stream.Enabled {
+						services = append(services, entities.CometStreamSvc)
+					}
+					return services
+				})
+			}
 			if err := healthCheckSvc.Run(ctx, svc.Config.HealthCheck.Port); err != nil {
 				svc.Log.Fatal("Failed to run the health check service", zap.Error(err))
 			}
 		}()
 	}
 
+	if svc.AdminService != nil {
+		shutdown_wg.Add(1)
+		go func() {
+			defer shutdown_wg.Done()
+			svc.Log.Info("Starting the admin service", zap.Bool("Admin.Enabled", true))
+			if err := svc.AdminService.Run(ctx); err != nil {
+				svc.Log.Error("Failed to run the admin service", zap.Error(err))
+				cancel()
+			}
+		}()
+	}
+
 	if svc.Config.Prometheus.Enabled {
 		//
 		// start: Prometheus Endpoint
@@ -101,11 +155,13 @@ func startService(args StartArgs) {
 		//
 		// start: Node Scanner
 		//
+		if svc.AdminService != nil {
+			svc.AdminService.RegisterScheduler(svc.NodeScannerService.Jobs())
+		}
 		shutdown_wg.Add(1)
 		go func() {
 			defer shutdown_wg.Done()
-			svc.Log.Info("Starting Node Scanner service in 10sec", zap.Bool("Prometheus.Enabled", true))
-			time.Sleep(10 * time.Second)
+			svc.Log.Info("Starting Node Scanner service", zap.Bool("Prometheus.Enabled", true))
 			if err := svc.NodeScannerService.Start(ctx); err != nil {
 				svc.Log.Error("Failed to start Node Scanner service", zap.Error(err))
 				cancel()
@@ -146,6 +202,10 @@ func startService(args StartArgs) {
 		svc.Log.Info("Not starting Node Scanner service", zap.Bool("Prometheus.Enabled", false))
 	}
 
+	config.OnReload(func(oldConfig, newConfig config.Config) {
+		reloadServices(&svc, &oldConfig, &newConfig)
+	})
+
 	svc.Log.Info("Service has started")
 
 	//
@@ -214,3 +274,54 @@ func startService(args StartArgs) {
 	time.Sleep(time.Millisecond * 100)
 	svc.Log.Info("Service has stopped")
 }
+
+// reloadServices applies the reloaded config to the running services. Sections, which cannot be applied
+// at runtime, are reported and require the service restart.
+func reloadServices(svc *cmd.AllServices, oldConfig, newConfig *config.Config) {
+	if oldConfig.Logging.Level != newConfig.Logging.Level {
+		if level, err := logging.ParseLevel(newConfig.Logging.Level); err == nil {
+			svc.Log.SetLevel(level)
+		}
+	}
+
+	if svc.NodeScannerService != nil {
+		svc.NodeScannerService.Reload(newConfig.Monitoring, newConfig.Scheduler)
+	}
+
+	if svc.EthereumMonitoringService != nil {
+		svc.EthereumMonitoringService.Reload(newConfig.Monitoring.EthereumChain)
+	}
+
+	if svc.ReadService != nil && !reflect.DeepEqual(oldConfig.CometBFT.TxFilter, newConfig.CometBFT.TxFilter) {
+		svc.ReadService.SetCometTxFilter(newConfig.CometBFT.TxFilter)
+		svc.Log.Info("Applied new CometBFT.TxFilter")
+	}
+
+	if svc.UpdateService != nil {
+		svc.UpdateService.SetTxFailuresEnabled(newConfig.DataNodeDBExtension.TxFailures.Enabled)
+	}
+
+	// Only the TxFilter of the CometBFT clients is applied at runtime
+	oldCometBFT, newCometBFT := oldConfig.CometBFT, newConfig.CometBFT
+	oldCometBFT.TxFilter, newCometBFT.TxFilter = config.CometTxFilterConfig{}, config.CometTxFilterConfig{}
+
+	unchanged := map[string]bool{
+		"CometBFT":                    reflect.DeepEqual(oldCometBFT, newCometBFT),
+		"Coingecko":                   reflect.DeepEqual(oldConfig.Coingecko, newConfig.Coingecko),
+		"VegaCore":                    reflect.DeepEqual(oldConfig.VegaCore, newConfig.VegaCore),
+		"Ethereum":                    reflect.DeepEqual(oldConfig.Ethereum, newConfig.Ethereum),
+		"Arbitrum":                    reflect.DeepEqual(oldConfig.Arbitrum, newConfig.Arbitrum),
+		"BridgeChains":                reflect.DeepEqual(oldConfig.BridgeChains, newConfig.BridgeChains),
+		"SQLStore":                    reflect.DeepEqual(oldConfig.SQLStore, newConfig.SQLStore),
+		"Prometheus":                  reflect.DeepEqual(oldConfig.Prometheus, newConfig.Prometheus),
+		"HealthCheck":                 reflect.DeepEqual(oldConfig.HealthCheck, newConfig.HealthCheck),
+		"HighAvailability":            reflect.DeepEqual(oldConfig.HighAvailability, newConfig.HighAvailability),
+		"Admin":                       reflect.DeepEqual(oldConfig.Admin, newConfig.Admin),
+		"DataNodeDBExtension.Enabled": oldConfig.DataNodeDBExtension.Enabled == newConfig.DataNodeDBExtension.Enabled,
+	}
+	for section, isUnchanged := range unchanged {
+		if !isUnchanged {
+			svc.Log.Warn("Config section changed, restart the service to apply it", zap.String("section", section))
+		}
+	}
+}
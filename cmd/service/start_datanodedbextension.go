@@ -2,118 +2,87 @@ package service
 
 import (
 	"context"
-	"errors"
-	"log"
+	"fmt"
+	"reflect"
 	"sync"
-	"time"
 
 	"go.uber.org/zap"
 
-	"github.com/vegaprotocol/vega-monitoring/clients/datanode"
 	"github.com/vegaprotocol/vega-monitoring/cmd"
+	"github.com/vegaprotocol/vega-monitoring/config"
 	"github.com/vegaprotocol/vega-monitoring/entities"
-	"github.com/vegaprotocol/vega-monitoring/metamonitoring"
+	"github.com/vegaprotocol/vega-monitoring/scheduler"
+	"github.com/vegaprotocol/vega-monitoring/scraper"
+	"github.com/vegaprotocol/vega-monitoring/services/stream"
 	"github.com/vegaprotocol/vega-monitoring/sqlstore"
 )
 
-const (
-	BlockSignersLoopInterval       = 30 * time.Second
-	NetworkHistoryLoopInterval     = 120 * time.Second
-	CometTxsLoopInterval           = 20 * time.Second
-	NetworkBalancesLoopInterval    = 15 * time.Second
-	AssetPricesLoopInterval        = 25 * time.Second
-	DataNodeHealthScrapperInterval = 30 * time.Second
-)
-
+// startDataNodeDBExtension starts the scrapers writing to the database. In the high availability mode it runs
+// every time the instance is elected, so it returns the errors instead of stopping the service.
 func startDataNodeDBExtension(
 	svc *cmd.AllServices,
 	shutdownWg *sync.WaitGroup,
 	ctx context.Context,
-) {
-	if err := setupDB(svc.Log, svc.Config); err != nil {
-		log.Fatalf("failed to setup database: %+v\n", err)
-	}
+) error {
+	// After a re-election the config may have been reloaded since the service started
+	cfg := config.Current()
 
-	if err := sqlstore.MigrateToLatestSchema(svc.Log, svc.Config.SQLStore.GetConnectionConfig()); err != nil {
-		log.Fatalf("failed to migrate database to latest version %+v\n", err)
+	if err := setupDB(svc.Log, cfg); err != nil {
+		return fmt.Errorf("failed to setup database: %w", err)
 	}
 
-	//
-	// start: Block Singers Service
-	//
-	if svc.Config.DataNodeDBExtension.BlockSigners.Enabled {
-		shutdownWg.Add(1)
-		go func() {
-			defer shutdownWg.Done()
-			runBlockSignersScraper(ctx, svc, svc.MonitoringService.BlockSignersStatusPublisher())
-		}()
-	} else {
-		svc.Log.Info("Not starting Block Signers Service", zap.String("config", "Enabled=false"))
+	if err := sqlstore.MigrateToLatestSchema(svc.Log, cfg.SQLStore.GetConnectionConfig()); err != nil {
+		return fmt.Errorf("failed to migrate database to latest version: %w", err)
 	}
 
-	//
-	// start: Network History Segments Service
-	//
-	if svc.Config.DataNodeDBExtension.NetworkHistorySegments.Enabled {
-		shutdownWg.Add(1)
-		go func() {
-			defer shutdownWg.Done()
-			runNetworkHistorySegmentsScraper(ctx, svc, svc.MonitoringService.SegmentsStatusPublisher())
-		}()
-	} else {
-		svc.Log.Info("Not starting Network History Segments Service", zap.String("config", "Enabled=false"))
+	jobs := scheduler.NewScheduler(svc.Log)
+	deps := scraper.Dependencies{
+		Config:        cfg,
+		Log:           svc.Log,
+		StoreService:  svc.StoreService,
+		ReadService:   svc.ReadService,
+		UpdateService: svc.UpdateService,
 	}
-
-	//
-	// start: Comet Txs Service
-	//
-	if svc.Config.DataNodeDBExtension.CometTxs.Enabled {
-		shutdownWg.Add(1)
-		go func() {
-			defer shutdownWg.Done()
-			runCometTxsScraper(ctx, svc, svc.MonitoringService.CometTxsStatusPublisher())
-		}()
-	} else {
-		svc.Log.Info("Not starting Comet Txs Service", zap.String("config", "Enabled=false"))
+	for _, reg := range scraper.Registered() {
+		if reg.Enabled(cfg) {
+			addScraperJob(svc, jobs, deps, reg, false)
+		} else {
+			svc.Log.Info(fmt.Sprintf("Not starting %s Service", reg.Title), zap.String("config", "Enabled=false"))
+		}
 	}
 
-	//
-	// start: Network Balances
-	//
-	if svc.Config.DataNodeDBExtension.NetworkBalances.Enabled {
-		shutdownWg.Add(1)
-		go func() {
-			defer shutdownWg.Done()
-			runNetworkBalancesScraper(ctx, svc, svc.MonitoringService.NetworkBalancesStatusPublisher())
-		}()
-	} else {
-		svc.Log.Info("Not starting Network Balances Service", zap.String("config", "Enabled=false"))
-	}
+	unsubscribe := config.OnReload(func(oldConfig, newConfig config.Config) {
+		reloadScraperJobs(svc, jobs, deps, &oldConfig, &newConfig)
+	})
 
-	//
-	// start: Asset Prices
-	//
-	if svc.Config.DataNodeDBExtension.AssetPrices.Enabled {
-		shutdownWg.Add(1)
-		go func() {
-			defer shutdownWg.Done()
-			runAssetPricesScraper(ctx, svc, svc.MonitoringService.AssetPricesStatusPublisher())
-		}()
-	} else {
-		svc.Log.Info("Not starting Asset Prices Service", zap.String("config", "Enabled=false"))
+	unregister := func() {}
+	if svc.AdminService != nil {
+		unregister = svc.AdminService.RegisterScheduler(jobs)
 	}
 
-	//
-	// start: Data Node
-	//
-	if svc.Config.DataNodeDBExtension.DataNode.Enabled {
+	shutdownWg.Add(1)
+	go func() {
+		defer shutdownWg.Done()
+		defer unsubscribe()
+		defer unregister()
+		jobs.Run(ctx)
+	}()
+
+	if cfg.CometBFT.Stream.Enabled {
+		cometStream := stream.NewCometStream(
+			svc.ReadService,
+			jobs,
+			cfg.CometBFT.Stream.Jobs,
+			svc.MonitoringService.StatusPublisher(entities.CometStreamSvc),
+			cfg.CometBFT.Stream.ReconnectInterval,
+			svc.Log,
+		)
 		shutdownWg.Add(1)
 		go func() {
 			defer shutdownWg.Done()
-			runDataNodeHealthScraper(ctx, svc, svc.MonitoringService.DataNodeStatusPublisher())
+			svc.Log.Info("Starting CometBFT Stream", zap.Strings("jobs", cfg.CometBFT.Stream.Jobs))
+			cometStream.Run(ctx)
 		}()
-	} else {
-		svc.Log.Info("Not starting Asset Prices Service", zap.String("config", "Enabled=false"))
 	}
 
 	//
@@ -122,246 +91,50 @@ func startDataNodeDBExtension(
 	shutdownWg.Add(1)
 	go func() {
 		defer shutdownWg.Done()
-		svc.MonitoringService.Run(ctx, NetworkHistoryLoopInterval*2)
+		svc.MonitoringService.Run(ctx, cfg.Scheduler.Job(config.NetworkHistorySegmentsJob).Interval*2)
 	}()
-}
-
-// Data Node
-func runDataNodeHealthScraper(ctx context.Context, svc *cmd.AllServices, statusReporter metamonitoring.MonitoringStatusPublisher) {
-	time.Sleep(3 * time.Second)
-	ticker := time.NewTicker(DataNodeHealthScrapperInterval)
-	defer ticker.Stop()
-
-	localNodeConfig := svc.Config.Monitoring.LocalNode
-	if len(localNodeConfig.REST) < 1 {
-		panic("Data node health scraper is disabled but missing or invalid config for Local Node Config: missing rest endpoint")
-	}
-
-	if localNodeConfig.Type != "datanode" {
-		panic("Data node health scraper is disabled but missing or invalid config for Local Node Config: type must be data node")
-	}
-
-	dataNodeClient := datanode.NewDataNodeClient(localNodeConfig.REST)
-
-	for {
-		svc.Log.Debugf("runDataNodeHealthScraper tick")
-		callCtx, cancel := context.WithTimeout(ctx, time.Second*5)
-		isHealthy, err := dataNodeClient.IsHealthy(callCtx)
-		cancel()
-		if isHealthy {
-			if err := statusReporter.Publish(true); err != nil {
-				svc.Log.Error("failed to publish status for the data node height", zap.Error(err))
-			}
-		} else {
-			svc.Log.Error("cannot check local data-node status", zap.Error(err))
-
-			failureReason := entities.ReasonUnknown
-			// Map error from data node client to the Monitoring system
-			if errors.Is(err, datanode.ErrBlocksGapTooBig) || errors.Is(err, datanode.ErrTimeGapTooBig) {
-				failureReason = entities.ReasonNodeIsNotUpToDate
-			} else if errors.Is(err, datanode.ErrHttpCallError) {
-				failureReason = entities.ReasonTargetConnectionFailure
-			} else if errors.Is(err, datanode.ErrMissingOrInvalidResponse) {
-				failureReason = entities.ReasonMissingOrInvalidResponse
-			}
-
-			if err := statusReporter.PublishWithReason(false, failureReason); err != nil {
-				svc.Log.Error("failed to publish status for the data node height", zap.Error(err))
-			}
-		}
 
-		select {
-		case <-ctx.Done():
-			svc.Log.Info("Stopping update Block Singers Scraper")
-			return
-		case <-ticker.C:
-			continue
-		}
-	}
-}
-
-// Block Signers
-func runBlockSignersScraper(ctx context.Context, svc *cmd.AllServices, statusReporter metamonitoring.MonitoringStatusPublisher) {
-	svc.Log.Info("Starting update Block Singers Scraper in 5sec")
-
-	time.Sleep(5 * time.Second) // delay everything by 5sec
-
-	ticker := time.NewTicker(BlockSignersLoopInterval)
-	defer ticker.Stop()
-
-	for {
-		svc.Log.Debugf("runBlockSignerScrapper tick")
-
-		if err := svc.UpdateService.UpdateBlockSignersAllNew(ctx); err != nil {
-			if err := statusReporter.Publish(false); err != nil {
-				svc.Log.Error("failed to publish false health check for the block signers svc", zap.Error(err))
-			}
-			svc.Log.Error("Failed to update Block Signers", zap.Error(err))
-		} else {
-			if err := statusReporter.Publish(true); err != nil {
-				svc.Log.Error("failed to publish true health check for the block signers svc", zap.Error(err))
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			svc.Log.Info("Stopping update Block Singers Scraper")
-			return
-		case <-ticker.C:
-			continue
-		}
-	}
-}
-
-// Network History Segments
-func runNetworkHistorySegmentsScraper(ctx context.Context, svc *cmd.AllServices, statusReporter metamonitoring.MonitoringStatusPublisher) {
-	svc.Log.Info("Starting update Network History Segments Scraper in 10sec")
-
-	time.Sleep(10 * time.Second) // delay everything by 10sec
-
-	ticker := time.NewTicker(NetworkHistoryLoopInterval) // every ~300 block
-	defer ticker.Stop()
-
-	for {
-		svc.Log.Debugf("runNetworkHistorySegmentsScraper tick")
-
-		apiURLs := []string{}
-		for _, dataNode := range svc.Config.Monitoring.DataNode {
-			apiURLs = append(apiURLs, dataNode.REST)
-		}
-		if err := svc.UpdateService.UpdateNetworkHistorySegments(ctx, apiURLs); err != nil {
-			svc.Log.Error("Failed to update Network History Segments", zap.Error(err))
-			if err := statusReporter.Publish(false); err != nil {
-				svc.Log.Error("failed to publish false health check for the network history segments svc", zap.Error(err))
-			}
-		} else {
-			if err := statusReporter.Publish(true); err != nil {
-				svc.Log.Error("failed to publish true health check for the network history segments svc", zap.Error(err))
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			svc.Log.Info("Stopping update Network History Segments Scraper")
-			return
-		case <-ticker.C:
-			continue
-		}
-	}
+	return nil
 }
 
-// Comet Txs
-func runCometTxsScraper(ctx context.Context, svc *cmd.AllServices, statusReporter metamonitoring.MonitoringStatusPublisher) {
-	svc.Log.Info("Starting update Comet Txs Scraper in 20sec")
-
-	time.Sleep(20 * time.Second) // delay everything by 20sec - 15sec after Block Signers scraper
-
-	ticker := time.NewTicker(CometTxsLoopInterval)
-	defer ticker.Stop()
-
-	for {
-		svc.Log.Debugf("runCometTxsScraper tick")
-
-		if err := svc.UpdateService.UpdateCometTxsAllNew(ctx); err != nil {
-			svc.Log.Error("Failed to update Comet Txs", zap.Error(err))
-			if err := statusReporter.Publish(false); err != nil {
-				svc.Log.Error("failed to publish false health check for the comet txs svc", zap.Error(err))
-			}
-		} else {
-			if err := statusReporter.Publish(true); err != nil {
-				svc.Log.Error("failed to publish true health check for the comet txs svc", zap.Error(err))
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			svc.Log.Info("Stopping update Comet Txs Scraper")
-			return
-		case <-ticker.C:
-			continue
-		}
+// addScraperJob creates the scraper and schedules it. When replace is true, the running job is restarted without the initial delay.
+func addScraperJob(svc *cmd.AllServices, jobs *scheduler.Scheduler, deps scraper.Dependencies, reg scraper.Registration, replace bool) {
+	s, err := reg.New(deps)
+	if err != nil {
+		svc.Log.Error(fmt.Sprintf("Failed to create %s Service", reg.Title), zap.Error(err))
+		jobs.Remove(reg.Name)
+		return
 	}
-}
-
-// Network Balances
-func runNetworkBalancesScraper(ctx context.Context, svc *cmd.AllServices, statusReporter metamonitoring.MonitoringStatusPublisher) {
-	svc.Log.Info("Starting update Network Balances Scraper in 15sec")
-
-	time.Sleep(NetworkBalancesLoopInterval)
-
-	ticker := time.NewTicker(50 * time.Second)
-	defer ticker.Stop()
-
-	for {
-		svc.Log.Debugf("runNetworkBalancesScraper tick")
-
-		success := true
-		if err := svc.UpdateService.UpdateAssetPoolBalances(ctx, svc.Config.Ethereum, svc.Config.Arbitrum); err != nil {
-			svc.Log.Error("Failed to update Network Balances: Asset Pool", zap.Error(err))
-			success = false
-		}
-
-		if err := svc.UpdateService.UpdatePartiesTotalBalances(ctx); err != nil {
-			svc.Log.Error("Failed to update Network Balances: Parties Total", zap.Error(err))
-			success = false
-		}
-		if err := svc.UpdateService.UpdateUnrealisedWithdrawalsBalances(ctx); err != nil {
-			svc.Log.Error(
-				"Failed to update Network Balances: Unrealised Withdrawals",
-				zap.Error(err),
-			)
 
-			success = false
-		}
-		if err := svc.UpdateService.UpdateUnfinalizedDepositsBalances(ctx); err != nil {
-			svc.Log.Error("Failed to update Network Balances: Unfinalized Deposits", zap.Error(err))
-			success = false
-		}
-
-		if err := statusReporter.Publish(success); err != nil {
-			svc.Log.Error("failed to publish %v health check for the network balance svc", zap.Error(err))
-		}
-
-		select {
-		case <-ctx.Done():
-			svc.Log.Info("Stopping update Network Balances Scraper")
-			return
-		case <-ticker.C:
-			continue
-		}
+	run := scraper.NewRunFunc(reg, s, svc.MonitoringService.StatusPublisher(reg.MonitoringService), svc.Log)
+	if replace {
+		jobs.Replace(reg.Name, deps.Config.Scheduler.Job(reg.Name), run)
+	} else {
+		jobs.Add(reg.Name, deps.Config.Scheduler.Job(reg.Name), run)
 	}
 }
 
-// Asset Prices
-func runAssetPricesScraper(ctx context.Context, svc *cmd.AllServices, statusReporter metamonitoring.MonitoringStatusPublisher) {
-	svc.Log.Info("Starting update Asset Prices Scraper in 25sec")
-
-	time.Sleep(25 * time.Second)
-
-	ticker := time.NewTicker(2 * time.Minute)
-	defer ticker.Stop()
-
-	for {
-		svc.Log.Debugf("runAssetPricesScraper tick")
-
-		if err := svc.UpdateService.UpdateAssetPrices(ctx); err != nil {
-			svc.Log.Error("Failed to update Asset Prices", zap.Error(err))
-
-			if err := statusReporter.Publish(false); err != nil {
-				svc.Log.Error("failed to publish false health check for the asset price svc", zap.Error(err))
+// reloadScraperJobs starts, stops or restarts only the scrapers affected by the config change. It runs in the
+// config watcher goroutine, so it does not wait for the stopped scrapers to return.
+func reloadScraperJobs(svc *cmd.AllServices, jobs *scheduler.Scheduler, deps scraper.Dependencies, oldConfig, newConfig *config.Config) {
+	deps.Config = newConfig
+	for _, reg := range scraper.Registered() {
+		wasEnabled, isEnabled := reg.Enabled(oldConfig), reg.Enabled(newConfig)
+		switch {
+		case wasEnabled && !isEnabled:
+			svc.Log.Info(fmt.Sprintf("Stopping %s Service after config reload", reg.Title))
+			jobs.Remove(reg.Name)
+			svc.MonitoringService.RemoveService(reg.MonitoringService)
+		case !wasEnabled && isEnabled:
+			svc.Log.Info(fmt.Sprintf("Starting %s Service after config reload", reg.Title))
+			addScraperJob(svc, jobs, deps, reg, false)
+		case isEnabled:
+			scheduleChanged := !reflect.DeepEqual(oldConfig.Scheduler.Job(reg.Name), newConfig.Scheduler.Job(reg.Name))
+			dependenciesChanged := reg.DependsOn != nil && !reflect.DeepEqual(reg.DependsOn(oldConfig), reg.DependsOn(newConfig))
+			if scheduleChanged || dependenciesChanged {
+				svc.Log.Info(fmt.Sprintf("Restarting %s Service after config reload", reg.Title))
+				addScraperJob(svc, jobs, deps, reg, true)
 			}
-		} else {
-			if err := statusReporter.Publish(true); err != nil {
-				svc.Log.Error("failed to publish false health check for the asset price svc", zap.Error(err))
-			}
-		}
-
-		select {
-		case <-ctx.Done():
-			svc.Log.Info("Stopping update Asset Prices Scraper")
-			return
-		case <-ticker.C:
-			continue
 		}
 	}
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/scraper"
 )
 
 type ValidateConfigArgs struct {
@@ -40,6 +41,12 @@ func RunValidateConfig(args ValidateConfigArgs) error {
 	if err != nil {
 		return err
 	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := scraper.ValidateConfig(cfg); err != nil {
+		return err
+	}
 	if args.Print {
 		byteCfg, err := json.MarshalIndent(cfg, "", "\t")
 		if err != nil {
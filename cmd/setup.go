@@ -3,9 +3,9 @@ package cmd
 import (
 	"code.vegaprotocol.io/vega/logging"
 
+	"github.com/vegaprotocol/vega-monitoring/admin"
 	"github.com/vegaprotocol/vega-monitoring/clients/coingecko"
 	"github.com/vegaprotocol/vega-monitoring/clients/comet"
-	"github.com/vegaprotocol/vega-monitoring/clients/ethutils"
 	vegaclient "github.com/vegaprotocol/vega-monitoring/clients/vega"
 	"github.com/vegaprotocol/vega-monitoring/config"
 	"github.com/vegaprotocol/vega-monitoring/metamonitoring"
@@ -29,6 +29,8 @@ type AllServices struct {
 	EthereumMonitoringService   *ethereummonitoring.EthereumMonitoringService
 	MetaMonitoringStatusService *metamonitoringprom.MetaMonitoringStatusService
 	MonitoringService           metamonitoring.MetamonitoringService
+	LeaderElection              *services.LeaderElection
+	AdminService                *admin.AdminService
 }
 
 func SetupServices(configFilePath string, forceDebug bool) (svc AllServices, err error) {
@@ -37,28 +39,26 @@ func SetupServices(configFilePath string, forceDebug bool) (svc AllServices, err
 		return
 	}
 	coingeckoClient := coingecko.NewCoingeckoClient(&svc.Config.Coingecko, svc.Log)
-	cometClient := comet.NewCometClient(&svc.Config.CometBFT)
+	cometClient := comet.NewCometClient(&svc.Config.CometBFT).WithLogger(svc.Log.Named("comet-client"))
 	coreClient := vegaclient.NewVegaClient(svc.Config.VegaCore.ApiURL)
 
 	if svc.Config.DataNodeDBExtension.Enabled {
-		var ethClient *ethutils.EthClient
-		ethClient, err = ethutils.NewEthClient(svc.Config.Ethereum.RPCEndpoint, svc.Log.Named("ethereum-client"))
+		var bridgeChains []*read.BridgeChain
+		bridgeChains, err = read.NewBridgeChains(svc.Config.GetBridgeChains(), svc.Log)
 		if err != nil {
 			return
 		}
 
-		var arbitrumClient *ethutils.EthClient
-		arbitrumClient, err = ethutils.NewEthClient(svc.Config.Arbitrum.RPCEndpoint, svc.Log.Named("arbitrum-client"))
+		svc.StoreService, err = services.NewStoreService(&svc.Config.SQLStore, svc.Log)
 		if err != nil {
 			return
 		}
 
-		svc.StoreService, err = services.NewStoreService(&svc.Config.SQLStore, svc.Log)
-		if err != nil {
-			return
+		if svc.Config.HighAvailability.Enabled {
+			svc.LeaderElection = svc.StoreService.NewLeaderElection(svc.Config.HighAvailability)
 		}
 
-		svc.ReadService, err = read.NewReadService(coingeckoClient, cometClient, ethClient, arbitrumClient, svc.StoreService, svc.Log)
+		svc.ReadService, err = read.NewReadService(coingeckoClient, cometClient, bridgeChains, svc.StoreService, svc.Log)
 		if err != nil {
 			return
 		}
@@ -67,6 +67,7 @@ func SetupServices(configFilePath string, forceDebug bool) (svc AllServices, err
 		if err != nil {
 			return
 		}
+		svc.UpdateService.SetTxFailuresEnabled(svc.Config.DataNodeDBExtension.TxFailures.Enabled)
 
 		svc.MonitoringService, err = metamonitoring.NewMonitoringStatusUpdateService(svc.StoreService, coreClient, svc.Log)
 		if err != nil {
@@ -79,8 +80,14 @@ func SetupServices(configFilePath string, forceDebug bool) (svc AllServices, err
 	if svc.Config.Prometheus.Enabled {
 		svc.PrometheusService = prometheus.NewPrometheusService(&svc.Config.Prometheus)
 
+		cometClient.WithEndpointReporter(svc.PrometheusService.VegaMonitoringCollector)
+
+		if svc.LeaderElection != nil {
+			svc.LeaderElection.OnRoleChange(svc.PrometheusService.VegaMonitoringCollector.UpdateHighAvailabilityRole)
+		}
+
 		svc.NodeScannerService = nodescanner.NewNodeScannerService(
-			&svc.Config.Monitoring, svc.PrometheusService.VegaMonitoringCollector, svc.Log,
+			&svc.Config.Monitoring, svc.Config.Scheduler, svc.PrometheusService.VegaMonitoringCollector, svc.Log,
 		)
 
 		svc.EthereumMonitoringService = ethereummonitoring.NewEthereumMonitoringService(
@@ -88,10 +95,45 @@ func SetupServices(configFilePath string, forceDebug bool) (svc AllServices, err
 		)
 
 		if svc.Config.DataNodeDBExtension.Enabled {
+			svc.UpdateService.
+				WithSigningReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithProposerFairnessReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithValidatorSetReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithTxFailureReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithValidatorHeartbeatReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithBlocksStatsReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithSegmentDivergenceReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithNetworkHistorySegmentsFetchReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithBridgeReconciliationReporter(svc.PrometheusService.VegaMonitoringCollector).
+				WithAssetPoolBalanceReporter(svc.PrometheusService.VegaMonitoringCollector)
 			svc.MetaMonitoringStatusService = metamonitoringprom.NewMetaMonitoringStatusService(
 				svc.ReadService, svc.PrometheusService.VegaMonitoringCollector, svc.Log,
 			)
 		}
 	}
+
+	if svc.Config.Admin.Enabled {
+		var tasks *admin.TaskManager
+		if svc.UpdateService != nil {
+			tasks = admin.NewTaskManager(svc.UpdateService, func() []string {
+				apiURLs := []string{}
+				for _, dataNode := range config.Current().Monitoring.DataNode {
+					apiURLs = append(apiURLs, dataNode.REST)
+				}
+				return apiURLs
+			}, svc.Log.Named("admin-tasks"))
+			if svc.LeaderElection != nil {
+				tasks.WithLeaderCheck(svc.LeaderElection.IsLeader)
+				svc.LeaderElection.OnRoleChange(func(role string) {
+					if role != services.RoleLeader {
+						tasks.CancelAll()
+					}
+				})
+			}
+		} else {
+			tasks = admin.NewTaskManager(nil, nil, svc.Log.Named("admin-tasks"))
+		}
+		svc.AdminService = admin.NewAdminService(svc.Config.Admin, tasks, svc.Log)
+	}
 	return
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/vegaprotocol/vega-monitoring/cmd"
+	"github.com/vegaprotocol/vega-monitoring/services/update"
 )
 
 type NetworkBalancesArgs struct {
@@ -17,6 +19,9 @@ type NetworkBalancesArgs struct {
 	PartiesTotal          bool
 	UnrealisedWithdrawals bool
 	UnfinalizedDeposits   bool
+	FromTime              string
+	ToTime                string
+	Step                  time.Duration
 }
 
 var networkBalancesArgs NetworkBalancesArgs
@@ -43,37 +48,71 @@ func init() {
 	networkBalancesCmd.PersistentFlags().BoolVar(&networkBalancesArgs.PartiesTotal, "parties-total", false, "Update Parties Total Balances")
 	networkBalancesCmd.PersistentFlags().BoolVar(&networkBalancesArgs.UnrealisedWithdrawals, "unrealised-withdrawals", false, "Update Unrealised Withdrawals Balances")
 	networkBalancesCmd.PersistentFlags().BoolVar(&networkBalancesArgs.UnfinalizedDeposits, "unfinalized-deposits", false, "Update Unfinalized Deposits Balances")
+	networkBalancesCmd.PersistentFlags().StringVar(&networkBalancesArgs.FromTime, "from-time", "", "Backfill Asset Pool Balances from the time in RFC3339 format, read at historical blocks. Requires archival RPC endpoints")
+	networkBalancesCmd.PersistentFlags().StringVar(&networkBalancesArgs.ToTime, "to-time", "", "Backfill Asset Pool Balances up to the time in RFC3339 format. Defaults to now")
+	networkBalancesCmd.PersistentFlags().DurationVar(&networkBalancesArgs.Step, "step", time.Hour, "Interval between the backfilled Asset Pool Balances")
 }
 
 func RunNetworkBalances(args NetworkBalancesArgs) error {
+	if len(args.FromTime) > 0 || len(args.ToTime) > 0 {
+		return runAssetPoolBalancesBackfill(args)
+	}
+
 	svc, err := cmd.SetupServices(args.ConfigFilePath, args.Debug)
 	if err != nil {
 		return err
 	}
 
+	balanceTime := update.NetworkBalanceTime()
 	if args.All || args.AssetPool {
-		if err := svc.UpdateService.UpdateAssetPoolBalances(context.Background(), svc.Config.Ethereum, svc.Config.Arbitrum); err != nil {
+		if err := svc.UpdateService.UpdateAssetPoolBalances(context.Background(), balanceTime); err != nil {
 			return err
 		}
 	}
 
 	if args.All || args.PartiesTotal {
-		if err := svc.UpdateService.UpdatePartiesTotalBalances(context.Background()); err != nil {
+		if err := svc.UpdateService.UpdatePartiesTotalBalances(context.Background(), balanceTime); err != nil {
 			return err
 		}
 	}
 
 	if args.All || args.UnrealisedWithdrawals {
-		if err := svc.UpdateService.UpdateUnrealisedWithdrawalsBalances(context.Background()); err != nil {
+		if err := svc.UpdateService.UpdateUnrealisedWithdrawalsBalances(context.Background(), balanceTime); err != nil {
 			return err
 		}
 	}
 
 	if args.All || args.UnfinalizedDeposits {
-		if err := svc.UpdateService.UpdateUnfinalizedDepositsBalances(context.Background()); err != nil {
+		if err := svc.UpdateService.UpdateUnfinalizedDepositsBalances(context.Background(), balanceTime); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+func runAssetPoolBalancesBackfill(args NetworkBalancesArgs) error {
+	if !args.AssetPool || args.All || args.PartiesTotal || args.UnrealisedWithdrawals || args.UnfinalizedDeposits {
+		return fmt.Errorf("--from-time and --to-time are supported only with --asset-pool")
+	}
+	if len(args.FromTime) < 1 {
+		return fmt.Errorf("--from-time is required with --to-time")
+	}
+	fromTime, err := time.Parse(time.RFC3339, args.FromTime)
+	if err != nil {
+		return fmt.Errorf("failed to parse --from-time: %w", err)
+	}
+	toTime := time.Now()
+	if len(args.ToTime) > 0 {
+		if toTime, err = time.Parse(time.RFC3339, args.ToTime); err != nil {
+			return fmt.Errorf("failed to parse --to-time: %w", err)
+		}
+	}
+
+	svc, err := cmd.SetupServices(args.ConfigFilePath, args.Debug)
+	if err != nil {
+		return err
+	}
+
+	return svc.UpdateService.UpdateAssetPoolBalancesRange(context.Background(), fromTime, toTime, args.Step)
+}
@@ -52,7 +52,10 @@ func RunNetworkHistorySegments(args NetworkHistorySegmentsArgs) error {
 		apiURLs = append(apiURLs, args.ApiURL)
 	}
 
-	if err := svc.UpdateService.UpdateNetworkHistorySegments(context.Background(), apiURLs); err != nil {
+	segmentsConfig := svc.Config.DataNodeDBExtension.NetworkHistorySegments
+	if err := svc.UpdateService.UpdateNetworkHistorySegments(
+		context.Background(), apiURLs, segmentsConfig.Concurrency, segmentsConfig.NodeTimeout,
+	); err != nil {
 		return err
 	}
 
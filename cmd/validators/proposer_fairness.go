@@ -0,0 +1,97 @@
+package validators
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/vegaprotocol/vega-monitoring/cmd"
+)
+
+type ProposerFairnessArgs struct {
+	*ValidatorsArgs
+	Epochs  int64
+	Refresh bool
+}
+
+var proposerFairnessArgs ProposerFairnessArgs
+
+var proposerFairnessCmd = &cobra.Command{
+	Use:   "proposer-fairness",
+	Short: "Print blocks proposed by validators compared with their voting power",
+	Long:  `Print blocks proposed by validators in the last complete epochs compared with blocks expected from their voting power`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := RunProposerFairness(proposerFairnessArgs); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	ValidatorsCmd.AddCommand(proposerFairnessCmd)
+	proposerFairnessArgs.ValidatorsArgs = &validatorsArgs
+
+	proposerFairnessCmd.PersistentFlags().Int64Var(&proposerFairnessArgs.Epochs, "epochs", 1, "Number of the last complete epochs to print")
+	proposerFairnessCmd.PersistentFlags().BoolVar(&proposerFairnessArgs.Refresh, "refresh", false, "Update proposer fairness before printing the report")
+}
+
+func RunProposerFairness(args ProposerFairnessArgs) error {
+	if args.Epochs < 1 {
+		return errors.New("--epochs must be greater than 0")
+	}
+
+	svc, err := cmd.SetupServices(args.ConfigFilePath, args.Debug)
+	if err != nil {
+		return err
+	}
+	if svc.StoreService == nil {
+		return errors.New("DataNodeDBExtension must be enabled to read proposer fairness")
+	}
+
+	ctx := context.Background()
+	if args.Refresh {
+		if err := svc.UpdateService.UpdateProposerFairness(ctx); err != nil {
+			return err
+		}
+	}
+
+	fairnessStore := svc.StoreService.NewProposerFairness()
+	lastEpoch, err := fairnessStore.GetLastCompleteEpoch(ctx)
+	if err != nil {
+		return err
+	}
+	if lastEpoch < 1 {
+		fmt.Println("No complete epochs, run with --refresh to update proposer fairness")
+		return nil
+	}
+
+	report, err := fairnessStore.GetReport(ctx, max(lastEpoch-args.Epochs+1, 1), lastEpoch)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "EPOCH\tNAME\tTM PUB KEY\tVOTING POWER SHARE\tEXPECTED\tACTUAL\tDEVIATION\tCOMPLETE\t")
+	for _, result := range report {
+		name := "-"
+		if result.Name != nil {
+			name = *result.Name
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%.2f%%\t%.1f\t%d\t%+.2f%%\t%t\t\n",
+			result.EpochSeq,
+			name,
+			result.TmPubKey,
+			result.VotingPowerShare*100,
+			result.ExpectedProposals,
+			result.ActualProposals,
+			result.Deviation*100,
+			result.Complete,
+		)
+	}
+
+	return w.Flush()
+}
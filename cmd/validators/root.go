@@ -0,0 +1,22 @@
+package validators
+
+import (
+	"github.com/spf13/cobra"
+	rootCmd "github.com/vegaprotocol/vega-monitoring/cmd"
+)
+
+type ValidatorsArgs struct {
+	*rootCmd.RootArgs
+}
+
+var validatorsArgs ValidatorsArgs
+
+var ValidatorsCmd = &cobra.Command{
+	Use:   "validators",
+	Short: "Reports about validators based on data in SQLStore",
+	Long:  `Reports about validators based on data in SQLStore`,
+}
+
+func init() {
+	validatorsArgs.RootArgs = &rootCmd.Args
+}
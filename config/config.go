@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"code.vegaprotocol.io/vega/datanode/sqlstore"
@@ -27,6 +29,8 @@ type Config struct {
 	
 	Arbitrum EthereumConfig `group:"Arbitrum" namespace:"arbitrum"`
 
+	BridgeChains []BridgeChainConfig `group:"BridgeChains" namespace:"bridgechains" comment:"EVM chains with a Vega bridge, used to get Asset Pool's asset balances.\n When empty, Ethereum and Arbitrum are used"`
+
 	HealthCheck HealthCheckConfig `group:"HealthCheck" namespace:"healthcheck"`
 
 	Logging struct {
@@ -40,6 +44,12 @@ type Config struct {
 	Monitoring MonitoringConfig `group:"Monitoring" namespace:"monitoring" comment:"collected metrics are exposed on prometheus"`
 
 	DataNodeDBExtension DataNodeDBExtensionConfig `group:"DataNodeDBExtension" namespace:"datanodedbextension" comment:"Create extra tables in DataNode database, and continuously fill them in"`
+
+	HighAvailability HighAvailabilityConfig `group:"HighAvailability" namespace:"highavailability" comment:"Run multiple instances against the same database. Only the leader instance runs the DataNodeDBExtension scrapers,\n the other instances wait as standby and take over when the leader stops"`
+
+	Scheduler SchedulerConfig `group:"Scheduler" namespace:"scheduler" comment:"Schedule of the scrapers and node scanners.\n Jobs missing in this section use the default schedule"`
+
+	Admin AdminConfig `group:"Admin" namespace:"admin" comment:"HTTP API to pause, resume and trigger the jobs, and to run backfills in the running service"`
 }
 
 type HealthCheckConfig struct {
@@ -58,7 +68,89 @@ type CoingeckoConfig struct {
 }
 
 type CometBFTConfig struct {
-	ApiURL string `long:"ApiURL"`
+	ApiURL              string              `long:"ApiURL"`
+	ApiURLs             []string            `long:"ApiURLs"             comment:"Additional endpoints used for failover. The healthy endpoints, which are up to date, are preferred in the configured order, starting with ApiURL"`
+	HealthCheckInterval time.Duration       `long:"HealthCheckInterval" comment:"How often the status of the endpoints is checked, when there are multiple endpoints"`
+	MaxLagBlocks        int64               `long:"MaxLagBlocks"        comment:"Endpoints more blocks behind the most up to date endpoint are used only when no other endpoint works"`
+	Quorum              CometQuorumConfig   `group:"Quorum"   namespace:"quorum"   comment:"Cross-check the block hashes between the endpoints before storing the block signers and comet txs"`
+	TxFilter            CometTxFilterConfig `group:"TxFilter" namespace:"txfilter" comment:"Select the txs stored in the metrics.comet_txs table.\nWhen both Include and Exclude are empty, the DefaultCometTxExclude list is used"`
+	Stream              CometStreamConfig   `group:"Stream"   namespace:"stream"   comment:"Subscribe to NewBlock events over the CometBFT websocket and run the ingestion jobs on every new block.\nThe jobs keep running on their schedule, so the blocks missed during disconnects are polled over HTTP"`
+}
+
+// Endpoints returns ApiURL followed by ApiURLs, without duplicates.
+func (c CometBFTConfig) Endpoints() []string {
+	endpoints := []string{}
+	for _, url := range append([]string{c.ApiURL}, c.ApiURLs...) {
+		url = strings.TrimRight(url, "/")
+		if len(url) > 0 && !slices.Contains(endpoints, url) {
+			endpoints = append(endpoints, url)
+		}
+	}
+
+	return endpoints
+}
+
+type CometQuorumConfig struct {
+	Enabled      bool `long:"Enabled"`
+	MinEndpoints int  `long:"MinEndpoints" comment:"Minimum number of endpoints, which must return the same block hash"`
+}
+
+type CometStreamConfig struct {
+	Enabled           bool          `long:"Enabled"`
+	WebsocketURL      string        `long:"WebsocketURL"      comment:"CometBFT websocket endpoint, e.g. ws://localhost:26657/websocket. When empty, it is derived from ApiURL"`
+	ReconnectInterval time.Duration `long:"ReconnectInterval" comment:"Delay before reconnecting after the stream is disconnected"`
+	Jobs              []string      `long:"Jobs"              comment:"Jobs triggered on every new block"`
+}
+
+type CometTxFilterConfig struct {
+	Include    []string            `long:"Include"    comment:"Command types to store, e.g. \"Transfer Funds\". Use \"*\" to store all commands. When empty, all commands except Exclude are stored"`
+	Exclude    []string            `long:"Exclude"    comment:"Command types not to store, applied after Include"`
+	Attributes []CometTxAttributes `long:"Attributes" comment:"Attributes stored for the command types. Commands not listed keep all attributes"`
+}
+
+// CometTxFilterIncludeAll in the Include list stores all command types, except the excluded ones.
+const CometTxFilterIncludeAll = "*"
+
+// Keeps returns true if txs with the command type are stored. When both Include and Exclude are empty,
+// the DefaultCometTxExclude list is used.
+func (c CometTxFilterConfig) Keeps(command string) bool {
+	exclude := c.Exclude
+	if len(c.Include) == 0 && len(c.Exclude) == 0 {
+		exclude = DefaultCometTxExclude
+	}
+	if len(c.Include) > 0 && !slices.Contains(c.Include, CometTxFilterIncludeAll) && !slices.Contains(c.Include, command) {
+		return false
+	}
+
+	return !slices.Contains(exclude, command)
+}
+
+type CometTxAttributes struct {
+	Command string   `long:"Command" comment:"Command type, e.g. \"Chain Event\""`
+	Keys    []string `long:"Keys"    comment:"Attribute keys to store"`
+}
+
+// DefaultCometTxExclude are the command types, which are not stored in the metrics.comet_txs table by default.
+// They are either stored in the data-node database, or there are too many of them.
+var DefaultCometTxExclude = []string{
+	"Submit Order",
+	"Cancel Order",
+	"Amend Order",
+	"Withdraw",
+	"Proposal",
+	"Vote on Proposal",
+	"Node Signature",
+	"Liquidity Provision Order",
+	"Cancel LiquidityProvision Order",
+	"Amend LiquidityProvision Order",
+	"Submit Oracle Data",
+	"Delegate",
+	"Undelegate",
+	"Key Rotate Submission",
+	"Transfer Funds",
+	"Cancel Transfer Funds",
+	"Ethereum Key Rotate Submission",
+	"Protocol Upgrade",
 }
 
 type VegaCoreConfig struct {
@@ -80,6 +172,43 @@ type EthereumConfig struct {
 	AssetPoolAddress string `long:"AssetPoolAddress" comment:"used to get balances of asssets"`
 }
 
+type BridgeChainConfig struct {
+	Name             string `long:"Name"             comment:"Name of the chain, used in the logs"`
+	ChainID          string `long:"ChainID"          comment:"Chain ID of the assets on the chain. Read from the RPC endpoint when empty"`
+	RPCEndpoint      string `long:"RPCEndpoint"`
+	AssetPoolAddress string `long:"AssetPoolAddress"`
+	EtherscanURL     string `long:"EtherscanURL"     comment:"Optional"`
+	EtherscanApiKey  string `long:"EtherscanApiKey"  comment:"Optional"`
+	MulticallAddress string `long:"MulticallAddress" comment:"Address of the Multicall3 contract used to read all balances at once. The canonical address when empty"`
+}
+
+// GetBridgeChains returns the configured bridge chains, or Ethereum and Arbitrum with an RPC endpoint, when no
+// bridge chains are configured.
+func (c Config) GetBridgeChains() []BridgeChainConfig {
+	if len(c.BridgeChains) > 0 {
+		return c.BridgeChains
+	}
+
+	result := []BridgeChainConfig{}
+	for _, chain := range []struct {
+		name   string
+		config EthereumConfig
+	}{{"ethereum", c.Ethereum}, {"arbitrum", c.Arbitrum}} {
+		if len(chain.config.RPCEndpoint) < 1 {
+			continue
+		}
+		result = append(result, BridgeChainConfig{
+			Name:             chain.name,
+			RPCEndpoint:      chain.config.RPCEndpoint,
+			AssetPoolAddress: chain.config.AssetPoolAddress,
+			EtherscanURL:     chain.config.EtherscanURL,
+			EtherscanApiKey:  chain.config.EtherscanApiKey,
+		})
+	}
+
+	return result
+}
+
 type PrometheusConfig struct {
 	Port    int    `long:"port"`
 	Path    string `long:"path"`
@@ -131,14 +260,16 @@ type RetentionPolicy struct {
 
 type DataNodeDBExtensionConfig struct {
 	Enabled             bool              `group:"Enabled" namespace:"enabled" comment:"Enable or Disable extension\n When disabled, then all other config from this section is ignored"`
-	BaseRetentionPolicy string            `long:"BaseRetentionPolicy" comment:"Define base retention policy you can override with the RetentionPolicy key.\nAvailable options:\n\t- lite - keep everything for 7 days,\n\t- archival - keep everything forever,\n\t- standard - keep everything except monitoring status for 4 months, monitoring status retention is 7 days."`
+	BaseRetentionPolicy string            `long:"BaseRetentionPolicy" comment:"Define base retention policy you can override with the RetentionPolicy key.\nAvailable options:\n\t- lite - keep everything for 7 days,\n\t- archival - keep everything forever,\n\t- standard - keep everything except monitoring status for 4 months, monitoring status retention is 7 days, daily aggregates are kept for 1 year."`
 	RetentionPolicy     []RetentionPolicy `long:"RetentionPolicy" comment:"Override policy defined in the BaseRetention Policy"`
 
 	BlockSigners struct {
 		Enabled bool `long:"enabled"`
 	} `group:"BlockSigners"           namespace:"blocksigners"`
 	NetworkHistorySegments struct {
-		Enabled bool `long:"enabled"`
+		Enabled     bool          `long:"enabled"`
+		Concurrency int           `long:"Concurrency" comment:"Maximum number of data-nodes queried at once"`
+		NodeTimeout time.Duration `long:"NodeTimeout" comment:"Time budget of a single data-node to return its segments"`
 	} `group:"NetworkHistorySegments" namespace:"networkhistorysegments"`
 	CometTxs struct {
 		Enabled bool `long:"enabled"`
@@ -152,6 +283,60 @@ type DataNodeDBExtensionConfig struct {
 	DataNode struct {
 		Enabled bool `long:"enabled"`
 	} `group:"DataNode"            namespace:"datanode"`
+	BlockGaps struct {
+		Enabled           bool  `long:"enabled"`
+		MaxBackfillBlocks int64 `long:"MaxBackfillBlocks" comment:"Maximum number of missing blocks filled in every run, per table"`
+	} `group:"BlockGaps"           namespace:"blockgaps" comment:"Find missing blocks in the BlockSigners and CometTxs tables and fill them in"`
+	ProposerFairness struct {
+		Enabled bool `long:"enabled"`
+	} `group:"ProposerFairness"    namespace:"proposerfairness" comment:"Compare blocks proposed by validators in each epoch with their voting power. Requires BlockSigners"`
+	ValidatorSet struct {
+		Enabled   bool  `long:"enabled"`
+		MaxBlocks int64 `long:"MaxBlocks" comment:"Maximum number of blocks checked for validator set changes in every run"`
+	} `group:"ValidatorSet"        namespace:"validatorset" comment:"Record changes of the CometBFT validator set"`
+	TxFailures struct {
+		Enabled              bool          `long:"enabled"`
+		Window               time.Duration `long:"Window"               comment:"Time window, in which the rejected validator txs are counted"`
+		MaxValidatorFailures int64         `long:"MaxValidatorFailures" comment:"Maximum number of rejected validator txs, e.g. heartbeats and votes, in the window before the check is unhealthy"`
+	} `group:"TxFailures"          namespace:"txfailures" comment:"Report unhealthy status on spikes of rejected validator txs. Requires CometTxs"`
+	ValidatorHeartbeats struct {
+		Enabled               bool          `long:"enabled"`
+		Window                time.Duration `long:"Window"                comment:"Time window, in which the heartbeats are counted"`
+		MaxSinceLastHeartbeat time.Duration `long:"MaxSinceLastHeartbeat" comment:"Maximum time since the last successful heartbeat of a validator before the check is unhealthy"`
+	} `group:"ValidatorHeartbeats" namespace:"validatorheartbeats" comment:"Track the heartbeat cadence of the validators. Requires CometTxs with the Validator Heartbeat txs and ValidatorSet"`
+	BridgeReconciliation struct {
+		Enabled   bool    `long:"enabled"`
+		Tolerance float64 `long:"Tolerance" comment:"Maximum discrepancy of an asset as a fraction of its asset pool balance, e.g. 0.001 for 0.1%"`
+	} `group:"BridgeReconciliation" namespace:"bridgereconciliation" comment:"Reconcile the asset pool balances with the funds held by the network. Requires NetworkBalances"`
+	SegmentDivergence struct {
+		Enabled bool          `long:"enabled"`
+		Window  time.Duration `long:"Window" comment:"Time window of the network history segments compared between the data-nodes"`
+	} `group:"SegmentDivergence" namespace:"segmentdivergence" comment:"Detect network history segments, which differ between the data-nodes. Requires NetworkHistorySegments"`
+}
+
+type HighAvailabilityConfig struct {
+	Enabled       bool          `long:"Enabled"`
+	LockID        int64         `long:"LockID"        comment:"Postgres advisory lock key, must be the same for all instances"`
+	RetryInterval time.Duration `long:"RetryInterval" comment:"How often a standby instance tries to take over the lock and the leader checks its database session"`
+}
+
+type AdminConfig struct {
+	Enabled  bool   `long:"Enabled"`
+	Port     int    `long:"Port"     comment:"the port admin HTTP server is running on"`
+	ApiToken string `long:"ApiToken" comment:"required in the Authorization: Bearer <ApiToken> header of every request"`
+}
+
+type SchedulerConfig struct {
+	Jobs map[string]JobConfig `group:"Jobs" namespace:"jobs" comment:"Key is the job name, one of: block_signers, network_history_segments, comet_txs, network_balances, asset_prices, data_node_health,\n node_scanner_cores, node_scanner_data_nodes, node_scanner_block_explorers, node_scanner_local_node"`
+}
+
+type JobConfig struct {
+	Interval          time.Duration `long:"Interval"          comment:"How often the job runs"`
+	InitialDelay      time.Duration `long:"InitialDelay"      comment:"Delay of the first run after the service has started"`
+	Timeout           time.Duration `long:"Timeout"           comment:"Max duration of a single run, 0 means no timeout"`
+	Jitter            time.Duration `long:"Jitter"            comment:"Random duration from [0, Jitter) added to every delay"`
+	BackoffMultiplier float64       `long:"BackoffMultiplier" comment:"After each consecutive failure the interval is multiplied by this value, 0 or 1 disables backoff"`
+	MaxBackoff        time.Duration `long:"MaxBackoff"        comment:"Upper limit of the interval after failures"`
 }
 
 type EthereumChain struct {
@@ -184,6 +369,8 @@ type EthCall struct {
 	OutputTransform string `long:"OutputTransform" comment:"Define function for transforming output from the contract.\nPossible values:\n\t- default - no transform\n\t- float_price:<decimal_places> - e.g. float_price:18 - convert price from big int to float with given decimal places"`
 }
 
+// ReadConfigAndWatch reads the config and reloads it when the file changes. The returned config is never
+// changed by a reload, the reloaded config is published by Current and passed to the OnReload handlers.
 func ReadConfigAndWatch(configFilePath string, logger *logging.Logger) (*Config, error) {
 	var config Config
 
@@ -196,14 +383,31 @@ func ReadConfigAndWatch(configFilePath string, logger *logging.Logger) (*Config,
 		return nil, fmt.Errorf("failed to unmarshal config %s: %w", configFilePath, err)
 	}
 
+	current.Store(&config)
+
 	viper.OnConfigChange(func(event fsnotify.Event) {
-		if event.Op == fsnotify.Write {
-			if err := viper.Unmarshal(&config); err != nil {
-				logger.Error("Failed to reload config after config changed", zap.Error(err))
-			} else {
-				logger.Info("Reloaded config, because config file changed", zap.String("event", event.Name))
-			}
+		if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+			return
+		}
+
+		var newConfig Config
+		if err := viper.Unmarshal(&newConfig); err != nil {
+			logger.Error("Failed to reload config after config changed", zap.Error(err))
+			setReloadStatus(fmt.Errorf("failed to unmarshal config: %w", err))
+			return
+		}
+
+		if err := newConfig.Validate(); err != nil {
+			logger.Error("Rejected config reload, the new config is invalid", zap.String("event", event.Name), zap.Error(err))
+			setReloadStatus(fmt.Errorf("invalid config: %w", err))
+			return
 		}
+
+		oldConfig := current.Swap(&newConfig)
+		setReloadStatus(nil)
+		logger.Info("Reloaded config, because config file changed", zap.String("event", event.Name))
+
+		notifyReloadHandlers(*oldConfig, newConfig)
 	})
 	viper.WatchConfig()
 
@@ -227,6 +431,14 @@ func NewDefaultConfig() Config {
 	}
 	// Local Node
 	config.CometBFT.ApiURL = "http://localhost:26657"
+	config.CometBFT.ApiURLs = []string{}
+	config.CometBFT.HealthCheckInterval = 15 * time.Second
+	config.CometBFT.MaxLagBlocks = 5
+	config.CometBFT.Quorum.Enabled = false
+	config.CometBFT.Quorum.MinEndpoints = 2
+	config.CometBFT.Stream.Enabled = false
+	config.CometBFT.Stream.ReconnectInterval = 5 * time.Second
+	config.CometBFT.Stream.Jobs = []string{BlockSignersJob, CometTxsJob}
 	config.VegaCore.ApiURL = "http://localhost:3003"
 	// Ethereum
 	config.Ethereum.RPCEndpoint = ""
@@ -259,10 +471,37 @@ func NewDefaultConfig() Config {
 	config.DataNodeDBExtension.Enabled = false
 	config.DataNodeDBExtension.BlockSigners.Enabled = true
 	config.DataNodeDBExtension.NetworkHistorySegments.Enabled = true
+	config.DataNodeDBExtension.NetworkHistorySegments.Concurrency = 8
+	config.DataNodeDBExtension.NetworkHistorySegments.NodeTimeout = 10 * time.Second
 	config.DataNodeDBExtension.CometTxs.Enabled = true
 	config.DataNodeDBExtension.NetworkBalances.Enabled = true
 	config.DataNodeDBExtension.AssetPrices.Enabled = true
+	config.DataNodeDBExtension.BlockGaps.Enabled = true
+	config.DataNodeDBExtension.BlockGaps.MaxBackfillBlocks = 2000
+	config.DataNodeDBExtension.ProposerFairness.Enabled = true
+	config.DataNodeDBExtension.ValidatorSet.Enabled = true
+	config.DataNodeDBExtension.ValidatorSet.MaxBlocks = 500
+	config.DataNodeDBExtension.TxFailures.Enabled = true
+	config.DataNodeDBExtension.TxFailures.Window = 10 * time.Minute
+	config.DataNodeDBExtension.TxFailures.MaxValidatorFailures = 10
+	config.DataNodeDBExtension.ValidatorHeartbeats.Enabled = true
+	config.DataNodeDBExtension.ValidatorHeartbeats.Window = 6 * time.Hour
+	config.DataNodeDBExtension.ValidatorHeartbeats.MaxSinceLastHeartbeat = 30 * time.Minute
+	config.DataNodeDBExtension.BridgeReconciliation.Enabled = true
+	config.DataNodeDBExtension.BridgeReconciliation.Tolerance = 0.001
+	config.DataNodeDBExtension.SegmentDivergence.Enabled = true
+	config.DataNodeDBExtension.SegmentDivergence.Window = 24 * time.Hour
 	config.DataNodeDBExtension.BaseRetentionPolicy = DefaultRetentionPolicy
+	// High Availability
+	config.HighAvailability.Enabled = false
+	config.HighAvailability.LockID = 7311
+	config.HighAvailability.RetryInterval = 5 * time.Second
+	// Scheduler
+	config.Scheduler.Jobs = DefaultJobs()
+	// Admin
+	config.Admin.Enabled = false
+	config.Admin.Port = 8902
+	config.Admin.ApiToken = ""
 	// HealthCheck
 	config.HealthCheck.Enabled = true
 	config.HealthCheck.Port = 8901
@@ -271,6 +510,15 @@ func NewDefaultConfig() Config {
 	return config
 }
 
+// Job returns schedule for the given job. If the job is not configured, the default schedule is returned.
+func (c SchedulerConfig) Job(name string) JobConfig {
+	if job, ok := c.Jobs[name]; ok {
+		return job
+	}
+
+	return DefaultJobs()[name]
+}
+
 func StoreDefaultConfigInFile(filePath string) (*Config, error) {
 	config := NewDefaultConfig()
 
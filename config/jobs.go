@@ -0,0 +1,71 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+// Names of the scheduled jobs. Viper lower-cases map keys, so names must be lower-case.
+const (
+	BlockSignersJob              = "block_signers"
+	NetworkHistorySegmentsJob    = "network_history_segments"
+	CometTxsJob                  = "comet_txs"
+	NetworkBalancesJob           = "network_balances"
+	AssetPricesJob               = "asset_prices"
+	DataNodeHealthJob            = "data_node_health"
+	NodeScannerCoresJob          = "node_scanner_cores"
+	NodeScannerDataNodesJob      = "node_scanner_data_nodes"
+	NodeScannerBlockExplorersJob = "node_scanner_block_explorers"
+	NodeScannerLocalNodeJob      = "node_scanner_local_node"
+)
+
+var registeredJobs = struct {
+	mut  sync.Mutex
+	jobs map[string]JobConfig
+}{
+	jobs: map[string]JobConfig{},
+}
+
+// RegisterDefaultJob adds the default schedule for a job defined outside of this package, e.g. by a scraper.
+func RegisterDefaultJob(name string, cfg JobConfig) {
+	registeredJobs.mut.Lock()
+	defer registeredJobs.mut.Unlock()
+
+	registeredJobs.jobs[name] = cfg
+}
+
+// DefaultJobs returns the schedules used when a job is not defined in the Scheduler config section.
+func DefaultJobs() map[string]JobConfig {
+	jobs := map[string]JobConfig{
+		BlockSignersJob:              NewJobConfig(30*time.Second, 5*time.Second),
+		NetworkHistorySegmentsJob:    NewJobConfig(120*time.Second, 10*time.Second),
+		CometTxsJob:                  NewJobConfig(20*time.Second, 20*time.Second),
+		NetworkBalancesJob:           NewJobConfig(50*time.Second, 15*time.Second),
+		AssetPricesJob:               NewJobConfig(2*time.Minute, 25*time.Second),
+		DataNodeHealthJob:            NewJobConfig(30*time.Second, 3*time.Second),
+		NodeScannerCoresJob:          NewJobConfig(time.Minute, 15*time.Second),
+		NodeScannerDataNodesJob:      NewJobConfig(time.Minute, 25*time.Second),
+		NodeScannerBlockExplorersJob: NewJobConfig(time.Minute, 35*time.Second),
+		NodeScannerLocalNodeJob:      NewJobConfig(15*time.Second, 10*time.Second),
+	}
+
+	registeredJobs.mut.Lock()
+	defer registeredJobs.mut.Unlock()
+	for name, cfg := range registeredJobs.jobs {
+		if _, ok := jobs[name]; !ok {
+			jobs[name] = cfg
+		}
+	}
+
+	return jobs
+}
+
+// NewJobConfig returns schedule with the default backoff for the given interval.
+func NewJobConfig(interval time.Duration, initialDelay time.Duration) JobConfig {
+	return JobConfig{
+		Interval:          interval,
+		InitialDelay:      initialDelay,
+		BackoffMultiplier: 2,
+		MaxBackoff:        10 * interval,
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReloadHandler is called after a valid config has been reloaded from the file.
+type ReloadHandler func(oldConfig, newConfig Config)
+
+// ReloadStatus describes the last attempt to reload the config file.
+type ReloadStatus struct {
+	Time    time.Time
+	Success bool
+	Error   string `json:",omitempty"`
+}
+
+type reloadHandlers struct {
+	mut      sync.Mutex
+	nextID   int
+	handlers map[int]ReloadHandler
+	status   *ReloadStatus
+}
+
+var reloads = reloadHandlers{
+	handlers: map[int]ReloadHandler{},
+}
+
+// current is the config last read from the file. A reload publishes a new Config instead of changing
+// the published one, so the readers never race with the config watcher.
+var current atomic.Pointer[Config]
+
+// Current returns the config last read from the file, or nil if the config has not been read yet.
+// The returned config must not be modified.
+func Current() *Config {
+	return current.Load()
+}
+
+// OnReload registers a handler called after every successful config reload.
+// Handlers are called in the config watcher goroutine, so they must not block for long.
+// The returned function unregisters the handler.
+func OnReload(handler ReloadHandler) func() {
+	reloads.mut.Lock()
+	defer reloads.mut.Unlock()
+
+	id := reloads.nextID
+	reloads.nextID++
+	reloads.handlers[id] = handler
+
+	return func() {
+		reloads.mut.Lock()
+		defer reloads.mut.Unlock()
+		delete(reloads.handlers, id)
+	}
+}
+
+// LastReloadStatus returns the status of the last config reload, or nil if the config has not been reloaded yet.
+func LastReloadStatus() *ReloadStatus {
+	reloads.mut.Lock()
+	defer reloads.mut.Unlock()
+
+	if reloads.status == nil {
+		return nil
+	}
+	status := *reloads.status
+
+	return &status
+}
+
+func setReloadStatus(err error) {
+	status := &ReloadStatus{
+		Time:    time.Now(),
+		Success: err == nil,
+	}
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	reloads.mut.Lock()
+	reloads.status = status
+	reloads.mut.Unlock()
+}
+
+func notifyReloadHandlers(oldConfig, newConfig Config) {
+	reloads.mut.Lock()
+	handlers := make([]ReloadHandler, 0, len(reloads.handlers))
+	for id := 0; id < reloads.nextID; id++ {
+		if handler, ok := reloads.handlers[id]; ok {
+			handlers = append(handlers, handler)
+		}
+	}
+	reloads.mut.Unlock()
+
+	for _, handler := range handlers {
+		handler(oldConfig, newConfig)
+	}
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// Validate checks the config for errors, which would make the services fail at runtime.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		errs = append(errs, fmt.Errorf("invalid Logging.Level %q: %w", c.Logging.Level, err))
+	}
+
+	for name, job := range c.Scheduler.Jobs {
+		if job.Interval <= 0 {
+			errs = append(errs, fmt.Errorf("invalid Scheduler.Jobs.%s.Interval: must be greater than 0", name))
+		}
+		if job.InitialDelay < 0 || job.Timeout < 0 || job.Jitter < 0 || job.MaxBackoff < 0 {
+			errs = append(errs, fmt.Errorf("invalid Scheduler.Jobs.%s: durations must not be negative", name))
+		}
+		if job.BackoffMultiplier < 0 {
+			errs = append(errs, fmt.Errorf("invalid Scheduler.Jobs.%s.BackoffMultiplier: must not be negative", name))
+		}
+	}
+
+	errs = append(errs, c.Monitoring.validate()...)
+
+	names := map[string]struct{}{}
+	for idx, chain := range c.BridgeChains {
+		if len(chain.Name) < 1 {
+			errs = append(errs, fmt.Errorf("missing BridgeChains[%d].Name", idx))
+		} else if _, ok := names[chain.Name]; ok {
+			errs = append(errs, fmt.Errorf("duplicated BridgeChains.Name %q", chain.Name))
+		}
+		names[chain.Name] = struct{}{}
+		if len(chain.RPCEndpoint) < 1 {
+			errs = append(errs, fmt.Errorf("missing BridgeChains.RPCEndpoint for %q", chain.Name))
+		}
+		if len(chain.AssetPoolAddress) < 1 {
+			errs = append(errs, fmt.Errorf("missing BridgeChains.AssetPoolAddress for %q", chain.Name))
+		}
+	}
+
+	for idx, attrs := range c.CometBFT.TxFilter.Attributes {
+		if len(attrs.Command) < 1 {
+			errs = append(errs, fmt.Errorf("missing CometBFT.TxFilter.Attributes[%d].Command", idx))
+		}
+	}
+
+	if len(c.CometBFT.Endpoints()) > 1 && c.CometBFT.HealthCheckInterval <= 0 {
+		errs = append(errs, fmt.Errorf("invalid CometBFT.HealthCheckInterval %s: must be greater than 0", c.CometBFT.HealthCheckInterval))
+	}
+	if c.CometBFT.Quorum.Enabled {
+		if c.CometBFT.Quorum.MinEndpoints < 2 {
+			errs = append(errs, fmt.Errorf("invalid CometBFT.Quorum.MinEndpoints %d: must be at least 2", c.CometBFT.Quorum.MinEndpoints))
+		}
+		if c.CometBFT.Quorum.MinEndpoints > len(c.CometBFT.Endpoints()) {
+			errs = append(errs, fmt.Errorf("invalid CometBFT.Quorum.MinEndpoints %d: only %d endpoints configured", c.CometBFT.Quorum.MinEndpoints, len(c.CometBFT.Endpoints())))
+		}
+	}
+	if c.CometBFT.Stream.Enabled && c.CometBFT.Stream.ReconnectInterval <= 0 {
+		errs = append(errs, fmt.Errorf("invalid CometBFT.Stream.ReconnectInterval %s: must be greater than 0", c.CometBFT.Stream.ReconnectInterval))
+	}
+
+	if c.Admin.Enabled {
+		if len(c.Admin.ApiToken) < 1 {
+			errs = append(errs, errors.New("missing Admin.ApiToken, it is required when Admin is enabled"))
+		}
+		if c.Admin.Port <= 0 {
+			errs = append(errs, fmt.Errorf("invalid Admin.Port %d", c.Admin.Port))
+		}
+	}
+
+	if c.DataNodeDBExtension.Enabled && c.DataNodeDBExtension.BlockGaps.Enabled && c.DataNodeDBExtension.BlockGaps.MaxBackfillBlocks <= 0 {
+		errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.BlockGaps.MaxBackfillBlocks %d: must be greater than 0", c.DataNodeDBExtension.BlockGaps.MaxBackfillBlocks))
+	}
+
+	if c.DataNodeDBExtension.Enabled && c.DataNodeDBExtension.ValidatorSet.Enabled && c.DataNodeDBExtension.ValidatorSet.MaxBlocks <= 0 {
+		errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.ValidatorSet.MaxBlocks %d: must be greater than 0", c.DataNodeDBExtension.ValidatorSet.MaxBlocks))
+	}
+
+	if c.DataNodeDBExtension.Enabled && c.DataNodeDBExtension.TxFailures.Enabled && c.DataNodeDBExtension.TxFailures.Window <= 0 {
+		errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.TxFailures.Window %s: must be greater than 0", c.DataNodeDBExtension.TxFailures.Window))
+	}
+
+	if heartbeats := c.DataNodeDBExtension.ValidatorHeartbeats; c.DataNodeDBExtension.Enabled && heartbeats.Enabled {
+		if heartbeats.MaxSinceLastHeartbeat <= 0 {
+			errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.ValidatorHeartbeats.MaxSinceLastHeartbeat %s: must be greater than 0", heartbeats.MaxSinceLastHeartbeat))
+		}
+		if heartbeats.Window < heartbeats.MaxSinceLastHeartbeat {
+			errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.ValidatorHeartbeats.Window %s: must not be shorter than MaxSinceLastHeartbeat", heartbeats.Window))
+		}
+		if !c.CometBFT.TxFilter.Keeps(entities.CometCommandValidatorHeartbeat) {
+			errs = append(errs, fmt.Errorf("DataNodeDBExtension.ValidatorHeartbeats requires CometBFT.TxFilter to store the %q txs", entities.CometCommandValidatorHeartbeat))
+		}
+		if !c.DataNodeDBExtension.ValidatorSet.Enabled {
+			errs = append(errs, errors.New("DataNodeDBExtension.ValidatorHeartbeats requires DataNodeDBExtension.ValidatorSet, the expected validators are read from the validator set"))
+		}
+	}
+
+	if segments := c.DataNodeDBExtension.NetworkHistorySegments; c.DataNodeDBExtension.Enabled && segments.Enabled {
+		if segments.Concurrency < 1 {
+			errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.NetworkHistorySegments.Concurrency %d: must be at least 1", segments.Concurrency))
+		}
+		if segments.NodeTimeout <= 0 {
+			errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.NetworkHistorySegments.NodeTimeout %s: must be greater than 0", segments.NodeTimeout))
+		}
+	}
+
+	if reconciliation := c.DataNodeDBExtension.BridgeReconciliation; c.DataNodeDBExtension.Enabled && reconciliation.Enabled {
+		if reconciliation.Tolerance < 0 {
+			errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.BridgeReconciliation.Tolerance %f: must not be negative", reconciliation.Tolerance))
+		}
+	}
+
+	if divergence := c.DataNodeDBExtension.SegmentDivergence; c.DataNodeDBExtension.Enabled && divergence.Enabled {
+		if divergence.Window <= 0 {
+			errs = append(errs, fmt.Errorf("invalid DataNodeDBExtension.SegmentDivergence.Window %s: must be greater than 0", divergence.Window))
+		}
+	}
+
+	if c.DataNodeDBExtension.Enabled && c.DataNodeDBExtension.DataNode.Enabled {
+		if len(c.Monitoring.LocalNode.REST) < 1 || c.Monitoring.LocalNode.Type != "datanode" {
+			errs = append(errs, errors.New("DataNodeDBExtension.DataNode requires Monitoring.LocalNode with REST endpoint and Type = datanode"))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+type monitoredNode struct {
+	name string
+	rest string
+}
+
+func (c *MonitoringConfig) validate() []error {
+	var errs []error
+
+	checkNodes := func(section string, nodes []monitoredNode) {
+		seen := map[string]struct{}{}
+		for idx, node := range nodes {
+			if len(node.name) < 1 {
+				errs = append(errs, fmt.Errorf("missing Monitoring.%s[%d].Name", section, idx))
+			}
+			if _, ok := seen[node.name]; ok {
+				errs = append(errs, fmt.Errorf("duplicated Monitoring.%s name %q", section, node.name))
+			}
+			seen[node.name] = struct{}{}
+			if len(node.rest) < 1 {
+				errs = append(errs, fmt.Errorf("missing Monitoring.%s[%d].REST for %q", section, idx, node.name))
+			}
+		}
+	}
+
+	nodes := []monitoredNode{}
+	for _, node := range c.Core {
+		nodes = append(nodes, monitoredNode{name: node.Name, rest: node.REST})
+	}
+	checkNodes("Core", nodes)
+
+	nodes = []monitoredNode{}
+	for _, node := range c.DataNode {
+		nodes = append(nodes, monitoredNode{name: node.Name, rest: node.REST})
+	}
+	checkNodes("DataNode", nodes)
+
+	nodes = []monitoredNode{}
+	for _, node := range c.BlockExplorer {
+		nodes = append(nodes, monitoredNode{name: node.Name, rest: node.REST})
+	}
+	checkNodes("BlockExplorer", nodes)
+
+	if c.LocalNode.Enabled {
+		switch c.LocalNode.Type {
+		case "core", "datanode", "blockexplorer":
+		default:
+			errs = append(errs, fmt.Errorf("invalid Monitoring.LocalNode.Type %q: expected one of core, datanode, blockexplorer", c.LocalNode.Type))
+		}
+	}
+
+	for _, chain := range c.EthereumChain {
+		if chain.Period <= 0 {
+			errs = append(errs, fmt.Errorf("invalid Monitoring.EthereumChain.Period for %q: must be greater than 0", chain.NodeName))
+		}
+		for _, call := range chain.Calls {
+			if _, err := abi.JSON(strings.NewReader(call.ABI)); err != nil {
+				errs = append(errs, fmt.Errorf("invalid ABI for the Monitoring.EthereumChain.Calls %q: %w", call.Name, err))
+			}
+		}
+		for _, event := range chain.Events {
+			if _, err := abi.JSON(strings.NewReader(event.ABI)); err != nil {
+				errs = append(errs, fmt.Errorf("invalid ABI for the Monitoring.EthereumChain.Events %q: %w", event.Name, err))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+type BalanceExclusionKind string
+
+const (
+	WithdrawalExclusion BalanceExclusionKind = "WITHDRAWAL"
+	DepositExclusion    BalanceExclusionKind = "DEPOSIT"
+)
+
+func (k BalanceExclusionKind) IsValid() error {
+	switch k {
+	case WithdrawalExclusion, DepositExclusion:
+		return nil
+	}
+	return fmt.Errorf("invalid balance exclusion kind %s: expected %s or %s", k, WithdrawalExclusion, DepositExclusion)
+}
+
+// BalanceExclusion is a withdrawal or a deposit excluded from the network balances. ID is hex encoded. Network is
+// the chain ID of the Vega network the exclusion applies to, or nil for all networks. The exclusion is ignored
+// after ExpiresAt.
+type BalanceExclusion struct {
+	Kind      BalanceExclusionKind `db:"kind"`
+	ID        string               `db:"id"`
+	Network   *string              `db:"network"`
+	Reason    string               `db:"reason"`
+	Author    string               `db:"author"`
+	CreatedAt time.Time            `db:"created_at"`
+	ExpiresAt *time.Time           `db:"expires_at"`
+}
@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// Tables checked for the missing block heights
+const (
+	BlockGapsBlockSigners = "block_signers"
+	BlockGapsCometTxs     = "comet_txs"
+)
+
+// BlockRange is a range of block heights, both ends inclusive.
+type BlockRange struct {
+	FromHeight int64 `db:"from_height"`
+	ToHeight   int64 `db:"to_height"`
+}
+
+func (r BlockRange) Len() int64 {
+	if r.ToHeight < r.FromHeight {
+		return 0
+	}
+
+	return r.ToHeight - r.FromHeight + 1
+}
+
+// BlockGapsStatus is the result of the last scan of a table for the missing block heights.
+type BlockGapsStatus struct {
+	TableName string `db:"table_name"`
+	// FromHeight and ToHeight are the scanned range of blocks
+	FromHeight     int64     `db:"from_height"`
+	ToHeight       int64     `db:"to_height"`
+	Gaps           int32     `db:"gaps"`
+	MissingHeights int64     `db:"missing_heights"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
@@ -11,3 +11,17 @@ type BlockSigner struct {
 	Role     BlockSignerRole                   `db:"role"`
 	TmPubKey vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
 }
+
+// BlockVote is the precommit of a validator in the commit of a block.
+type BlockVote struct {
+	VegaTime time.Time                         `db:"vega_time"`
+	Height   int64                             `db:"height"`
+	Round    int32                             `db:"round"`
+	TmPubKey vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
+	VoteTime time.Time                         `db:"vote_time"`
+}
+
+// Latency returns the time between the block time and the vote.
+func (v BlockVote) Latency() time.Duration {
+	return v.VoteTime.Sub(v.VegaTime)
+}
@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+)
+
+// BlockStats are the statistics of a single block. BlockInterval is the time since the previous block, and Round
+// is the consensus round, in which the block was committed. TxsPerCommand is map[command]count of all txs,
+// regardless of the comet txs filter.
+type BlockStats struct {
+	Height           int64
+	BlockTime        time.Time
+	BlockInterval    *time.Duration
+	ProposerTmPubKey vega_entities.TendermintPublicKey
+	Round            int32
+	SizeBytes        int64
+	TxCount          int64
+	FailedTxCount    int64
+	TxsPerCommand    map[string]int64
+}
@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ReconciliationBalance is a balance of an asset from the metrics.network_balances table, with the decimals of
+// the asset. AssetID is hex encoded.
+type ReconciliationBalance struct {
+	BalanceTime   time.Time         `db:"balance_time"`
+	AssetID       string            `db:"asset_id"`
+	AssetSymbol   string            `db:"asset_symbol"`
+	ChainID       string            `db:"chain_id"`
+	BalanceSource BalanceSourceType `db:"balance_source"`
+	Balance       decimal.Decimal   `db:"balance"`
+	Decimals      int               `db:"decimals"`
+}
+
+// BridgeReconciliation compares the balance of the asset pool on the bridge with the funds held by the network
+// at BalanceTime. Discrepancy is AssetPool - (PartiesTotal + UnrealisedWithdrawals - UnfinalizedDeposits), so
+// a negative discrepancy means the bridge holds less than the network owes. All amounts are in the asset's
+// smallest unit.
+type BridgeReconciliation struct {
+	BalanceTime           time.Time       `db:"balance_time"`
+	AssetID               string          `db:"asset_id"`
+	AssetSymbol           string          `db:"asset_symbol"`
+	ChainID               string          `db:"chain_id"`
+	AssetPool             decimal.Decimal `db:"asset_pool"`
+	PartiesTotal          decimal.Decimal `db:"parties_total"`
+	UnrealisedWithdrawals decimal.Decimal `db:"unrealised_withdrawals"`
+	UnfinalizedDeposits   decimal.Decimal `db:"unfinalized_deposits"`
+	Discrepancy           decimal.Decimal `db:"discrepancy"`
+	Decimals              int             `db:"decimals"`
+	WithinTolerance       bool            `db:"within_tolerance"`
+}
+
+// DiscrepancyInAssetUnits returns the discrepancy divided by 10^Decimals.
+func (r BridgeReconciliation) DiscrepancyInAssetUnits() float64 {
+	return r.Discrepancy.Shift(int32(-r.Decimals)).InexactFloat64()
+}
@@ -0,0 +1,49 @@
+package entities
+
+const (
+	CometCommandChainEvent            = "Chain Event"
+	CometCommandNodeVote              = "Node Vote"
+	CometCommandStateVariableProposal = "State Variable Proposal"
+	CometCommandIssueSignatures       = "Issue Signatures"
+)
+
+// CometCommandTx identifies a decoded comet tx. The block time is taken from the data-node blocks table.
+type CometCommandTx struct {
+	Height    int64
+	HeightIdx int
+	Code      int
+	Submitter string
+}
+
+// ChainEvent is a decoded "Chain Event" command. EventType is the name of the event oneof, e.g. erc20.
+// Nonce is an unsigned 64-bit integer, so it is stored as a decimal string.
+type ChainEvent struct {
+	CometCommandTx
+	TxID      string
+	Nonce     string
+	EventType string
+}
+
+// NodeVote is a decoded "Node Vote" command.
+type NodeVote struct {
+	CometCommandTx
+	Reference string
+	VoteType  string
+}
+
+// StateVariableProposal is a decoded "State Variable Proposal" command.
+type StateVariableProposal struct {
+	CometCommandTx
+	StateVarID      string
+	EventID         string
+	KeyValueBundles int
+}
+
+// IssueSignatures is a decoded "Issue Signatures" command. SignaturesSubmitter is the Ethereum address, which
+// submits the signatures to the bridge, not the submitter of the tx.
+type IssueSignatures struct {
+	CometCommandTx
+	SignaturesSubmitter string
+	Kind                string
+	ValidatorNodeID     string
+}
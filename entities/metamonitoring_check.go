@@ -7,18 +7,38 @@ import (
 type MonitoringServiceType string
 
 const (
-	BlockSignersSvc       MonitoringServiceType = "BLOCK_SIGNERS"
-	DataNodeSvc           MonitoringServiceType = "DATA_NODE"
-	SegmentsSvc           MonitoringServiceType = "SEGMENTS"
-	CometTxsSvc           MonitoringServiceType = "COMET_TXS"
-	NetworkBalancesSvc    MonitoringServiceType = "NETWORK_BALANCES"
-	AssetPricesSvc        MonitoringServiceType = "ASSET_PRICES"
-	PromEthereumCallsSvc  MonitoringServiceType = "PROMETHEUS_ETHEREUM_CALLS_SERVICE"
-	PromEthNodeScannerSvc MonitoringServiceType = "PROMETHEUS_ETH_NODE_SCANNER"
-	PromNodeScannerSvc    MonitoringServiceType = "PROMETHEUS_NODE_SCANNER"
-	PromMetamonitoringSvc MonitoringServiceType = "PROMETHEUS_METAMONITORING"
+	BlockSignersSvc         MonitoringServiceType = "BLOCK_SIGNERS"
+	DataNodeSvc             MonitoringServiceType = "DATA_NODE"
+	SegmentsSvc             MonitoringServiceType = "SEGMENTS"
+	CometTxsSvc             MonitoringServiceType = "COMET_TXS"
+	NetworkBalancesSvc      MonitoringServiceType = "NETWORK_BALANCES"
+	AssetPricesSvc          MonitoringServiceType = "ASSET_PRICES"
+	PromEthereumCallsSvc    MonitoringServiceType = "PROMETHEUS_ETHEREUM_CALLS_SERVICE"
+	PromEthNodeScannerSvc   MonitoringServiceType = "PROMETHEUS_ETH_NODE_SCANNER"
+	PromNodeScannerSvc      MonitoringServiceType = "PROMETHEUS_NODE_SCANNER"
+	PromMetamonitoringSvc   MonitoringServiceType = "PROMETHEUS_METAMONITORING"
+	BlockGapsSvc            MonitoringServiceType = "BLOCK_GAPS"
+	ProposerFairnessSvc     MonitoringServiceType = "PROPOSER_FAIRNESS"
+	ValidatorSetSvc         MonitoringServiceType = "VALIDATOR_SET"
+	TxFailuresSvc           MonitoringServiceType = "TX_FAILURES"
+	CometStreamSvc          MonitoringServiceType = "COMET_STREAM"
+	ValidatorHeartbeatsSvc  MonitoringServiceType = "VALIDATOR_HEARTBEATS"
+	SegmentDivergenceSvc    MonitoringServiceType = "SEGMENT_DIVERGENCE"
+	BridgeReconciliationSvc MonitoringServiceType = "BRIDGE_RECONCILIATION"
 )
 
+// IsBuiltIn returns true for the services with dedicated fields in the meta-monitoring statuses.
+// Other services are registered by the scrapers.
+func (s MonitoringServiceType) IsBuiltIn() bool {
+	switch s {
+	case BlockSignersSvc, DataNodeSvc, SegmentsSvc, CometTxsSvc, NetworkBalancesSvc, AssetPricesSvc,
+		PromEthereumCallsSvc, PromEthNodeScannerSvc, PromNodeScannerSvc, PromMetamonitoringSvc:
+		return true
+	}
+
+	return false
+}
+
 var AllMonitoringServices = []MonitoringServiceType{
 	BlockSignersSvc,
 	SegmentsSvc,
@@ -46,6 +66,11 @@ const (
 	ReasonEthereumContractCallFailure         UnhealthyReason = 5
 	ReasonEthereumContractInvalidResponseType UnhealthyReason = 6
 	ReasonEthereumContractEventFilterFailure  UnhealthyReason = 7
+
+	ReasonValidatorTxsRejected         UnhealthyReason = 9
+	ReasonValidatorHeartbeatsLate      UnhealthyReason = 10
+	ReasonSegmentsDiverged             UnhealthyReason = 11
+	ReasonBridgeReconciliationMismatch UnhealthyReason = 12
 )
 
 type MonitoringStatus struct {
@@ -73,6 +98,14 @@ func UnHealthyReasonString(reason UnhealthyReason) string {
 		return "Failed to call ethereum smart contract"
 	case ReasonEthereumContractEventFilterFailure:
 		return "Failed to filter ethereum smart contract event"
+	case ReasonValidatorTxsRejected:
+		return "Too many rejected validator transactions"
+	case ReasonValidatorHeartbeatsLate:
+		return "Validators are late with heartbeats"
+	case ReasonSegmentsDiverged:
+		return "Network history segments diverged between data-nodes"
+	case ReasonBridgeReconciliationMismatch:
+		return "Asset pool balance does not match the funds held by the network"
 	}
 
 	return "Unknown reason"
@@ -14,11 +14,12 @@ const (
 	AssetPoolBalanceType                  BalanceSourceType = "ASSET_POOL"
 	PartiesTotalBalanceType               BalanceSourceType = "PARTIES_TOTAL"
 	UnrealisedWithdrawalsTotalBalanceType BalanceSourceType = "UNREALISED_WITHDRAWALS_TOTAL"
+	UnfinalizedDepositsBalanceType        BalanceSourceType = "UNFINALIZED_DEPOSITS"
 )
 
 func (n BalanceSourceType) IsValid() error {
 	switch n {
-	case AssetPoolBalanceType, PartiesTotalBalanceType, UnrealisedWithdrawalsTotalBalanceType:
+	case AssetPoolBalanceType, PartiesTotalBalanceType, UnrealisedWithdrawalsTotalBalanceType, UnfinalizedDepositsBalanceType:
 		return nil
 	}
 	return fmt.Errorf("Invalid Ethereum network %s", n)
@@ -31,9 +32,11 @@ type NetworkBalance struct {
 	BalanceSource           BalanceSourceType
 	Balance                 decimal.Decimal
 	ChainID                 string
+	// BlockNumber is the block on the bridge chain, at which the asset pool balance has been read
+	BlockNumber *uint64
 }
 
-func NewAssetPoolBalance(assetID entities.AssetID, time time.Time, assetHexAddress, chainID string, balance decimal.Decimal) NetworkBalance {
+func NewAssetPoolBalance(assetID entities.AssetID, time time.Time, assetHexAddress, chainID string, balance decimal.Decimal, blockNumber uint64) NetworkBalance {
 	return NetworkBalance{
 		AssetID:                 assetID,
 		BalanceTime:             time,
@@ -41,5 +44,16 @@ func NewAssetPoolBalance(assetID entities.AssetID, time time.Time, assetHexAddre
 		ChainID:                 chainID,
 		BalanceSource:           AssetPoolBalanceType,
 		Balance:                 balance,
+		BlockNumber:             &blockNumber,
 	}
 }
+
+// AssetPoolBalanceStatus is the result of reading the asset pool balance of the asset from its bridge chain.
+// Error is empty, when the balance has been stored.
+type AssetPoolBalanceStatus struct {
+	AssetID     string
+	AssetSymbol string
+	ChainID     string
+	Healthy     bool
+	Error       string
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"time"
+
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+)
+
+// Epoch is the range of blocks of a Vega epoch. LastBlock is nil for the current epoch.
+type Epoch struct {
+	ID         int64  `db:"id"`
+	FirstBlock int64  `db:"first_block"`
+	LastBlock  *int64 `db:"last_block"`
+}
+
+// ValidatorSetEntry is a validator in the active validator set of an epoch.
+type ValidatorSetEntry struct {
+	EpochSeq    int64                             `db:"epoch_seq"`
+	Height      int64                             `db:"height"`
+	TmPubKey    vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
+	Address     string                            `db:"address"`
+	VotingPower int64                             `db:"voting_power"`
+}
+
+// ProposerFairness compares the number of blocks proposed by a validator in an epoch with the number
+// expected from its share of the voting power.
+type ProposerFairness struct {
+	EpochSeq          int64                             `db:"epoch_seq"`
+	TmPubKey          vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
+	VotingPower       int64                             `db:"voting_power"`
+	VotingPowerShare  float64                           `db:"voting_power_share"`
+	TotalBlocks       int64                             `db:"total_blocks"`
+	ExpectedProposals float64                           `db:"expected_proposals"`
+	ActualProposals   int64                             `db:"actual_proposals"`
+	// Deviation is (ActualProposals - ExpectedProposals) / ExpectedProposals
+	Deviation float64 `db:"deviation"`
+	// Complete is false while the epoch is in progress, or its blocks have not been processed yet
+	Complete  bool      `db:"complete"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// ProposerFairnessReport is the proposer fairness with the name of the validator node.
+type ProposerFairnessReport struct {
+	ProposerFairness
+	Name *string `db:"name"`
+}
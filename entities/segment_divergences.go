@@ -0,0 +1,31 @@
+package entities
+
+import "time"
+
+type SegmentDivergenceKind string
+
+const (
+	// SegmentDivergent is a segment, which differs from the segment of the majority of the data-nodes
+	SegmentDivergent SegmentDivergenceKind = "DIVERGENT"
+	// SegmentMissing is a segment of other data-nodes, which the data-node does not have, even though it has
+	// segments before and after the height
+	SegmentMissing SegmentDivergenceKind = "MISSING"
+	// SegmentNoConsensus is a segment at a height, where the data-nodes produced different segments, and no segment
+	// is produced by more than half of them
+	SegmentNoConsensus SegmentDivergenceKind = "NO_CONSENSUS"
+)
+
+// SegmentDivergence is a network history segment of a data-node, which does not match the other data-nodes.
+// VegaTime is the time of the block at the height. MajoritySegmentID is nil, when no segment is produced by more
+// than half of the data-nodes.
+type SegmentDivergence struct {
+	VegaTime          time.Time             `db:"vega_time"`
+	Height            int64                 `db:"height"`
+	DataNode          string                `db:"data_node"`
+	Kind              SegmentDivergenceKind `db:"kind"`
+	SegmentID         *string               `db:"segment_id"`
+	MajoritySegmentID *string               `db:"majority_segment_id"`
+	MajorityNodes     int                   `db:"majority_nodes"`
+	TotalNodes        int                   `db:"total_nodes"`
+	DetectedAt        time.Time             `db:"detected_at"`
+}
@@ -0,0 +1,29 @@
+package entities
+
+// ValidatorCommands are the commands submitted only by validator nodes. Rejections of these commands are an early
+// sign of a misconfigured node.
+var ValidatorCommands = []string{
+	"Validator Heartbeat",
+	CometCommandNodeVote,
+	"Node Signature",
+	CometCommandChainEvent,
+	CometCommandStateVariableProposal,
+}
+
+// TxFailure is a comet tx with a non-zero result code. Category is the classified error, e.g. spam_rejection.
+type TxFailure struct {
+	Height    int64   `db:"height"`
+	HeightIdx int     `db:"height_idx"`
+	Code      int     `db:"code"`
+	Submitter string  `db:"submitter"`
+	Command   string  `db:"command"`
+	Category  string  `db:"category"`
+	Info      *string `db:"info"`
+}
+
+// TxFailuresCount is the number of failed txs of a submitter and command in a time window.
+type TxFailuresCount struct {
+	Submitter string `db:"submitter"`
+	Command   string `db:"command"`
+	Failures  int64  `db:"failures"`
+}
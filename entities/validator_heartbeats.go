@@ -0,0 +1,57 @@
+package entities
+
+import "time"
+
+const CometCommandValidatorHeartbeat = "Validator Heartbeat"
+
+type HeartbeatStatus string
+
+const (
+	// HeartbeatOK is a validator with a recent successful heartbeat
+	HeartbeatOK HeartbeatStatus = "OK"
+	// HeartbeatFailing is a validator, which last heartbeat tx has been rejected
+	HeartbeatFailing HeartbeatStatus = "FAILING"
+	// HeartbeatLate is a validator without a successful heartbeat for longer than the threshold
+	HeartbeatLate HeartbeatStatus = "LATE"
+	// HeartbeatMissing is a validator without a successful heartbeat in the whole window
+	HeartbeatMissing HeartbeatStatus = "MISSING"
+	// HeartbeatNotValidator is a submitter of heartbeats outside the current validator set, e.g. an ersatz node or
+	// a validator, which has left the set. It never alerts.
+	HeartbeatNotValidator HeartbeatStatus = "NOT_VALIDATOR"
+)
+
+// IsAlerting returns true for the statuses, which lead to the demotion of the validator when they persist.
+func (s HeartbeatStatus) IsAlerting() bool {
+	return s == HeartbeatLate || s == HeartbeatMissing
+}
+
+// HeartbeatTx is a Validator Heartbeat comet tx.
+type HeartbeatTx struct {
+	VegaTime  time.Time `db:"vega_time"`
+	Height    int64     `db:"height"`
+	Code      int       `db:"code"`
+	Submitter string    `db:"submitter"`
+}
+
+// HeartbeatValidator is a validator node expected to send heartbeats. Submitter is the hex encoded Vega pub key.
+type HeartbeatValidator struct {
+	Submitter string  `db:"submitter"`
+	Name      *string `db:"name"`
+}
+
+// ValidatorHeartbeat is the heartbeat cadence of a validator in the time window before CheckedAt. The intervals
+// are between the successful heartbeats, and SinceLast is the time from the last successful heartbeat to CheckedAt.
+type ValidatorHeartbeat struct {
+	CheckedAt           time.Time       `db:"vega_time"`
+	Submitter           string          `db:"submitter"`
+	Name                *string         `db:"name"`
+	Heartbeats          int64           `db:"heartbeats"`
+	FailedHeartbeats    int64           `db:"failed_heartbeats"`
+	LastHeartbeatAt     *time.Time      `db:"last_heartbeat_at"`
+	LastHeartbeatHeight *int64          `db:"last_heartbeat_height"`
+	LastFailedCode      *int            `db:"last_failed_code"`
+	AvgIntervalSeconds  *float64        `db:"avg_interval_seconds"`
+	MaxIntervalSeconds  *float64        `db:"max_interval_seconds"`
+	SinceLastSeconds    *float64        `db:"since_last_seconds"`
+	Status              HeartbeatStatus `db:"status"`
+}
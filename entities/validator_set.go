@@ -0,0 +1,37 @@
+package entities
+
+import (
+	"time"
+
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+)
+
+type ValidatorSetChangeType string
+
+const (
+	// ValidatorSetSnapshot is a member of the first recorded validator set
+	ValidatorSetSnapshot        ValidatorSetChangeType = "SNAPSHOT"
+	ValidatorJoined             ValidatorSetChangeType = "JOINED"
+	ValidatorLeft               ValidatorSetChangeType = "LEFT"
+	ValidatorVotingPowerChanged ValidatorSetChangeType = "VOTING_POWER_CHANGED"
+)
+
+// ValidatorSetChange is a change of a validator in the CometBFT validator set at a height.
+// VotingPower is 0 for validators, which left the set.
+type ValidatorSetChange struct {
+	VegaTime            time.Time                         `db:"vega_time"`
+	Height              int64                             `db:"height"`
+	TmPubKey            vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
+	Address             string                            `db:"address"`
+	Change              ValidatorSetChangeType            `db:"change"`
+	VotingPower         int64                             `db:"voting_power"`
+	PreviousVotingPower int64                             `db:"previous_voting_power"`
+	ProposerPriority    int64                             `db:"proposer_priority"`
+}
+
+// ValidatorSetMember is a validator in the validator set at a height.
+type ValidatorSetMember struct {
+	TmPubKey    vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
+	Address     string                            `db:"address"`
+	VotingPower int64                             `db:"voting_power"`
+}
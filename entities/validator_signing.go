@@ -0,0 +1,32 @@
+package entities
+
+import (
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+)
+
+type ValidatorNode struct {
+	Name     string                            `db:"name"`
+	TmPubKey vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
+}
+
+// ValidatorSigningStatus describes how a validator signs the recent blocks, in which it was in the active validator set.
+type ValidatorSigningStatus struct {
+	TmPubKey string
+	Name     string
+	// MissedBlocksStreak is the number of consecutive blocks the validator has not signed
+	MissedBlocksStreak int64
+	// SignedBlocks is the number of signed blocks in the rolling window of WindowBlocks
+	SignedBlocks int64
+	WindowBlocks int64
+	// LastSignedHeight is 0 if the validator has not signed any block since the tracking started
+	LastSignedHeight int64
+}
+
+// SignedRatio returns signed blocks to all blocks ratio in the rolling window.
+func (s ValidatorSigningStatus) SignedRatio() float64 {
+	if s.WindowBlocks < 1 {
+		return 0
+	}
+
+	return float64(s.SignedBlocks) / float64(s.WindowBlocks)
+}
@@ -8,11 +8,16 @@ import (
 	"github.com/vegaprotocol/vega-monitoring/cmd/datanode"
 	"github.com/vegaprotocol/vega-monitoring/cmd/etherscan"
 	"github.com/vegaprotocol/vega-monitoring/cmd/ethutils"
+	"github.com/vegaprotocol/vega-monitoring/cmd/exclusions"
 	"github.com/vegaprotocol/vega-monitoring/cmd/grafana"
 	"github.com/vegaprotocol/vega-monitoring/cmd/service"
 	"github.com/vegaprotocol/vega-monitoring/cmd/sqlstore"
 	"github.com/vegaprotocol/vega-monitoring/cmd/update"
+	"github.com/vegaprotocol/vega-monitoring/cmd/validators"
 	"github.com/vegaprotocol/vega-monitoring/cmd/version"
+
+	// Scrapers register themselves in the scraper registry
+	_ "github.com/vegaprotocol/vega-monitoring/scraper/builtin"
 )
 
 func main() {
@@ -31,4 +36,6 @@ func init() {
 	rootCmd.RootCmd.AddCommand(version.VersionCmd)
 	rootCmd.RootCmd.AddCommand(grafana.GrafanaCmd)
 	rootCmd.RootCmd.AddCommand(datanode.DataNodeCmd)
+	rootCmd.RootCmd.AddCommand(validators.ValidatorsCmd)
+	rootCmd.RootCmd.AddCommand(exclusions.ExclusionsCmd)
 }
@@ -22,6 +22,7 @@ const responseCacheTime = 30 * time.Second
 
 type readService interface {
 	GetMetaMonitoringStatusesExtended(context.Context) (*read.MetaMonitoringStatusesExtended, error)
+	GetBlockGapsStatuses(context.Context) ([]entities.BlockGapsStatus, error)
 }
 
 type healthCheckStatusDetails struct {
@@ -38,6 +39,14 @@ func newHealthCheckStatusDetailsFromReadStatusDetails(details read.StatusDetails
 	}
 }
 
+type healthCheckBlockGapsDetails struct {
+	FromHeight     int64
+	ToHeight       int64
+	Gaps           int32
+	MissingHeights int64
+	UpdatedAt      time.Time
+}
+
 type healthCheckResponseDetails struct {
 	AssetPricesData             healthCheckStatusDetails
 	BlockSignersData            healthCheckStatusDetails
@@ -53,11 +62,21 @@ type healthCheckResponseDetails struct {
 	PrometheusEthNodeScannerData healthCheckStatusDetails
 	PrometheusNodeScannerData    healthCheckStatusDetails
 	PrometheusMetamonitoringData healthCheckStatusDetails
+
+	Scrapers map[string]healthCheckStatusDetails `json:",omitempty"`
+	// BlockGaps are the blocks missing in the tables at the last scan. They are filled in by the block_gaps scraper.
+	BlockGaps map[string]healthCheckBlockGapsDetails `json:",omitempty"`
 }
 
 type healthCheckResponse struct {
-	Healthy bool
-	Details healthCheckResponseDetails
+	Healthy      bool
+	Role         string               `json:",omitempty"`
+	ConfigReload *config.ReloadStatus `json:",omitempty"`
+	Details      healthCheckResponseDetails
+}
+
+type roleProvider interface {
+	Role() string
 }
 
 type HealthCheckService struct {
@@ -67,6 +86,9 @@ type HealthCheckService struct {
 	config       config.HealthCheckConfig
 	lastResponse *healthCheckResponse
 	logger       *logging.Logger
+	roleProvider roleProvider
+	// expectedServices returns the enabled scrapers, which must report healthy status
+	expectedServices func() []entities.MonitoringServiceType
 }
 
 func NewHealthCheckService(cfg config.HealthCheckConfig, readService readService, logger *logging.Logger) (*HealthCheckService, error) {
@@ -78,6 +100,66 @@ func NewHealthCheckService(cfg config.HealthCheckConfig, readService readService
 	}, nil
 }
 
+// WithRole adds role of the instance in the high availability mode to the response.
+func (hc *HealthCheckService) WithRole(provider roleProvider) *HealthCheckService {
+	hc.roleProvider = provider
+	return hc
+}
+
+// WithScrapers adds the status of the enabled scrapers to the response. The scrapers missing
+// the status are reported unhealthy.
+func (hc *HealthCheckService) WithScrapers(expectedServices func() []entities.MonitoringServiceType) *HealthCheckService {
+	hc.expectedServices = expectedServices
+	return hc
+}
+
+func (hc *HealthCheckService) scraperStatuses(statuses map[entities.MonitoringServiceType]read.StatusDetails) (map[string]healthCheckStatusDetails, bool) {
+	if hc.expectedServices == nil {
+		return nil, true
+	}
+
+	result := map[string]healthCheckStatusDetails{}
+	healthy := true
+	for _, service := range hc.expectedServices() {
+		if service.IsBuiltIn() {
+			continue
+		}
+
+		details, ok := statuses[service]
+		if !ok {
+			details = read.StatusDetails{
+				Healthy:         false,
+				UpdatedAt:       time.Unix(0, 0),
+				UnhealthyReason: entities.ReasonMissingStatusFromService,
+			}
+		}
+		result[string(service)] = newHealthCheckStatusDetailsFromReadStatusDetails(details)
+		healthy = healthy && details.Healthy
+	}
+
+	return result, healthy
+}
+
+func (hc *HealthCheckService) blockGaps(ctx context.Context) (map[string]healthCheckBlockGapsDetails, error) {
+	statuses, err := hc.readService.GetBlockGapsStatuses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch block gaps statuses: %w", err)
+	}
+
+	result := map[string]healthCheckBlockGapsDetails{}
+	for _, status := range statuses {
+		result[status.TableName] = healthCheckBlockGapsDetails{
+			FromHeight:     status.FromHeight,
+			ToHeight:       status.ToHeight,
+			Gaps:           status.Gaps,
+			MissingHeights: status.MissingHeights,
+			UpdatedAt:      status.UpdatedAt,
+		}
+	}
+
+	return result, nil
+}
+
 func (hc *HealthCheckService) fetchGrafanaStatus() *healthCheckStatusDetails {
 	resp, err := http.Get(fmt.Sprintf("%s/api/health", strings.TrimRight(hc.config.GrafanaServer.URI, "/")))
 	if err != nil {
@@ -117,8 +199,15 @@ func (hc *HealthCheckService) fetchStatus(ctx context.Context) (*healthCheckResp
 		grafanaServerStatus = hc.fetchGrafanaStatus()
 	}
 
+	scraperStatuses, scrapersHealthy := hc.scraperStatuses(statuses.Scrapers)
+
+	blockGaps, err := hc.blockGaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &healthCheckResponse{
-		Healthy: statuses.HealthyOverAll && (grafanaServerStatus == nil || grafanaServerStatus.Healthy),
+		Healthy: statuses.HealthyOverAll && scrapersHealthy && (grafanaServerStatus == nil || grafanaServerStatus.Healthy),
 		Details: healthCheckResponseDetails{
 			DataNodeData:                newHealthCheckStatusDetailsFromReadStatusDetails(statuses.DataNodeData),
 			AssetPricesData:             newHealthCheckStatusDetailsFromReadStatusDetails(statuses.AssetPricesData),
@@ -128,6 +217,8 @@ func (hc *HealthCheckService) fetchStatus(ctx context.Context) (*healthCheckResp
 			NetworkHistorySegmentsData:  newHealthCheckStatusDetailsFromReadStatusDetails(statuses.NetworkHistorySegmentsData),
 			PrometheusEthereumCallsData: newHealthCheckStatusDetailsFromReadStatusDetails(statuses.PrometheusEthereumCallsData),
 			GrafanaServer:               grafanaServerStatus,
+			Scrapers:                    scraperStatuses,
+			BlockGaps:                   blockGaps,
 		},
 	}, nil
 }
@@ -153,6 +244,11 @@ func (hc *HealthCheckService) handler() http.HandlerFunc {
 			}
 		}
 
+		if hc.roleProvider != nil {
+			hc.lastResponse.Role = hc.roleProvider.Role()
+		}
+		hc.lastResponse.ConfigReload = config.LastReloadStatus()
+
 		response, err := json.MarshalIndent(hc.lastResponse, "", "    ")
 		if err != nil {
 			hc.logger.Error("Failed to marshal response", zap.Error(err), zap.String("requestId", requestId))
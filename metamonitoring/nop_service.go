@@ -23,29 +23,11 @@ func NewNopService() MetamonitoringService {
 	return &nopService{}
 }
 
-func (*nopService) DataNodeStatusPublisher() MonitoringStatusPublisher {
+func (*nopService) StatusPublisher(entities.MonitoringServiceType) MonitoringStatusPublisher {
 	return &nopPublisher{}
 }
 
-func (*nopService) BlockSignersStatusPublisher() MonitoringStatusPublisher {
-	return &nopPublisher{}
-}
-
-func (*nopService) SegmentsStatusPublisher() MonitoringStatusPublisher {
-	return &nopPublisher{}
-}
-
-func (*nopService) CometTxsStatusPublisher() MonitoringStatusPublisher {
-	return &nopPublisher{}
-}
-
-func (*nopService) NetworkBalancesStatusPublisher() MonitoringStatusPublisher {
-	return &nopPublisher{}
-}
-
-func (*nopService) AssetPricesStatusPublisher() MonitoringStatusPublisher {
-	return &nopPublisher{}
-}
+func (*nopService) RemoveService(entities.MonitoringServiceType) {}
 
 func (*nopService) PrometheusEthereumCalls() MonitoringStatusPublisher {
 	return &nopPublisher{}
@@ -2,6 +2,8 @@ package metamonitoring
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"code.vegaprotocol.io/vega/logging"
@@ -22,11 +24,18 @@ type MonitoringStatusPublisher interface {
 
 type monitoringStatusPublisherService struct {
 	store *sqlstore.MonitoringStatus
+	// running is false when statuses are not flushed to the database, e.g. when
+	// the instance is a standby. Statuses published in the meantime are dropped.
+	running *atomic.Bool
 
 	service entities.MonitoringServiceType
 }
 
 func (ps *monitoringStatusPublisherService) Publish(isHealthy bool) error {
+	if !ps.running.Load() {
+		return nil
+	}
+
 	event := entities.MonitoringStatus{
 		StatusTime:      time.Now(),
 		IsHealthy:       isHealthy,
@@ -40,6 +49,10 @@ func (ps *monitoringStatusPublisherService) Publish(isHealthy bool) error {
 }
 
 func (ps *monitoringStatusPublisherService) PublishWithReason(isHealthy bool, reason entities.UnhealthyReason) error {
+	if !ps.running.Load() {
+		return nil
+	}
+
 	event := entities.MonitoringStatus{
 		StatusTime:      time.Now(),
 		IsHealthy:       isHealthy,
@@ -62,12 +75,10 @@ type VegaClient interface {
 }
 
 type MetamonitoringService interface {
-	DataNodeStatusPublisher() MonitoringStatusPublisher
-	BlockSignersStatusPublisher() MonitoringStatusPublisher
-	SegmentsStatusPublisher() MonitoringStatusPublisher
-	CometTxsStatusPublisher() MonitoringStatusPublisher
-	NetworkBalancesStatusPublisher() MonitoringStatusPublisher
-	AssetPricesStatusPublisher() MonitoringStatusPublisher
+	// StatusPublisher returns publisher for the service. The service is expected to publish its status
+	// regularly, otherwise it is reported unhealthy, until it is removed with RemoveService.
+	StatusPublisher(service entities.MonitoringServiceType) MonitoringStatusPublisher
+	RemoveService(service entities.MonitoringServiceType)
 	PrometheusEthereumCalls() MonitoringStatusPublisher
 	Run(ctx context.Context, tickInterval time.Duration)
 }
@@ -78,4 +89,6 @@ type MonitoringStatusUpdateService struct {
 	vegaClient            VegaClient
 	logger                *logging.Logger
 	activeServices        []entities.MonitoringServiceType
+	running               atomic.Bool
+	mut                   sync.Mutex
 }
@@ -3,6 +3,7 @@ package metamonitoring
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"code.vegaprotocol.io/vega/logging"
@@ -20,66 +21,40 @@ func NewMonitoringStatusUpdateService(store MonitoringStore, vegaClient VegaClie
 	}, nil
 }
 
-func (msus *MonitoringStatusUpdateService) DataNodeStatusPublisher() MonitoringStatusPublisher {
-	msus.activeServices = append(msus.activeServices, entities.DataNodeSvc)
-
-	return &monitoringStatusPublisherService{
-		store:   msus.monitoringStatusStore,
-		service: entities.DataNodeSvc,
-	}
-}
-
-func (msus *MonitoringStatusUpdateService) BlockSignersStatusPublisher() MonitoringStatusPublisher {
-	msus.activeServices = append(msus.activeServices, entities.BlockSignersSvc)
-
-	return &monitoringStatusPublisherService{
-		store:   msus.monitoringStatusStore,
-		service: entities.BlockSignersSvc,
-	}
-}
-
-func (msus *MonitoringStatusUpdateService) SegmentsStatusPublisher() MonitoringStatusPublisher {
-	msus.activeServices = append(msus.activeServices, entities.SegmentsSvc)
-
-	return &monitoringStatusPublisherService{
-		store:   msus.monitoringStatusStore,
-		service: entities.SegmentsSvc,
-	}
+func (msus *MonitoringStatusUpdateService) StatusPublisher(service entities.MonitoringServiceType) MonitoringStatusPublisher {
+	return msus.newPublisher(service)
 }
 
-func (msus *MonitoringStatusUpdateService) CometTxsStatusPublisher() MonitoringStatusPublisher {
-	msus.activeServices = append(msus.activeServices, entities.CometTxsSvc)
+// RemoveService stops reporting the service unhealthy, when it does not publish its status, e.g. when it is disabled.
+func (msus *MonitoringStatusUpdateService) RemoveService(service entities.MonitoringServiceType) {
+	msus.mut.Lock()
+	defer msus.mut.Unlock()
 
-	return &monitoringStatusPublisherService{
-		store:   msus.monitoringStatusStore,
-		service: entities.CometTxsSvc,
-	}
+	msus.activeServices = slices.DeleteFunc(msus.activeServices, func(active entities.MonitoringServiceType) bool {
+		return active == service
+	})
 }
 
-func (msus *MonitoringStatusUpdateService) NetworkBalancesStatusPublisher() MonitoringStatusPublisher {
-	msus.activeServices = append(msus.activeServices, entities.NetworkBalancesSvc)
-
+func (msus *MonitoringStatusUpdateService) PrometheusEthereumCalls() MonitoringStatusPublisher {
 	return &monitoringStatusPublisherService{
 		store:   msus.monitoringStatusStore,
-		service: entities.NetworkBalancesSvc,
+		running: &msus.running,
+		service: entities.PromEthereumCallsSvc,
 	}
 }
 
-func (msus *MonitoringStatusUpdateService) AssetPricesStatusPublisher() MonitoringStatusPublisher {
-	msus.activeServices = append(msus.activeServices, entities.AssetPricesSvc)
+func (msus *MonitoringStatusUpdateService) newPublisher(service entities.MonitoringServiceType) MonitoringStatusPublisher {
+	msus.mut.Lock()
+	defer msus.mut.Unlock()
 
-	return &monitoringStatusPublisherService{
-		store:   msus.monitoringStatusStore,
-		service: entities.AssetPricesSvc,
+	if !slices.Contains(msus.activeServices, service) {
+		msus.activeServices = append(msus.activeServices, service)
 	}
-}
-
-func (msus *MonitoringStatusUpdateService) PrometheusEthereumCalls() MonitoringStatusPublisher {
-	msus.activeServices = append(msus.activeServices, entities.AssetPricesSvc)
 
 	return &monitoringStatusPublisherService{
 		store:   msus.monitoringStatusStore,
-		service: entities.PromEthereumCallsSvc,
+		running: &msus.running,
+		service: service,
 	}
 }
 
@@ -122,11 +97,28 @@ func (msus *MonitoringStatusUpdateService) isNodeUpToDate(ctx context.Context) (
 }
 
 func (msus *MonitoringStatusUpdateService) Run(ctx context.Context, tickInterval time.Duration) {
-	time.Sleep(tickInterval + 3)
+	msus.running.Store(true)
+	defer func() {
+		msus.running.Store(false)
+		if err := msus.monitoringStatusStore.Clear(); err != nil {
+			msus.logger.Error("failed to clear pending health checks", zap.Error(err))
+		}
+	}()
+
+	select {
+	case <-time.After(tickInterval + 3):
+	case <-ctx.Done():
+		return
+	}
 	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
 
 	monitoringStatusStore := msus.monitoringStatusStore
 	for {
+		msus.mut.Lock()
+		activeServices := slices.Clone(msus.activeServices)
+		msus.mut.Unlock()
+
 		isUpToDate, err := msus.isNodeUpToDate(ctx)
 		if err != nil {
 			msus.logger.Errorf("failed to check if node is up to date: %s", err.Error())
@@ -141,7 +133,7 @@ func (msus *MonitoringStatusUpdateService) Run(ctx context.Context, tickInterval
 				msus.logger.Error("failed to flush clear all actual health checks when node is not up to date", zap.Error(err))
 			}
 
-			for _, service := range msus.activeServices {
+			for _, service := range activeServices {
 				if !monitoringStatusStore.IsPendingFor(service) {
 					monitoringStatusStore.Add(entities.MonitoringStatus{
 						StatusTime:      time.Now(),
@@ -154,7 +146,7 @@ func (msus *MonitoringStatusUpdateService) Run(ctx context.Context, tickInterval
 		} else {
 			// Check if all of the monitoring services provided any status update
 			// if not add failed state
-			for _, service := range msus.activeServices {
+			for _, service := range activeServices {
 				if !monitoringStatusStore.IsPendingFor(service) {
 					monitoringStatusStore.Add(entities.MonitoringStatus{
 						StatusTime:      time.Now(),
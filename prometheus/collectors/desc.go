@@ -17,6 +17,8 @@ var desc = struct {
 		dataNodePerformanceRESTInfoDuration *prometheus.Desc
 		dataNodePerformanceGQLInfoDuration  *prometheus.Desc
 		dataNodePerformanceGRPCInfoDuration *prometheus.Desc
+
+		segmentDivergences *prometheus.Desc
 	}
 
 	BlockExplorer struct {
@@ -25,8 +27,37 @@ var desc = struct {
 
 	MetaMonitoring struct {
 		monitoringDatabaseHealthy *prometheus.Desc
+		missingBlockHeights       *prometheus.Desc
+	}
+
+	Validator struct {
+		missedBlocksStreak *prometheus.Desc
+		signedRatio        *prometheus.Desc
+		lastSignedHeight   *prometheus.Desc
+		proposerDeviation  *prometheus.Desc
+		setVotingPower     *prometheus.Desc
+		setHeight          *prometheus.Desc
+
+		heartbeatSinceLast   *prometheus.Desc
+		heartbeatAvgInterval *prometheus.Desc
+		heartbeatFailures    *prometheus.Desc
+		heartbeatStatus      *prometheus.Desc
+	}
+
+	Comet struct {
+		endpointHealthy *prometheus.Desc
+		endpointHeight  *prometheus.Desc
 	}
 
+	Bridge struct {
+		reconciliationDiscrepancy     *prometheus.Desc
+		reconciliationWithinTolerance *prometheus.Desc
+		reconciliationTime            *prometheus.Desc
+		assetPoolBalanceHealthy       *prometheus.Desc
+	}
+
+	HighAvailabilityRole *prometheus.Desc
+
 	EthereumNodeStatus           *prometheus.Desc
 	EthereumNodeHeight           *prometheus.Desc
 	EthereumAccountBalances      *prometheus.Desc
@@ -73,6 +104,9 @@ func init() {
 	desc.DataNode.dataNodePerformanceGRPCInfoDuration = prometheus.NewDesc(
 		"datanode_performance_grpc_info_duration", "Duration of gRPC request to get info about node", []string{"node", "type", "environment", "internal"}, nil,
 	)
+	desc.DataNode.segmentDivergences = prometheus.NewDesc(
+		"network_history_segment_divergences", "Number of network history segments of the data-node, which differ from the majority or are missing, in the checked window", []string{"data_node", "kind"}, nil,
+	)
 
 	//
 	// Block Explorer
@@ -87,6 +121,77 @@ func init() {
 	desc.MetaMonitoring.monitoringDatabaseHealthy = prometheus.NewDesc(
 		"monitoring_db_status", "Status of data in Monitoring Database. 1 good, 0 bad", []string{"data_type"}, nil,
 	)
+	desc.MetaMonitoring.missingBlockHeights = prometheus.NewDesc(
+		"missing_block_heights", "Number of blocks missing in the Monitoring Database table at the last scan", []string{"table"}, nil,
+	)
+
+	//
+	// Validators
+	//
+	desc.Validator.missedBlocksStreak = prometheus.NewDesc(
+		"validator_missed_blocks_streak", "Number of consecutive blocks not signed by the validator", []string{"tm_pub_key", "name"}, nil,
+	)
+	desc.Validator.signedRatio = prometheus.NewDesc(
+		"validator_signed_ratio", "Ratio of blocks signed by the validator in the rolling window of the latest blocks", []string{"tm_pub_key", "name"}, nil,
+	)
+	desc.Validator.lastSignedHeight = prometheus.NewDesc(
+		"validator_last_signed_height", "Height of the last block signed by the validator", []string{"tm_pub_key", "name"}, nil,
+	)
+	desc.Validator.setVotingPower = prometheus.NewDesc(
+		"validator_set_voting_power", "Voting power of the validator in the current CometBFT validator set", []string{"tm_pub_key", "name", "address"}, nil,
+	)
+	desc.Validator.setHeight = prometheus.NewDesc(
+		"validator_set_height", "Last height checked for changes of the CometBFT validator set", nil, nil,
+	)
+	desc.Validator.heartbeatSinceLast = prometheus.NewDesc(
+		"validator_heartbeat_since_last_seconds", "Time from the last successful heartbeat of the validator to the latest stored comet tx", []string{"submitter", "name"}, nil,
+	)
+	desc.Validator.heartbeatAvgInterval = prometheus.NewDesc(
+		"validator_heartbeat_interval_seconds", "Average interval between the successful heartbeats of the validator in the window", []string{"submitter", "name"}, nil,
+	)
+	desc.Validator.heartbeatFailures = prometheus.NewDesc(
+		"validator_heartbeat_failures", "Number of rejected heartbeats of the validator in the window", []string{"submitter", "name"}, nil,
+	)
+	desc.Validator.heartbeatStatus = prometheus.NewDesc(
+		"validator_heartbeat_status", "Heartbeat status of the validator. 1 for the current status, 0 otherwise", []string{"submitter", "name", "status"}, nil,
+	)
+	desc.Validator.proposerDeviation = prometheus.NewDesc(
+		"validator_proposer_deviation", "Relative deviation of blocks proposed by the validator from blocks expected from its voting power in the last complete epoch", []string{"tm_pub_key", "name", "epoch"}, nil,
+	)
+
+	//
+	// CometBFT endpoints
+	//
+	desc.Comet.endpointHealthy = prometheus.NewDesc(
+		"comet_endpoint_healthy", "Health of the CometBFT endpoint at the last check. 1 healthy, 0 unhealthy", []string{"url", "preferred"}, nil,
+	)
+	desc.Comet.endpointHeight = prometheus.NewDesc(
+		"comet_endpoint_height", "Latest block height of the CometBFT endpoint at the last check", []string{"url"}, nil,
+	)
+
+	//
+	// Bridge
+	//
+	desc.Bridge.reconciliationDiscrepancy = prometheus.NewDesc(
+		"bridge_reconciliation_discrepancy", "Asset pool balance minus the funds held by the network, in asset units. Negative when the bridge holds less than the network owes", []string{"asset_id", "asset", "chain_id"}, nil,
+	)
+	desc.Bridge.reconciliationWithinTolerance = prometheus.NewDesc(
+		"bridge_reconciliation_within_tolerance", "1 when the discrepancy of the asset is within the tolerance, 0 otherwise", []string{"asset_id", "asset", "chain_id"}, nil,
+	)
+	desc.Bridge.reconciliationTime = prometheus.NewDesc(
+		"bridge_reconciliation_balance_time", "Unix time of the network balances used by the latest reconciliation of the asset", []string{"asset_id", "asset", "chain_id"}, nil,
+	)
+
+	desc.Bridge.assetPoolBalanceHealthy = prometheus.NewDesc(
+		"asset_pool_balance_healthy", "1 when the asset pool balance of the asset has been read from its bridge chain by the last update, 0 otherwise", []string{"asset_id", "asset", "chain_id"}, nil,
+	)
+
+	//
+	// High Availability
+	//
+	desc.HighAvailabilityRole = prometheus.NewDesc(
+		"ha_role", "Role of this instance in the high availability mode. 1 for the current role, 0 otherwise", []string{"role"}, nil,
+	)
 
 	//
 	// Ethereum chains
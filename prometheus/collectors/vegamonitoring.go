@@ -1,11 +1,15 @@
 package collectors
 
 import (
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
 	"github.com/vegaprotocol/vega-monitoring/prometheus/types"
 	"github.com/vegaprotocol/vega-monitoring/services/read"
 )
@@ -33,6 +37,47 @@ type VegaMonitoringCollector struct {
 
 	// Meta-Monitoring
 	monitoringDatabaseStatuses read.MetaMonitoringStatuses
+	blockGaps                  []entities.BlockGapsStatus
+
+	// Validators signing the blocks
+	validatorSigning  []entities.ValidatorSigningStatus
+	voteLatency       *prometheus.HistogramVec
+	nonZeroRoundVotes *prometheus.CounterVec
+	proposerFairness  []entities.ProposerFairnessReport
+
+	// Validator set
+	validatorSetHeight  int64
+	validatorSet        []entities.ValidatorSetMember
+	validatorSetNames   map[string]string
+	validatorSetChanges *prometheus.CounterVec
+
+	// Validator heartbeats
+	validatorHeartbeats []entities.ValidatorHeartbeat
+
+	// Network history segments
+	segmentDivergences         []entities.SegmentDivergence
+	segmentDivergenceDataNodes []string
+	segmentsFetchDuration      *prometheus.HistogramVec
+	latestSegmentHeight        *prometheus.GaugeVec
+
+	// Bridge
+	bridgeReconciliations    []entities.BridgeReconciliation
+	assetPoolBalanceStatuses []entities.AssetPoolBalanceStatus
+
+	// Comet txs
+	txFailures *prometheus.CounterVec
+
+	// Blocks
+	blockInterval prometheus.Histogram
+	blockTxs      prometheus.Histogram
+
+	// CometBFT endpoints
+	cometEndpoints        []comet.EndpointStatus
+	cometFailovers        *prometheus.CounterVec
+	cometQuorumMismatches prometheus.Counter
+
+	// High Availability
+	haRole string
 
 	// Ethereum Node Statuses
 	ethNodeStatuses []types.EthereumNodeStatus
@@ -52,6 +97,51 @@ func NewVegaMonitoringCollector() *VegaMonitoringCollector {
 		ethNodeHeights:          map[string]types.EthereumNodeHeight{},
 
 		contractEvents: map[types.EntityHash]types.EthereumContractsEvents{},
+
+		voteLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "validator_vote_latency_seconds",
+			Help:    "Time between the block time and the precommit of the validator for the block",
+			Buckets: []float64{0.1, 0.25, 0.5, 0.75, 1, 1.5, 2, 3, 5, 10},
+		}, []string{"tm_pub_key", "name"}),
+		nonZeroRoundVotes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_non_zero_round_votes_total",
+			Help: "Number of votes of the validator for blocks, which were not committed in the first round",
+		}, []string{"tm_pub_key", "name"}),
+		validatorSetChanges: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "validator_set_changes_total",
+			Help: "Number of changes of the CometBFT validator set",
+		}, []string{"tm_pub_key", "name", "change"}),
+		txFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "comet_tx_failures_total",
+			Help: "Number of comet txs with a non-zero result code, by the classified error category. The submitter is set only for the validator commands",
+		}, []string{"command", "submitter", "category"}),
+		blockInterval: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_interval_seconds",
+			Help:    "Time between the block and the previous block",
+			Buckets: []float64{0.25, 0.5, 0.75, 1, 1.25, 1.5, 2, 3, 5, 10},
+		}),
+		blockTxs: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "block_txs",
+			Help:    "Number of txs in the block",
+			Buckets: []float64{0, 1, 5, 10, 25, 50, 100, 250, 500, 1000},
+		}),
+		cometFailovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "comet_endpoint_failovers_total",
+			Help: "Number of changes of the preferred CometBFT endpoint",
+		}, []string{"from", "to"}),
+		cometQuorumMismatches: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "comet_quorum_mismatches_total",
+			Help: "Number of blocks, for which the CometBFT endpoints returned different block hashes",
+		}),
+		segmentsFetchDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "network_history_segments_fetch_duration_seconds",
+			Help:    "Time to fetch the network history segments from the data-node",
+			Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 3, 5, 10, 20, 30},
+		}, []string{"data_node", "result"}),
+		latestSegmentHeight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "network_history_latest_segment_height",
+			Help: "Height of the latest stored network history segment of the data-node",
+		}, []string{"data_node"}),
 	}
 }
 
@@ -123,6 +213,143 @@ func (c *VegaMonitoringCollector) UpdateMonitoringDBStatuses(newStatuses read.Me
 	c.monitoringDatabaseStatuses = newStatuses
 }
 
+func (c *VegaMonitoringCollector) UpdateBlockGaps(statuses []entities.BlockGapsStatus) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.blockGaps = statuses
+}
+
+func (c *VegaMonitoringCollector) UpdateValidatorSigning(statuses []entities.ValidatorSigningStatus) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.validatorSigning = statuses
+}
+
+func (c *VegaMonitoringCollector) UpdateProposerFairness(results []entities.ProposerFairnessReport) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.proposerFairness = results
+}
+
+// UpdateValidatorSet sets the current validator set. names is map[tendermint-pub-key]node-name.
+func (c *VegaMonitoringCollector) UpdateValidatorSet(height int64, members []entities.ValidatorSetMember, names map[string]string) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.validatorSetHeight = height
+	c.validatorSet = members
+	c.validatorSetNames = names
+}
+
+// ObserveValidatorSetChanges counts the changes of the validator set, except the snapshot. names is map[tendermint-pub-key]node-name.
+func (c *VegaMonitoringCollector) ObserveValidatorSetChanges(changes []entities.ValidatorSetChange, names map[string]string) {
+	for _, change := range changes {
+		if change.Change == entities.ValidatorSetSnapshot {
+			continue
+		}
+		tmPubKey := change.TmPubKey.String()
+		c.validatorSetChanges.WithLabelValues(tmPubKey, names[tmPubKey], string(change.Change)).Inc()
+	}
+}
+
+// UpdateValidatorHeartbeats sets the heartbeat cadence of the validators.
+func (c *VegaMonitoringCollector) UpdateValidatorHeartbeats(heartbeats []entities.ValidatorHeartbeat) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.validatorHeartbeats = heartbeats
+}
+
+// UpdateSegmentDivergences sets the network history segment divergences found by the last check.
+func (c *VegaMonitoringCollector) UpdateSegmentDivergences(divergences []entities.SegmentDivergence, dataNodes []string) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.segmentDivergences = divergences
+	c.segmentDivergenceDataNodes = dataNodes
+}
+
+// UpdateBridgeReconciliations sets the latest reconciliation of every asset and chain.
+func (c *VegaMonitoringCollector) UpdateBridgeReconciliations(reconciliations []entities.BridgeReconciliation) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.bridgeReconciliations = reconciliations
+}
+
+// UpdateAssetPoolBalanceStatuses sets the status of the asset pool balance of every enabled asset.
+func (c *VegaMonitoringCollector) UpdateAssetPoolBalanceStatuses(statuses []entities.AssetPoolBalanceStatus) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.assetPoolBalanceStatuses = statuses
+}
+
+// ObserveSegmentsFetch records the duration of the fetch of the network history segments from the data-node.
+func (c *VegaMonitoringCollector) ObserveSegmentsFetch(dataNode string, duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	c.segmentsFetchDuration.WithLabelValues(dataNode, result).Observe(duration.Seconds())
+}
+
+// UpdateLatestSegmentHeight sets the height of the latest stored network history segment of the data-node.
+func (c *VegaMonitoringCollector) UpdateLatestSegmentHeight(dataNode string, height int64) {
+	c.latestSegmentHeight.WithLabelValues(dataNode).Set(float64(height))
+}
+
+// ObserveTxFailures counts the failed txs per command, submitter and error category. The submitter label is kept
+// only for the validator commands, as any party can submit the other commands.
+func (c *VegaMonitoringCollector) ObserveTxFailures(failures []entities.TxFailure) {
+	for _, failure := range failures {
+		submitter := ""
+		if slices.Contains(entities.ValidatorCommands, failure.Command) {
+			submitter = failure.Submitter
+		}
+		c.txFailures.WithLabelValues(failure.Command, submitter, failure.Category).Inc()
+	}
+}
+
+// ObserveBlockVotes adds the votes for a block to the vote latency histogram. names is map[tendermint-pub-key]node-name.
+// ObserveBlocksStats adds the interval and the number of txs of the new blocks to the histograms.
+func (c *VegaMonitoringCollector) ObserveBlocksStats(stats []entities.BlockStats) {
+	for _, block := range stats {
+		if block.BlockInterval != nil {
+			c.blockInterval.Observe(block.BlockInterval.Seconds())
+		}
+		c.blockTxs.Observe(float64(block.TxCount))
+	}
+}
+
+// UpdateCometEndpoints sets the health of the CometBFT endpoints.
+func (c *VegaMonitoringCollector) UpdateCometEndpoints(statuses []comet.EndpointStatus) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.cometEndpoints = statuses
+}
+
+// ObserveCometFailover counts the changes of the preferred CometBFT endpoint.
+func (c *VegaMonitoringCollector) ObserveCometFailover(from string, to string) {
+	c.cometFailovers.WithLabelValues(from, to).Inc()
+}
+
+// ObserveCometQuorumMismatch counts the blocks, for which the CometBFT endpoints disagree on the block hash.
+func (c *VegaMonitoringCollector) ObserveCometQuorumMismatch(height int64) {
+	c.cometQuorumMismatches.Inc()
+}
+
+func (c *VegaMonitoringCollector) ObserveBlockVotes(votes []entities.BlockVote, names map[string]string) {
+	for _, vote := range votes {
+		tmPubKey := vote.TmPubKey.String()
+		c.voteLatency.WithLabelValues(tmPubKey, names[tmPubKey]).Observe(vote.Latency().Seconds())
+		if vote.Round > 0 {
+			c.nonZeroRoundVotes.WithLabelValues(tmPubKey, names[tmPubKey]).Inc()
+		}
+	}
+}
+
+func (c *VegaMonitoringCollector) UpdateHighAvailabilityRole(role string) {
+	c.accessMu.Lock()
+	defer c.accessMu.Unlock()
+	c.haRole = role
+}
+
 func (c *VegaMonitoringCollector) UpdateEthereumNodeStatuses(nodeHealthy []types.EthereumNodeStatus) {
 	c.accessMu.Lock()
 	defer c.accessMu.Unlock()
@@ -168,6 +395,49 @@ func (c *VegaMonitoringCollector) Describe(ch chan<- *prometheus.Desc) {
 
 	// MetaMonitoring: Monitoring Database
 	ch <- desc.MetaMonitoring.monitoringDatabaseHealthy
+	ch <- desc.MetaMonitoring.missingBlockHeights
+
+	// Validators
+	ch <- desc.Validator.missedBlocksStreak
+	ch <- desc.Validator.signedRatio
+	ch <- desc.Validator.lastSignedHeight
+	ch <- desc.Validator.proposerDeviation
+	ch <- desc.Validator.setVotingPower
+	ch <- desc.Validator.setHeight
+	c.validatorSetChanges.Describe(ch)
+	c.voteLatency.Describe(ch)
+	c.nonZeroRoundVotes.Describe(ch)
+	ch <- desc.Validator.heartbeatSinceLast
+	ch <- desc.Validator.heartbeatAvgInterval
+	ch <- desc.Validator.heartbeatFailures
+	ch <- desc.Validator.heartbeatStatus
+
+	// Network history segments
+	ch <- desc.DataNode.segmentDivergences
+	c.segmentsFetchDuration.Describe(ch)
+	c.latestSegmentHeight.Describe(ch)
+
+	// Bridge
+	ch <- desc.Bridge.reconciliationDiscrepancy
+	ch <- desc.Bridge.reconciliationWithinTolerance
+	ch <- desc.Bridge.reconciliationTime
+	ch <- desc.Bridge.assetPoolBalanceHealthy
+
+	// Comet txs
+	c.txFailures.Describe(ch)
+
+	// Blocks
+	c.blockInterval.Describe(ch)
+	c.blockTxs.Describe(ch)
+
+	// CometBFT endpoints
+	ch <- desc.Comet.endpointHealthy
+	ch <- desc.Comet.endpointHeight
+	c.cometFailovers.Describe(ch)
+	c.cometQuorumMismatches.Describe(ch)
+
+	// High Availability
+	ch <- desc.HighAvailabilityRole
 
 	// Ethereum Node Statuses
 	ch <- desc.EthereumNodeStatus
@@ -188,6 +458,20 @@ func (c *VegaMonitoringCollector) Collect(ch chan<- prometheus.Metric) {
 	c.collectDataNodeStatuses(ch)
 	c.collectBlockExplorerStatuses(ch)
 	c.collectMonitoringDatabaseStatuses(ch)
+	c.collectBlockGaps(ch)
+	c.collectValidatorSigning(ch)
+	c.collectProposerFairness(ch)
+	c.collectValidatorSet(ch)
+	c.collectValidatorHeartbeats(ch)
+	c.collectSegmentDivergences(ch)
+	c.segmentsFetchDuration.Collect(ch)
+	c.latestSegmentHeight.Collect(ch)
+	c.collectBridge(ch)
+	c.txFailures.Collect(ch)
+	c.blockInterval.Collect(ch)
+	c.blockTxs.Collect(ch)
+	c.collectCometEndpoints(ch)
+	c.collectHighAvailabilityRole(ch)
 	c.collectEthereumNodeStatuses(ch)
 	c.collectEthereumNodesHeights(ch)
 	c.collectEthereumAccountBalances(ch)
@@ -326,6 +610,9 @@ func (c *VegaMonitoringCollector) collectMonitoringDatabaseStatuses(ch chan<- pr
 			"network_balances":         c.monitoringDatabaseStatuses.NetworkBalancesData,
 			"network_history_segments": c.monitoringDatabaseStatuses.NetworkHistorySegmentsData,
 		}
+		for service, value := range c.monitoringDatabaseStatuses.Scrapers {
+			fieldToValue[strings.ToLower(string(service))] = value
+		}
 
 		for dataType, value := range fieldToValue {
 			if value != nil {
@@ -341,6 +628,219 @@ func (c *VegaMonitoringCollector) collectMonitoringDatabaseStatuses(ch chan<- pr
 	}
 }
 
+func (c *VegaMonitoringCollector) collectBlockGaps(ch chan<- prometheus.Metric) {
+	for _, status := range c.blockGaps {
+		ch <- prometheus.NewMetricWithTimestamp(
+			status.UpdatedAt,
+			prometheus.MustNewConstMetric(
+				desc.MetaMonitoring.missingBlockHeights, prometheus.GaugeValue, float64(status.MissingHeights),
+				// Labels
+				status.TableName,
+			))
+	}
+}
+
+func (c *VegaMonitoringCollector) collectValidatorSigning(ch chan<- prometheus.Metric) {
+	for _, status := range c.validatorSigning {
+		ch <- prometheus.MustNewConstMetric(
+			desc.Validator.missedBlocksStreak, prometheus.GaugeValue, float64(status.MissedBlocksStreak),
+			// Labels
+			status.TmPubKey, status.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			desc.Validator.signedRatio, prometheus.GaugeValue, status.SignedRatio(),
+			// Labels
+			status.TmPubKey, status.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			desc.Validator.lastSignedHeight, prometheus.GaugeValue, float64(status.LastSignedHeight),
+			// Labels
+			status.TmPubKey, status.Name,
+		)
+	}
+	c.voteLatency.Collect(ch)
+	c.nonZeroRoundVotes.Collect(ch)
+}
+
+func (c *VegaMonitoringCollector) collectProposerFairness(ch chan<- prometheus.Metric) {
+	for _, result := range c.proposerFairness {
+		name := ""
+		if result.Name != nil {
+			name = *result.Name
+		}
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			result.UpdatedAt,
+			prometheus.MustNewConstMetric(
+				desc.Validator.proposerDeviation, prometheus.GaugeValue, result.Deviation,
+				// Labels
+				result.TmPubKey.String(), name, strconv.FormatInt(result.EpochSeq, 10),
+			))
+	}
+}
+
+func (c *VegaMonitoringCollector) collectValidatorSet(ch chan<- prometheus.Metric) {
+	if c.validatorSetHeight > 0 {
+		ch <- prometheus.MustNewConstMetric(
+			desc.Validator.setHeight, prometheus.GaugeValue, float64(c.validatorSetHeight),
+		)
+	}
+	for _, member := range c.validatorSet {
+		tmPubKey := member.TmPubKey.String()
+		ch <- prometheus.MustNewConstMetric(
+			desc.Validator.setVotingPower, prometheus.GaugeValue, float64(member.VotingPower),
+			// Labels
+			tmPubKey, c.validatorSetNames[tmPubKey], member.Address,
+		)
+	}
+	c.validatorSetChanges.Collect(ch)
+}
+
+func (c *VegaMonitoringCollector) collectSegmentDivergences(ch chan<- prometheus.Metric) {
+	allKinds := []entities.SegmentDivergenceKind{entities.SegmentDivergent, entities.SegmentMissing, entities.SegmentNoConsensus}
+
+	counts := map[string]map[entities.SegmentDivergenceKind]int{}
+	for _, dataNode := range c.segmentDivergenceDataNodes {
+		counts[dataNode] = map[entities.SegmentDivergenceKind]int{}
+	}
+	for _, divergence := range c.segmentDivergences {
+		if _, ok := counts[divergence.DataNode]; !ok {
+			counts[divergence.DataNode] = map[entities.SegmentDivergenceKind]int{}
+		}
+		counts[divergence.DataNode][divergence.Kind]++
+	}
+
+	for dataNode, nodeCounts := range counts {
+		for _, kind := range allKinds {
+			ch <- prometheus.MustNewConstMetric(
+				desc.DataNode.segmentDivergences, prometheus.GaugeValue, float64(nodeCounts[kind]),
+				// Labels
+				dataNode, string(kind),
+			)
+		}
+	}
+}
+
+func (c *VegaMonitoringCollector) collectBridge(ch chan<- prometheus.Metric) {
+	for _, reconciliation := range c.bridgeReconciliations {
+		withinTolerance := 0.0
+		if reconciliation.WithinTolerance {
+			withinTolerance = 1
+		}
+		labels := []string{reconciliation.AssetID, reconciliation.AssetSymbol, reconciliation.ChainID}
+
+		ch <- prometheus.MustNewConstMetric(
+			desc.Bridge.reconciliationDiscrepancy, prometheus.GaugeValue, reconciliation.DiscrepancyInAssetUnits(), labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			desc.Bridge.reconciliationWithinTolerance, prometheus.GaugeValue, withinTolerance, labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			desc.Bridge.reconciliationTime, prometheus.GaugeValue, float64(reconciliation.BalanceTime.Unix()), labels...,
+		)
+	}
+
+	for _, status := range c.assetPoolBalanceStatuses {
+		healthy := 0.0
+		if status.Healthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			desc.Bridge.assetPoolBalanceHealthy, prometheus.GaugeValue, healthy,
+			// Labels
+			status.AssetID, status.AssetSymbol, status.ChainID,
+		)
+	}
+}
+
+func (c *VegaMonitoringCollector) collectCometEndpoints(ch chan<- prometheus.Metric) {
+	for _, endpoint := range c.cometEndpoints {
+		healthy := 0.0
+		if endpoint.Healthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(
+			desc.Comet.endpointHealthy, prometheus.GaugeValue, healthy,
+			// Labels
+			endpoint.URL, strconv.FormatBool(endpoint.Preferred),
+		)
+		if endpoint.LatestHeight > 0 {
+			ch <- prometheus.MustNewConstMetric(
+				desc.Comet.endpointHeight, prometheus.GaugeValue, float64(endpoint.LatestHeight),
+				// Labels
+				endpoint.URL,
+			)
+		}
+	}
+	c.cometFailovers.Collect(ch)
+	c.cometQuorumMismatches.Collect(ch)
+}
+
+func (c *VegaMonitoringCollector) collectValidatorHeartbeats(ch chan<- prometheus.Metric) {
+	allStatuses := []entities.HeartbeatStatus{
+		entities.HeartbeatOK, entities.HeartbeatFailing, entities.HeartbeatLate, entities.HeartbeatMissing,
+		entities.HeartbeatNotValidator,
+	}
+
+	for _, heartbeat := range c.validatorHeartbeats {
+		name := ""
+		if heartbeat.Name != nil {
+			name = *heartbeat.Name
+		}
+		if heartbeat.SinceLastSeconds != nil {
+			ch <- prometheus.NewMetricWithTimestamp(
+				heartbeat.CheckedAt,
+				prometheus.MustNewConstMetric(
+					desc.Validator.heartbeatSinceLast, prometheus.GaugeValue, *heartbeat.SinceLastSeconds,
+					// Labels
+					heartbeat.Submitter, name,
+				))
+		}
+		if heartbeat.AvgIntervalSeconds != nil {
+			ch <- prometheus.MustNewConstMetric(
+				desc.Validator.heartbeatAvgInterval, prometheus.GaugeValue, *heartbeat.AvgIntervalSeconds,
+				// Labels
+				heartbeat.Submitter, name,
+			)
+		}
+		ch <- prometheus.MustNewConstMetric(
+			desc.Validator.heartbeatFailures, prometheus.GaugeValue, float64(heartbeat.FailedHeartbeats),
+			// Labels
+			heartbeat.Submitter, name,
+		)
+		for _, status := range allStatuses {
+			value := 0.0
+			if status == heartbeat.Status {
+				value = 1
+			}
+			ch <- prometheus.MustNewConstMetric(
+				desc.Validator.heartbeatStatus, prometheus.GaugeValue, value,
+				// Labels
+				heartbeat.Submitter, name, string(status),
+			)
+		}
+	}
+}
+
+func (c *VegaMonitoringCollector) collectHighAvailabilityRole(ch chan<- prometheus.Metric) {
+	if c.haRole == "" {
+		return
+	}
+
+	for _, role := range []string{"leader", "standby"} {
+		value := 0.0
+		if role == c.haRole {
+			value = 1
+		}
+
+		ch <- prometheus.MustNewConstMetric(
+			desc.HighAvailabilityRole, prometheus.GaugeValue, value,
+			// Labels
+			role,
+		)
+	}
+}
+
 func (c *VegaMonitoringCollector) collectEthereumNodeStatuses(ch chan<- prometheus.Metric) {
 	for _, ethNodeStatus := range c.ethNodeStatuses {
 		status := 1.0
@@ -3,6 +3,7 @@ package ethereummonitoring
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -27,6 +28,11 @@ type EthereumMonitoringService struct {
 	logger             *logging.Logger
 	monitoringStatuses []healthStatus
 	msLock             sync.Mutex
+
+	// cfgLock guards cfg and restart
+	cfgLock sync.Mutex
+	// restart stops the current run, so it is started again with the reloaded config
+	restart context.CancelFunc
 }
 
 type healthStatus struct {
@@ -57,7 +63,47 @@ type ethNodeMonitoring struct {
 	rpcEndpoint string
 }
 
+// Start runs the ethereum monitoring until the context is cancelled. When the config is reloaded,
+// all the ethereum monitoring workers are restarted with the new config.
 func (s *EthereumMonitoringService) Start(ctx context.Context, statusPublisher metamonitoring.MonitoringStatusPublisher) error {
+	for {
+		runCtx, restart := context.WithCancel(ctx)
+		var restarted atomic.Bool
+
+		s.cfgLock.Lock()
+		cfg := s.cfg
+		s.restart = func() {
+			restarted.Store(true)
+			restart()
+		}
+		s.cfgLock.Unlock()
+
+		err := s.run(runCtx, cfg, statusPublisher)
+		restart()
+
+		if ctx.Err() != nil || !restarted.Load() {
+			return err
+		}
+		s.logger.Info("Restarting ethereum monitoring after config reload", zap.Int("chains", len(cfg)))
+	}
+}
+
+// Reload restarts the ethereum monitoring if the config has changed.
+func (s *EthereumMonitoringService) Reload(cfg []config.EthereumChain) {
+	s.cfgLock.Lock()
+	defer s.cfgLock.Unlock()
+
+	if reflect.DeepEqual(s.cfg, cfg) {
+		return
+	}
+
+	s.cfg = cfg
+	if s.restart != nil {
+		s.restart()
+	}
+}
+
+func (s *EthereumMonitoringService) run(ctx context.Context, cfg []config.EthereumChain, statusPublisher metamonitoring.MonitoringStatusPublisher) error {
 	var monitoringWg sync.WaitGroup
 
 	svcContext, cancel := context.WithCancel(ctx)
@@ -68,7 +114,7 @@ func (s *EthereumMonitoringService) Start(ctx context.Context, statusPublisher m
 
 	nodeClients := []ethNodeMonitoring{}
 
-	for idx, chainConfig := range s.cfg {
+	for idx, chainConfig := range cfg {
 		if len(chainConfig.RPCEndpoint) < 1 {
 			s.logger.Errorf("failed to start the prometheus ethereum monitoring service for network id %s: empty rpc address", chainConfig.NetworkId)
 			continue
@@ -106,7 +152,7 @@ func (s *EthereumMonitoringService) Start(ctx context.Context, statusPublisher m
 					s.logger.Errorf("failed to start monitoring account balances in the prometheus ethereum monitoring for network id %s: %s", chainConfig.NetworkId, err.Error())
 					cancel()
 				}
-			}(&failure, s.cfg[idx], chainConfig)
+			}(&failure, cfg[idx], chainConfig)
 		}
 
 		if len(chainConfig.Calls) > 0 {
@@ -126,7 +172,7 @@ func (s *EthereumMonitoringService) Start(ctx context.Context, statusPublisher m
 					s.logger.Errorf("failed to start monitoring ethereum calls in the prometheus ethereum monitoring for network id %s: %s", callCfg.NetworkId, err.Error())
 					cancel()
 				}
-			}(&failure, s.cfg[idx], chainConfig)
+			}(&failure, cfg[idx], chainConfig)
 		}
 
 		if len(chainConfig.Events) > 0 {
@@ -144,7 +190,7 @@ func (s *EthereumMonitoringService) Start(ctx context.Context, statusPublisher m
 					failure.Store(true)
 					s.logger.Errorf("failed to start monitoring ethereum events for network id %s: %s", callCfg.NetworkId, err.Error())
 				}
-			}(&failure, s.cfg[idx], chainConfig)
+			}(&failure, cfg[idx], chainConfig)
 		}
 	}
 
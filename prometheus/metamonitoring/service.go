@@ -54,6 +54,13 @@ func (s *MetaMonitoringStatusService) startUpdatingMetamonitoringStatuses(ctx co
 			s.log.Debug("successfully updated Meta-Monitoring statuses in prometheus")
 		}
 
+		blockGaps, err := s.store.GetBlockGapsStatuses(ctx)
+		if err != nil {
+			s.log.Error("Failed to get block gaps statuses from monitoring database", zap.Error(err))
+		} else {
+			s.collector.UpdateBlockGaps(blockGaps)
+		}
+
 		select {
 		case <-ctx.Done():
 			s.log.Info("Stopping Meta-Monitoring statues for Prometheus")
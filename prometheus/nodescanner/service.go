@@ -3,6 +3,7 @@ package nodescanner
 import (
 	"context"
 	"log"
+	"reflect"
 	"sync"
 	"time"
 
@@ -14,104 +15,132 @@ import (
 	"github.com/vegaprotocol/vega-monitoring/config"
 	"github.com/vegaprotocol/vega-monitoring/prometheus/collectors"
 	"github.com/vegaprotocol/vega-monitoring/prometheus/types"
+	"github.com/vegaprotocol/vega-monitoring/scheduler"
 )
 
 type NodeScannerService struct {
-	config    *config.MonitoringConfig
+	mut       sync.Mutex
+	config    config.MonitoringConfig
+	schedule  config.SchedulerConfig
+	jobs      *scheduler.Scheduler
 	collector *collectors.VegaMonitoringCollector
 	log       *logging.Logger
 }
 
 func NewNodeScannerService(
 	config *config.MonitoringConfig,
+	schedule config.SchedulerConfig,
 	collector *collectors.VegaMonitoringCollector,
 	log *logging.Logger,
 ) *NodeScannerService {
 	log = log.With(zap.String("service", "node-scanner"))
-	level, err := logging.ParseLevel(config.Level)
-	if err != nil {
-		log.Warn("Logging level not set for Node Scanner, using default: Info", zap.String("Monitoring.Level", config.Level))
-		level = logging.InfoLevel
-	}
-	log.SetLevel(level)
+	setLogLevel(log, config.Level)
 
 	log.Debug("Node Scanner config", zap.Any("config", *config))
 
 	return &NodeScannerService{
-		config:    config,
+		config:    *config,
+		schedule:  schedule,
+		jobs:      scheduler.NewScheduler(log),
 		collector: collector,
 		log:       log,
 	}
 }
 
-func (s *NodeScannerService) Start(ctx context.Context) error {
-	var wg sync.WaitGroup
+func setLogLevel(log *logging.Logger, levelName string) {
+	level, err := logging.ParseLevel(levelName)
+	if err != nil {
+		log.Warn("Logging level not set for Node Scanner, using default: Info", zap.String("Monitoring.Level", levelName))
+		level = logging.InfoLevel
+	}
+	log.SetLevel(level)
+}
 
+// Jobs returns the scheduler running the node scanners.
+func (s *NodeScannerService) Jobs() *scheduler.Scheduler {
+	return s.jobs
+}
+
+func (s *NodeScannerService) Start(ctx context.Context) error {
+	s.mut.Lock()
 	if s.config.LocalNode.Enabled {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			s.log.Info(
-				"Starting Scanning Local Node go-routine",
-				zap.Bool("Monitoring.LocalNode.Enabled", true),
-				zap.String("type", s.config.LocalNode.Type),
-				zap.String("rest", s.config.LocalNode.REST),
-			)
-			s.startScanningLocalNode(ctx)
-			s.log.Info(
-				"Stopping Scanning Local Node go-routine",
-				zap.String("type", s.config.LocalNode.Type),
-				zap.String("rest", s.config.LocalNode.REST),
-			)
-		}()
+		s.log.Info(
+			"Starting Scanning Local Node",
+			zap.Bool("Monitoring.LocalNode.Enabled", true),
+			zap.String("type", s.config.LocalNode.Type),
+			zap.String("rest", s.config.LocalNode.REST),
+		)
+		s.jobs.Add(config.NodeScannerLocalNodeJob, s.schedule.Job(config.NodeScannerLocalNodeJob), s.scanLocalNode(s.config.LocalNode))
 	} else {
-		s.log.Info("Not starting Scanning Local Node go-routine", zap.Bool("Monitoring.LocalNode.Enabled", false))
+		s.log.Info("Not starting Scanning Local Node", zap.Bool("Monitoring.LocalNode.Enabled", false))
 	}
 
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		time.Sleep(5 * time.Second) // delay by 5 sec
-		s.log.Info("Starting Scanning Cores go-routine", zap.Int("count", len(s.config.Core)))
-		s.startScanningCores(ctx)
-		s.log.Info("Stopping Scanning Cores go-routine", zap.Int("count", len(s.config.Core)))
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		time.Sleep(15 * time.Second) // delay by 15 sec
-		s.log.Info("Starting Scanning Data Nodes go-routine", zap.Int("count", len(s.config.DataNode)))
-		s.startScanningDataNodes(ctx)
-		s.log.Info("Stopping Scanning Data Nodes go-routine", zap.Int("count", len(s.config.DataNode)))
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		time.Sleep(25 * time.Second) // delay by 25 sec
-		s.log.Info("Starting Scanning Block Explorer go-routine", zap.Int("count", len(s.config.BlockExplorer)))
-		s.startScanningBlockExplorers(ctx)
-		s.log.Info("Stopping Scanning Block Explorer go-routine", zap.Int("count", len(s.config.BlockExplorer)))
-	}()
-
-	wg.Wait()
+	s.log.Info("Starting Scanning Cores", zap.Int("count", len(s.config.Core)))
+	s.jobs.Add(config.NodeScannerCoresJob, s.schedule.Job(config.NodeScannerCoresJob), s.scanCores(s.config.Core))
+
+	s.log.Info("Starting Scanning Data Nodes", zap.Int("count", len(s.config.DataNode)))
+	s.jobs.Add(config.NodeScannerDataNodesJob, s.schedule.Job(config.NodeScannerDataNodesJob), s.scanDataNodes(s.config.DataNode))
+
+	s.log.Info("Starting Scanning Block Explorers", zap.Int("count", len(s.config.BlockExplorer)))
+	s.jobs.Add(config.NodeScannerBlockExplorersJob, s.schedule.Job(config.NodeScannerBlockExplorersJob), s.scanBlockExplorers(s.config.BlockExplorer))
+	s.mut.Unlock()
+
+	s.jobs.Run(ctx)
+	s.log.Info("Stopped Node Scanner")
 	return nil
 }
 
-func (s *NodeScannerService) startScanningCores(ctx context.Context) {
+// Reload restarts only the scanners, which config has changed.
+func (s *NodeScannerService) Reload(cfg config.MonitoringConfig, schedule config.SchedulerConfig) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	oldCfg, oldSchedule := s.config, s.schedule
+	s.config, s.schedule = cfg, schedule
+
+	if oldCfg.Level != cfg.Level {
+		setLogLevel(s.log, cfg.Level)
+	}
+
+	scheduleChanged := func(job string) bool {
+		return !reflect.DeepEqual(oldSchedule.Job(job), schedule.Job(job))
+	}
+
+	if !reflect.DeepEqual(oldCfg.Core, cfg.Core) || scheduleChanged(config.NodeScannerCoresJob) {
+		s.log.Info("Restarting Scanning Cores after config reload", zap.Int("count", len(cfg.Core)))
+		s.jobs.Replace(config.NodeScannerCoresJob, schedule.Job(config.NodeScannerCoresJob), s.scanCores(cfg.Core))
+	}
+
+	if !reflect.DeepEqual(oldCfg.DataNode, cfg.DataNode) || scheduleChanged(config.NodeScannerDataNodesJob) {
+		s.log.Info("Restarting Scanning Data Nodes after config reload", zap.Int("count", len(cfg.DataNode)))
+		s.jobs.Replace(config.NodeScannerDataNodesJob, schedule.Job(config.NodeScannerDataNodesJob), s.scanDataNodes(cfg.DataNode))
+	}
 
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
+	if !reflect.DeepEqual(oldCfg.BlockExplorer, cfg.BlockExplorer) || scheduleChanged(config.NodeScannerBlockExplorersJob) {
+		s.log.Info("Restarting Scanning Block Explorers after config reload", zap.Int("count", len(cfg.BlockExplorer)))
+		s.jobs.Replace(config.NodeScannerBlockExplorersJob, schedule.Job(config.NodeScannerBlockExplorersJob), s.scanBlockExplorers(cfg.BlockExplorer))
+	}
+
+	if !reflect.DeepEqual(oldCfg.LocalNode, cfg.LocalNode) || scheduleChanged(config.NodeScannerLocalNodeJob) {
+		if cfg.LocalNode.Enabled {
+			s.log.Info("Restarting Scanning Local Node after config reload", zap.String("type", cfg.LocalNode.Type), zap.String("rest", cfg.LocalNode.REST))
+			s.jobs.Replace(config.NodeScannerLocalNodeJob, schedule.Job(config.NodeScannerLocalNodeJob), s.scanLocalNode(cfg.LocalNode))
+		} else if s.jobs.Has(config.NodeScannerLocalNodeJob) {
+			s.log.Info("Stopping Scanning Local Node after config reload")
+			s.jobs.Remove(config.NodeScannerLocalNodeJob)
+		}
+	}
+}
 
+func (s *NodeScannerService) scanCores(nodes []config.CoreConfig) scheduler.RunFunc {
 	dataNodeClients := map[string]*datanode.DataNodeClient{}
-	for _, node := range s.config.Core {
+	for _, node := range nodes {
 		dataNodeClients[node.Name] = datanode.NewDataNodeClient(node.REST)
 	}
 
-	for {
+	return func(ctx context.Context) error {
 		// Go Cores one-by-one synchroniously
-		for _, node := range s.config.Core {
+		for _, node := range nodes {
 			s.log.Debug("Scanning Core", zap.String("name", node.Name), zap.String("rest", node.REST))
 			coreStatus, _, err := requestCoreStats(dataNodeClients[node.Name], []string{})
 			if err != nil {
@@ -124,36 +153,24 @@ func (s *NodeScannerService) startScanningCores(ctx context.Context) {
 			s.collector.UpdateCoreStatus(node.Name, coreStatus)
 			s.log.Debug("Scanned Core", zap.String("name", node.Name), zap.String("rest", node.REST), zap.Any("status", *coreStatus))
 
-			select {
-			case <-ctx.Done():
-				break
-			default:
-				continue
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
 		}
-
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			continue
-		}
+		return nil
 	}
 }
 
-func (s *NodeScannerService) startScanningDataNodes(ctx context.Context) {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
+func (s *NodeScannerService) scanDataNodes(nodes []config.DataNodeConfig) scheduler.RunFunc {
 	dataNodeClients := map[string]*datanode.DataNodeClient{}
-	for _, node := range s.config.DataNode {
+	for _, node := range nodes {
 		dataNodeClients[node.Name] = datanode.NewDataNodeClient(node.REST)
 	}
 
-	for {
+	return func(ctx context.Context) error {
 		// Go DataNode one-by-one synchroniusly
 		start := time.Now()
-		for _, node := range s.config.DataNode {
+		for _, node := range nodes {
 			s.log.Debug("Scanning Data Node", zap.String("name", node.Name), zap.String("rest", node.REST))
 			dataNodeStatus, err := requestDataNodeStats(dataNodeClients[node.Name])
 			if err != nil {
@@ -176,39 +193,28 @@ func (s *NodeScannerService) startScanningDataNodes(ctx context.Context) {
 			s.collector.UpdateDataNodeStatus(node.Name, dataNodeStatus)
 			s.log.Debug("Scanned Data Node", zap.String("name", node.Name), zap.String("rest", node.REST), zap.Any("status", *dataNodeStatus))
 
-			select {
-			case <-ctx.Done():
-				break
-			default:
-				continue
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
 		}
-		s.log.Debug("Finished scanning data nodes", zap.Int("count", len(s.config.DataNode)), zap.Duration("time", time.Since(start)))
-
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			continue
-		}
+		s.log.Debug("Finished scanning data nodes", zap.Int("count", len(nodes)), zap.Duration("time", time.Since(start)))
+		return nil
 	}
 }
 
-func (s *NodeScannerService) startScanningBlockExplorers(ctx context.Context) {
-	ticker := time.NewTicker(time.Minute)
-	defer ticker.Stop()
-
+func (s *NodeScannerService) scanBlockExplorers(nodes []config.BlockExplorerConfig) scheduler.RunFunc {
 	dataNodeClients := map[string]*datanode.DataNodeClient{}
 	beClients := map[string]*blockexplorer.Client{}
-	for _, node := range s.config.BlockExplorer {
+	for _, node := range nodes {
 		dataNodeClients[node.Name] = datanode.NewDataNodeClient(node.REST)
 	}
-	for _, node := range s.config.BlockExplorer {
+	for _, node := range nodes {
 		beClients[node.Name] = blockexplorer.NewBlockExplorerClient(node.REST)
 	}
-	for {
+
+	return func(ctx context.Context) error {
 		// Go BlockExplorer one-by-one synchronously
-		for _, node := range s.config.BlockExplorer {
+		for _, node := range nodes {
 			s.log.Debug("Scanning Block Explorer", zap.String("name", node.Name), zap.String("rest", node.REST))
 			blockExplorerStatus, err := requestBlockExplorerStats(
 				dataNodeClients[node.Name],
@@ -224,38 +230,26 @@ func (s *NodeScannerService) startScanningBlockExplorers(ctx context.Context) {
 			s.collector.UpdateBlockExplorerStatus(node.Name, blockExplorerStatus)
 			s.log.Debug("Scanned Block Explorer", zap.String("name", node.Name), zap.String("rest", node.REST), zap.Any("status", *blockExplorerStatus))
 
-			select {
-			case <-ctx.Done():
-				break
-			default:
-				continue
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
 		}
-
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			continue
-		}
+		return nil
 	}
 }
 
-func (s *NodeScannerService) startScanningLocalNode(ctx context.Context) {
-	var (
-		coreStatus          *types.CoreStatus
-		dataNodeStatus      *types.DataNodeStatus
-		blockExplorerStatus *types.BlockExplorerStatus
-		node                = &s.config.LocalNode
-		nodeType            = types.NodeType(node.Type)
-	)
-	ticker := time.NewTicker(15 * time.Second)
-	defer ticker.Stop()
+func (s *NodeScannerService) scanLocalNode(node config.LocalNodeConfig) scheduler.RunFunc {
+	nodeType := types.NodeType(node.Type)
 
 	dataNodeClient := datanode.NewDataNodeClient(node.REST)
-	for {
+	return func(ctx context.Context) error {
+		var (
+			coreStatus          *types.CoreStatus
+			dataNodeStatus      *types.DataNodeStatus
+			blockExplorerStatus *types.BlockExplorerStatus
+			err                 error
+		)
 		s.log.Debug("Scanning Local Node", zap.String("name", node.Name), zap.String("type", node.Type), zap.String("rest", node.REST))
-		var err error
 
 		switch nodeType {
 		case types.CoreType:
@@ -308,14 +302,9 @@ func (s *NodeScannerService) startScanningLocalNode(ctx context.Context) {
 				zap.Any("status", *blockExplorerStatus),
 			)
 		default:
-			log.Fatalf("Failed to start scanning Local Node, unknow node type %s", s.config.LocalNode.Type)
+			log.Fatalf("Failed to start scanning Local Node, unknow node type %s", node.Type)
 		}
 
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			continue
-		}
+		return nil
 	}
 }
@@ -0,0 +1,412 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+const defaultInterval = time.Minute
+
+var ErrJobNotFound = errors.New("job not found")
+
+type RunFunc func(ctx context.Context) error
+
+type Job struct {
+	Name   string
+	Config config.JobConfig
+	Run    RunFunc
+}
+
+// JobStatus describes the current state and the result of the last run of a job.
+type JobStatus struct {
+	Name                string
+	Interval            time.Duration
+	Paused              bool
+	Running             bool
+	Runs                int64
+	ConsecutiveFailures int
+	LastRunAt           *time.Time `json:",omitempty"`
+	LastDuration        time.Duration
+	LastError           string     `json:",omitempty"`
+	LastSuccessAt       *time.Time `json:",omitempty"`
+}
+
+type runningJob struct {
+	Job
+	cancel  context.CancelFunc
+	done    chan struct{}
+	trigger chan struct{}
+	wake    chan struct{}
+	// status is guarded by the Scheduler.statusMu
+	status JobStatus
+}
+
+type Scheduler struct {
+	log *logging.Logger
+	mu  sync.Mutex
+	// ctx is set when the scheduler is running
+	ctx  context.Context
+	wg   sync.WaitGroup
+	jobs map[string]*runningJob
+	// stopping are the removed jobs, which may still be running. A job added again with the same name
+	// starts only after the removed one has stopped.
+	stopping map[string]<-chan struct{}
+	// statusMu guards the status of the jobs. It is separate from mu, so the running jobs update it without
+	// waiting for the other calls.
+	statusMu sync.Mutex
+}
+
+func NewScheduler(log *logging.Logger) *Scheduler {
+	return &Scheduler{
+		log:      log.With(zap.String("service", "scheduler")),
+		jobs:     map[string]*runningJob{},
+		stopping: map[string]<-chan struct{}{},
+	}
+}
+
+// Add registers a new job. If the scheduler is already running, the job is started immediately.
+// If a job with the same name exists, it is replaced.
+func (s *Scheduler) Add(name string, cfg config.JobConfig, run RunFunc) {
+	s.add(name, cfg, run, true)
+}
+
+// Replace stops the job with the given name, and starts it again with the new schedule and run function.
+// The initial delay is skipped for the restarted job.
+func (s *Scheduler) Replace(name string, cfg config.JobConfig, run RunFunc) {
+	s.add(name, cfg, run, false)
+}
+
+// Remove stops the job and removes it from the scheduler. It does not wait for the running job to return,
+// the returned channel is closed when the job has stopped.
+func (s *Scheduler) Remove(name string) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stopped := s.stopJob(name)
+	delete(s.jobs, name)
+	if stopped == nil {
+		done := make(chan struct{})
+		close(done)
+		return done
+	}
+	s.stopping[name] = stopped
+
+	return stopped
+}
+
+// Has returns true if a job with the given name is registered.
+func (s *Scheduler) Has(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.jobs[name]
+	return ok
+}
+
+// Pause stops running the job until it is resumed. The job keeps its schedule, so it can still be triggered manually.
+func (s *Scheduler) Pause(name string) error {
+	return s.setPaused(name, true)
+}
+
+// Resume runs the paused job again according to its schedule.
+func (s *Scheduler) Resume(name string) error {
+	return s.setPaused(name, false)
+}
+
+// Trigger runs the job as soon as possible, even if it is paused. The schedule continues from the triggered run.
+func (s *Scheduler) Trigger(name string) error {
+	return s.notify(name, func(job *runningJob) chan struct{} { return job.trigger })
+}
+
+// Wake runs the job as soon as possible, unless it is paused. The schedule continues from the run.
+// It is used by the automatic triggers, so only the manual Trigger runs the paused jobs.
+func (s *Scheduler) Wake(name string) error {
+	return s.notify(name, func(job *runningJob) chan struct{} { return job.wake })
+}
+
+func (s *Scheduler) notify(name string, channel func(job *runningJob) chan struct{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	select {
+	case channel(job) <- struct{}{}:
+	default:
+		// The job has already been notified
+	}
+
+	return nil
+}
+
+// Statuses returns the status of all the registered jobs sorted by name.
+func (s *Scheduler) Statuses() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		statuses = append(statuses, job.status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+// Run starts all the registered jobs and blocks until the context is cancelled and all jobs have stopped.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	s.ctx = ctx
+	for _, job := range s.jobs {
+		s.startJob(job, true, nil)
+	}
+	s.mu.Unlock()
+
+	<-ctx.Done()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	s.ctx = nil
+	s.mu.Unlock()
+}
+
+func (s *Scheduler) add(name string, cfg config.JobConfig, run RunFunc, withInitialDelay bool) {
+	if cfg.Interval <= 0 {
+		s.log.Warn("Invalid job interval, using default", zap.String("job", name), zap.Duration("interval", cfg.Interval), zap.Duration("default", defaultInterval))
+		cfg.Interval = defaultInterval
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The new job waits for the replaced or removed one, so their runs never overlap and the mutex is not held
+	// meanwhile
+	previousDone := s.stopJob(name)
+	if previousDone == nil {
+		previousDone = s.stopping[name]
+	}
+	delete(s.stopping, name)
+	job := &runningJob{
+		Job: Job{
+			Name:   name,
+			Config: cfg,
+			Run:    run,
+		},
+		trigger: make(chan struct{}, 1),
+		wake:    make(chan struct{}, 1),
+		status: JobStatus{
+			Name: name,
+		},
+	}
+	// Keep the history and the paused state of the replaced job
+	if oldJob, ok := s.jobs[name]; ok {
+		s.statusMu.Lock()
+		job.status = oldJob.status
+		s.statusMu.Unlock()
+	}
+	job.status.Interval = cfg.Interval
+	s.jobs[name] = job
+
+	if s.ctx != nil {
+		s.startJob(job, withInitialDelay, previousDone)
+	}
+}
+
+// startJob must be called with the mutex held. The job starts after previousDone is closed, unless it is nil.
+func (s *Scheduler) startJob(job *runningJob, withInitialDelay bool, previousDone <-chan struct{}) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	job.cancel = cancel
+	job.done = make(chan struct{})
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(job.done)
+		if previousDone != nil {
+			<-previousDone
+		}
+		s.runJob(ctx, job, withInitialDelay)
+	}()
+}
+
+// stopJob cancels the job, and returns the channel closed when the job has stopped, or nil when the job is not
+// running. It must be called with the mutex held, and the channel must be waited on only after the mutex is
+// released, so Trigger and the other calls are not blocked until the running scrape returns.
+func (s *Scheduler) stopJob(name string) <-chan struct{} {
+	job, ok := s.jobs[name]
+	if !ok || job.cancel == nil {
+		return nil
+	}
+
+	job.cancel()
+	job.cancel = nil
+
+	return job.done
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job *runningJob, withInitialDelay bool) {
+	logger := s.log.With(zap.String("job", job.Name))
+	logger.Info(
+		"Starting job",
+		zap.Duration("initial-delay", job.Config.InitialDelay),
+		zap.Duration("interval", job.Config.Interval),
+		zap.Duration("timeout", job.Config.Timeout),
+	)
+
+	triggered := false
+	if withInitialDelay {
+		var ok bool
+		if ok, triggered = wait(ctx, job.trigger, job.wake, withJitter(job.Config.InitialDelay, job.Config.Jitter)); !ok {
+			logger.Info("Stopping job")
+			return
+		}
+	}
+
+	for {
+		start := time.Now()
+		if s.isPaused(job) && !triggered {
+			logger.Debug("Job is paused, skipping run")
+		} else {
+			logger.Debug("Running job", zap.Bool("triggered", triggered))
+			s.runAndRecord(ctx, job, logger)
+		}
+
+		s.statusMu.Lock()
+		failures := job.status.ConsecutiveFailures
+		s.statusMu.Unlock()
+
+		delay := nextDelay(job.Config, failures) - time.Since(start)
+		var ok bool
+		if ok, triggered = wait(ctx, job.trigger, job.wake, withJitter(delay, job.Config.Jitter)); !ok {
+			logger.Info("Stopping job")
+			return
+		}
+	}
+}
+
+func (s *Scheduler) runAndRecord(ctx context.Context, job *runningJob, logger *logging.Logger) {
+	start := time.Now()
+	s.statusMu.Lock()
+	job.status.Running = true
+	job.status.LastRunAt = &start
+	s.statusMu.Unlock()
+
+	err := runOnce(ctx, job.Job)
+
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+	job.status.Running = false
+	job.status.Runs++
+	job.status.LastDuration = time.Since(start)
+	if err != nil {
+		job.status.ConsecutiveFailures++
+		job.status.LastError = err.Error()
+		logger.Error("Job failed", zap.Int("consecutive-failures", job.status.ConsecutiveFailures), zap.Error(err))
+	} else {
+		finishedAt := time.Now()
+		job.status.ConsecutiveFailures = 0
+		job.status.LastError = ""
+		job.status.LastSuccessAt = &finishedAt
+	}
+}
+
+func (s *Scheduler) isPaused(job *runningJob) bool {
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	return job.status.Paused
+}
+
+func (s *Scheduler) setPaused(name string, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	s.statusMu.Lock()
+	job.status.Paused = paused
+	s.statusMu.Unlock()
+
+	s.log.Info("Job paused state changed", zap.String("job", name), zap.Bool("paused", paused))
+
+	return nil
+}
+
+func runOnce(ctx context.Context, job Job) error {
+	if job.Config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Config.Timeout)
+		defer cancel()
+	}
+
+	return job.Run(ctx)
+}
+
+// nextDelay returns the delay between the start of the last run and the next one,
+// applying the exponential backoff for consecutive failures.
+func nextDelay(cfg config.JobConfig, failures int) time.Duration {
+	if failures < 1 || cfg.BackoffMultiplier <= 1 {
+		return cfg.Interval
+	}
+
+	delay := float64(cfg.Interval) * math.Pow(cfg.BackoffMultiplier, float64(failures))
+	if cfg.MaxBackoff > 0 && delay > float64(cfg.MaxBackoff) {
+		return cfg.MaxBackoff
+	}
+	if delay > math.MaxInt64 {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(delay)
+}
+
+func withJitter(delay time.Duration, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return delay
+	}
+
+	return delay + time.Duration(rand.Int63n(int64(jitter)))
+}
+
+// wait waits for the given duration or until the job is triggered or woken up. Returns false if the context
+// has been cancelled in the meantime, and true as the second value if the job has been triggered.
+func wait(ctx context.Context, trigger <-chan struct{}, wake <-chan struct{}, d time.Duration) (bool, bool) {
+	if d <= 0 {
+		return ctx.Err() == nil, false
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false, false
+	case <-trigger:
+		return true, true
+	case <-wake:
+		return true, false
+	case <-timer.C:
+		return true, false
+	}
+}
@@ -0,0 +1,155 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+func TestNextDelay(t *testing.T) {
+	testScenarios := []struct {
+		name     string
+		cfg      config.JobConfig
+		failures int
+		result   time.Duration
+	}{
+		{
+			name:     "no failures",
+			cfg:      config.JobConfig{Interval: 30 * time.Second, BackoffMultiplier: 2, MaxBackoff: 5 * time.Minute},
+			failures: 0,
+			result:   30 * time.Second,
+		},
+		{
+			name:     "backoff disabled",
+			cfg:      config.JobConfig{Interval: 30 * time.Second, BackoffMultiplier: 1, MaxBackoff: 5 * time.Minute},
+			failures: 3,
+			result:   30 * time.Second,
+		},
+		{
+			name:     "single failure",
+			cfg:      config.JobConfig{Interval: 30 * time.Second, BackoffMultiplier: 2, MaxBackoff: 5 * time.Minute},
+			failures: 1,
+			result:   time.Minute,
+		},
+		{
+			name:     "consecutive failures",
+			cfg:      config.JobConfig{Interval: 30 * time.Second, BackoffMultiplier: 2, MaxBackoff: 5 * time.Minute},
+			failures: 3,
+			result:   4 * time.Minute,
+		},
+		{
+			name:     "capped by max backoff",
+			cfg:      config.JobConfig{Interval: 30 * time.Second, BackoffMultiplier: 2, MaxBackoff: 5 * time.Minute},
+			failures: 10,
+			result:   5 * time.Minute,
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.result, nextDelay(tc.cfg, tc.failures))
+		})
+	}
+}
+
+func TestTriggerIsNotBlockedByStoppingJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	s := NewScheduler(logging.NewTestLogger())
+	// The slow job ignores the cancellation until it is released, like a scrape waiting for its timeout
+	s.Add("slow", config.JobConfig{Interval: time.Hour}, func(ctx context.Context) error {
+		close(started)
+		<-release
+		return nil
+	})
+	s.Add("other", config.JobConfig{Interval: time.Hour, InitialDelay: time.Hour}, func(ctx context.Context) error {
+		return nil
+	})
+	go s.Run(ctx)
+	<-started
+
+	removed := make(chan struct{})
+	go func() {
+		<-s.Remove("slow")
+		close(removed)
+	}()
+
+	triggered := make(chan error)
+	go func() {
+		// Give Remove the time to cancel the slow job first
+		time.Sleep(10 * time.Millisecond)
+		triggered <- s.Trigger("other")
+	}()
+
+	select {
+	case err := <-triggered:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Trigger blocked by the stopping job")
+	}
+
+	select {
+	case <-removed:
+		t.Fatal("Remove returned before the job stopped")
+	default:
+	}
+	close(release)
+	<-removed
+	assert.False(t, s.Has("slow"))
+}
+
+func TestRemoveDoesNotWaitForRunningJob(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	s := NewScheduler(logging.NewTestLogger())
+	s.Add("slow", config.JobConfig{Interval: time.Hour}, func(ctx context.Context) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	})
+	go s.Run(ctx)
+	<-started
+
+	removed := make(chan (<-chan struct{}))
+	go func() {
+		removed <- s.Remove("slow")
+	}()
+
+	var stopped <-chan struct{}
+	select {
+	case stopped = <-removed:
+	case <-time.After(time.Second):
+		t.Fatal("Remove blocked by the running job")
+	}
+	assert.False(t, s.Has("slow"))
+
+	// The job added again must not overlap with the removed one
+	s.Add("slow", config.JobConfig{Interval: time.Hour}, func(ctx context.Context) error {
+		started <- struct{}{}
+		return nil
+	})
+	select {
+	case <-started:
+		t.Fatal("Added job started before the removed one stopped")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("Added job did not start after the removed one stopped")
+	}
+}
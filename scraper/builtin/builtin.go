@@ -0,0 +1,352 @@
+// Package builtin registers the scrapers shipped with vega-monitoring.
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/datanode"
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/scraper"
+	"github.com/vegaprotocol/vega-monitoring/services/update"
+)
+
+func init() {
+	scraper.Register(scraper.Registration{
+		Name:              config.BlockSignersJob,
+		Title:             "Block Signers",
+		MonitoringService: entities.BlockSignersSvc,
+		Enabled:           func(cfg *config.Config) bool { return cfg.DataNodeDBExtension.BlockSigners.Enabled },
+		Tables:            []string{"metrics.block_signers_hourly", "metrics.block_votes", "metrics.block_votes_hourly"},
+		LongTermTables:    []string{"metrics.block_signers_daily", "metrics.block_votes_daily"},
+		New: func(deps scraper.Dependencies) (scraper.Scraper, error) {
+			return scraper.Func(deps.UpdateService.UpdateBlockSignersAllNew), nil
+		},
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              config.NetworkHistorySegmentsJob,
+		Title:             "Network History Segments",
+		MonitoringService: entities.SegmentsSvc,
+		Enabled:           func(cfg *config.Config) bool { return cfg.DataNodeDBExtension.NetworkHistorySegments.Enabled },
+		DependsOn: func(cfg *config.Config) any {
+			return []any{cfg.DataNodeDBExtension.NetworkHistorySegments, cfg.Monitoring.DataNode}
+		},
+		New: newNetworkHistorySegments,
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              config.CometTxsJob,
+		Title:             "Comet Txs",
+		MonitoringService: entities.CometTxsSvc,
+		Enabled:           func(cfg *config.Config) bool { return cfg.DataNodeDBExtension.CometTxs.Enabled },
+		Tables: []string{
+			"metrics.chain_events",
+			"metrics.node_votes",
+			"metrics.state_variable_proposals",
+			"metrics.issue_signatures",
+			"metrics.blocks_stats",
+			"metrics.blocks_stats_hourly",
+		},
+		LongTermTables: []string{"metrics.blocks_stats_daily"},
+		New: func(deps scraper.Dependencies) (scraper.Scraper, error) {
+			return scraper.Func(deps.UpdateService.UpdateCometTxsAllNew), nil
+		},
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              config.NetworkBalancesJob,
+		Title:             "Network Balances",
+		MonitoringService: entities.NetworkBalancesSvc,
+		Enabled:           func(cfg *config.Config) bool { return cfg.DataNodeDBExtension.NetworkBalances.Enabled },
+		New:               newNetworkBalances,
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              config.AssetPricesJob,
+		Title:             "Asset Prices",
+		MonitoringService: entities.AssetPricesSvc,
+		Enabled:           func(cfg *config.Config) bool { return cfg.DataNodeDBExtension.AssetPrices.Enabled },
+		New: func(deps scraper.Dependencies) (scraper.Scraper, error) {
+			return scraper.Func(deps.UpdateService.UpdateAssetPrices), nil
+		},
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              config.DataNodeHealthJob,
+		Title:             "Data Node Health",
+		MonitoringService: entities.DataNodeSvc,
+		Enabled:           func(cfg *config.Config) bool { return cfg.DataNodeDBExtension.DataNode.Enabled },
+		DependsOn:         func(cfg *config.Config) any { return cfg.Monitoring.LocalNode },
+		New:               newDataNodeHealth,
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              "block_gaps",
+		Title:             "Block Gaps",
+		MonitoringService: entities.BlockGapsSvc,
+		DefaultSchedule:   config.NewJobConfig(10*time.Minute, time.Minute),
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.DataNodeDBExtension.BlockGaps.Enabled &&
+				(cfg.DataNodeDBExtension.BlockSigners.Enabled || cfg.DataNodeDBExtension.CometTxs.Enabled)
+		},
+		DependsOn: func(cfg *config.Config) any { return cfg.DataNodeDBExtension },
+		New:       newBlockGaps,
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              "proposer_fairness",
+		Title:             "Proposer Fairness",
+		MonitoringService: entities.ProposerFairnessSvc,
+		DefaultSchedule:   config.NewJobConfig(10*time.Minute, 40*time.Second),
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.DataNodeDBExtension.ProposerFairness.Enabled && cfg.DataNodeDBExtension.BlockSigners.Enabled
+		},
+		DependsOn: func(cfg *config.Config) any { return cfg.DataNodeDBExtension },
+		New: func(deps scraper.Dependencies) (scraper.Scraper, error) {
+			return scraper.Func(deps.UpdateService.UpdateProposerFairness), nil
+		},
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              "validator_set",
+		Title:             "Validator Set",
+		MonitoringService: entities.ValidatorSetSvc,
+		DefaultSchedule:   config.NewJobConfig(30*time.Second, 10*time.Second),
+		Enabled:           func(cfg *config.Config) bool { return cfg.DataNodeDBExtension.ValidatorSet.Enabled },
+		DependsOn:         func(cfg *config.Config) any { return cfg.DataNodeDBExtension },
+		New: func(deps scraper.Dependencies) (scraper.Scraper, error) {
+			maxBlocks := deps.Config.DataNodeDBExtension.ValidatorSet.MaxBlocks
+
+			return scraper.Func(func(ctx context.Context) error {
+				return deps.UpdateService.UpdateValidatorSet(ctx, maxBlocks)
+			}), nil
+		},
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              "tx_failures",
+		Title:             "Tx Failures",
+		MonitoringService: entities.TxFailuresSvc,
+		DefaultSchedule:   config.NewJobConfig(time.Minute, 50*time.Second),
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.DataNodeDBExtension.TxFailures.Enabled && cfg.DataNodeDBExtension.CometTxs.Enabled
+		},
+		DependsOn: func(cfg *config.Config) any { return cfg.DataNodeDBExtension },
+		Tables:    []string{"metrics.comet_tx_failures", "metrics.comet_tx_failures_hourly"},
+		New:       newTxFailures,
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              "validator_heartbeats",
+		Title:             "Validator Heartbeats",
+		MonitoringService: entities.ValidatorHeartbeatsSvc,
+		DefaultSchedule:   config.NewJobConfig(time.Minute, 50*time.Second),
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.DataNodeDBExtension.ValidatorHeartbeats.Enabled && cfg.DataNodeDBExtension.CometTxs.Enabled
+		},
+		DependsOn: func(cfg *config.Config) any { return cfg.DataNodeDBExtension },
+		Tables:    []string{"metrics.validator_heartbeats"},
+		New:       newValidatorHeartbeats,
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              "segment_divergence",
+		Title:             "Segment Divergence",
+		MonitoringService: entities.SegmentDivergenceSvc,
+		DefaultSchedule:   config.NewJobConfig(5*time.Minute, time.Minute),
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.DataNodeDBExtension.SegmentDivergence.Enabled && cfg.DataNodeDBExtension.NetworkHistorySegments.Enabled
+		},
+		// The data-nodes are compared, so the scraper is created again when they change
+		DependsOn: func(cfg *config.Config) any { return []any{cfg.DataNodeDBExtension, cfg.Monitoring.DataNode} },
+		Tables:    []string{"metrics.network_history_segment_divergences"},
+		New:       newSegmentDivergence,
+	})
+
+	scraper.Register(scraper.Registration{
+		Name:              "bridge_reconciliation",
+		Title:             "Bridge Reconciliation",
+		MonitoringService: entities.BridgeReconciliationSvc,
+		DefaultSchedule:   config.NewJobConfig(time.Minute, 30*time.Second),
+		Enabled: func(cfg *config.Config) bool {
+			return cfg.DataNodeDBExtension.BridgeReconciliation.Enabled && cfg.DataNodeDBExtension.NetworkBalances.Enabled
+		},
+		DependsOn: func(cfg *config.Config) any { return cfg.DataNodeDBExtension },
+		Tables:    []string{"metrics.bridge_reconciliation"},
+		New:       newBridgeReconciliation,
+	})
+}
+
+// Network History Segments
+func newNetworkHistorySegments(deps scraper.Dependencies) (scraper.Scraper, error) {
+	cfg := deps.Config.DataNodeDBExtension.NetworkHistorySegments
+
+	return scraper.Func(func(ctx context.Context) error {
+		apiURLs := []string{}
+		for _, dataNode := range deps.Config.Monitoring.DataNode {
+			apiURLs = append(apiURLs, dataNode.REST)
+		}
+
+		return deps.UpdateService.UpdateNetworkHistorySegments(ctx, apiURLs, cfg.Concurrency, cfg.NodeTimeout)
+	}), nil
+}
+
+// Network Balances
+func newNetworkBalances(deps scraper.Dependencies) (scraper.Scraper, error) {
+	return scraper.Func(func(ctx context.Context) error {
+		var failed []string
+		// All the sources are stored with the same balance time, so they can be reconciled
+		balanceTime := update.NetworkBalanceTime()
+		if err := deps.UpdateService.UpdateAssetPoolBalances(ctx, balanceTime); err != nil {
+			deps.Log.Error("Failed to update Network Balances: Asset Pool", zap.Error(err))
+			failed = append(failed, "Asset Pool")
+		}
+
+		if err := deps.UpdateService.UpdatePartiesTotalBalances(ctx, balanceTime); err != nil {
+			deps.Log.Error("Failed to update Network Balances: Parties Total", zap.Error(err))
+			failed = append(failed, "Parties Total")
+		}
+		if err := deps.UpdateService.UpdateUnrealisedWithdrawalsBalances(ctx, balanceTime); err != nil {
+			deps.Log.Error(
+				"Failed to update Network Balances: Unrealised Withdrawals",
+				zap.Error(err),
+			)
+
+			failed = append(failed, "Unrealised Withdrawals")
+		}
+		if err := deps.UpdateService.UpdateUnfinalizedDepositsBalances(ctx, balanceTime); err != nil {
+			deps.Log.Error("Failed to update Network Balances: Unfinalized Deposits", zap.Error(err))
+			failed = append(failed, "Unfinalized Deposits")
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("failed parts: %s", strings.Join(failed, ", "))
+		}
+		return nil
+	}), nil
+}
+
+// Block Gaps
+func newBlockGaps(deps scraper.Dependencies) (scraper.Scraper, error) {
+	cfg := deps.Config.DataNodeDBExtension
+
+	return scraper.Func(func(ctx context.Context) error {
+		var errs []error
+		if cfg.BlockSigners.Enabled {
+			if err := deps.UpdateService.BackfillBlockSignersGaps(ctx, cfg.BlockGaps.MaxBackfillBlocks); err != nil {
+				errs = append(errs, fmt.Errorf("block signers: %w", err))
+			}
+		}
+		if cfg.CometTxs.Enabled {
+			if err := deps.UpdateService.BackfillCometTxsGaps(ctx, cfg.BlockGaps.MaxBackfillBlocks); err != nil {
+				errs = append(errs, fmt.Errorf("comet txs: %w", err))
+			}
+		}
+
+		return errors.Join(errs...)
+	}), nil
+}
+
+// Tx Failures
+func newTxFailures(deps scraper.Dependencies) (scraper.Scraper, error) {
+	cfg := deps.Config.DataNodeDBExtension.TxFailures
+
+	return scraper.Func(func(ctx context.Context) error {
+		err := deps.UpdateService.CheckValidatorTxFailures(ctx, cfg.Window, cfg.MaxValidatorFailures)
+
+		var rejectedErr *update.ValidatorTxsRejectedError
+		if errors.As(err, &rejectedErr) {
+			return scraper.Unhealthy(entities.ReasonValidatorTxsRejected, err)
+		}
+		return err
+	}), nil
+}
+
+// Validator Heartbeats
+func newValidatorHeartbeats(deps scraper.Dependencies) (scraper.Scraper, error) {
+	cfg := deps.Config.DataNodeDBExtension.ValidatorHeartbeats
+
+	return scraper.Func(func(ctx context.Context) error {
+		err := deps.UpdateService.UpdateValidatorHeartbeats(ctx, cfg.Window, cfg.MaxSinceLastHeartbeat)
+
+		var lateErr *update.ValidatorHeartbeatsLateError
+		if errors.As(err, &lateErr) {
+			return scraper.Unhealthy(entities.ReasonValidatorHeartbeatsLate, err)
+		}
+		return err
+	}), nil
+}
+
+func newSegmentDivergence(deps scraper.Dependencies) (scraper.Scraper, error) {
+	window := deps.Config.DataNodeDBExtension.SegmentDivergence.Window
+	apiURLs := []string{}
+	for _, dataNode := range deps.Config.Monitoring.DataNode {
+		apiURLs = append(apiURLs, dataNode.REST)
+	}
+
+	return scraper.Func(func(ctx context.Context) error {
+		err := deps.UpdateService.CheckNetworkHistorySegments(ctx, apiURLs, window)
+
+		var divergedErr *update.SegmentsDivergedError
+		if errors.As(err, &divergedErr) {
+			return scraper.Unhealthy(entities.ReasonSegmentsDiverged, err)
+		}
+		return err
+	}), nil
+}
+
+func newBridgeReconciliation(deps scraper.Dependencies) (scraper.Scraper, error) {
+	tolerance := deps.Config.DataNodeDBExtension.BridgeReconciliation.Tolerance
+
+	return scraper.Func(func(ctx context.Context) error {
+		err := deps.UpdateService.UpdateBridgeReconciliation(ctx, tolerance)
+
+		var mismatchErr *update.BridgeReconciliationMismatchError
+		if errors.As(err, &mismatchErr) {
+			return scraper.Unhealthy(entities.ReasonBridgeReconciliationMismatch, err)
+		}
+		return err
+	}), nil
+}
+
+// Data Node
+func newDataNodeHealth(deps scraper.Dependencies) (scraper.Scraper, error) {
+	localNodeConfig := deps.Config.Monitoring.LocalNode
+	if len(localNodeConfig.REST) < 1 {
+		return nil, errors.New("missing or invalid config for Local Node Config: missing rest endpoint")
+	}
+
+	if localNodeConfig.Type != "datanode" {
+		return nil, errors.New("missing or invalid config for Local Node Config: type must be data node")
+	}
+
+	dataNodeClient := datanode.NewDataNodeClient(localNodeConfig.REST)
+
+	return scraper.Func(func(ctx context.Context) error {
+		callCtx, cancel := context.WithTimeout(ctx, time.Second*5)
+		isHealthy, err := dataNodeClient.IsHealthy(callCtx)
+		cancel()
+		if isHealthy {
+			return nil
+		}
+
+		failureReason := entities.ReasonUnknown
+		// Map error from data node client to the Monitoring system
+		if errors.Is(err, datanode.ErrBlocksGapTooBig) || errors.Is(err, datanode.ErrTimeGapTooBig) {
+			failureReason = entities.ReasonNodeIsNotUpToDate
+		} else if errors.Is(err, datanode.ErrHttpCallError) {
+			failureReason = entities.ReasonTargetConnectionFailure
+		} else if errors.Is(err, datanode.ErrMissingOrInvalidResponse) {
+			failureReason = entities.ReasonMissingOrInvalidResponse
+		}
+
+		return scraper.Unhealthy(failureReason, fmt.Errorf("cannot check local data-node status: %w", err))
+	}), nil
+}
@@ -0,0 +1,99 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/scraper"
+	_ "github.com/vegaprotocol/vega-monitoring/scraper/builtin"
+	"github.com/vegaprotocol/vega-monitoring/sqlstore"
+)
+
+func TestRetentionTables(t *testing.T) {
+	expected := []sqlstore.RetentionTable{
+		{Name: "metrics.block_signers_hourly"},
+		{Name: "metrics.block_signers_daily", LongTerm: true},
+		{Name: "metrics.block_votes"},
+		{Name: "metrics.block_votes_hourly"},
+		{Name: "metrics.block_votes_daily", LongTerm: true},
+		{Name: "metrics.chain_events"},
+		{Name: "metrics.node_votes"},
+		{Name: "metrics.state_variable_proposals"},
+		{Name: "metrics.issue_signatures"},
+		{Name: "metrics.comet_tx_failures"},
+		{Name: "metrics.comet_tx_failures_hourly"},
+		{Name: "metrics.validator_heartbeats"},
+		{Name: "metrics.blocks_stats"},
+		{Name: "metrics.blocks_stats_hourly"},
+		{Name: "metrics.blocks_stats_daily", LongTerm: true},
+		{Name: "metrics.network_history_segment_divergences"},
+		{Name: "metrics.bridge_reconciliation"},
+	}
+
+	tables := scraper.RetentionTables()
+	assert.ElementsMatch(t, expected, tables)
+
+	// The tables of the base policies must not be declared again
+	for _, table := range tables {
+		for _, policy := range sqlstore.StandardRetentionPolicy {
+			assert.NotEqual(t, policy.TableName, table.Name)
+		}
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	testScenarios := []struct {
+		name    string
+		enabled bool
+		jobs    []string
+		err     string
+	}{
+		{name: "default jobs", enabled: true, jobs: []string{config.BlockSignersJob, config.CometTxsJob}},
+		{name: "unknown job", enabled: true, jobs: []string{config.CometTxsJob, "comet_tx"}, err: `invalid CometBFT.Stream.Jobs "comet_tx": no such scraper`},
+		{name: "node scanner job", enabled: true, jobs: []string{config.NodeScannerCoresJob}, err: `invalid CometBFT.Stream.Jobs "node_scanner_cores": no such scraper`},
+		{name: "stream disabled", enabled: false, jobs: []string{"comet_tx"}},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.NewDefaultConfig()
+			cfg.CometBFT.Stream.Enabled = tc.enabled
+			cfg.CometBFT.Stream.Jobs = tc.jobs
+
+			err := scraper.ValidateConfig(&cfg)
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestDependsOnDataNodes(t *testing.T) {
+	oldConfig := config.NewDefaultConfig()
+	oldConfig.Monitoring.DataNode = []config.DataNodeConfig{{Name: "node-1", REST: "https://node-1"}}
+	newConfig := config.NewDefaultConfig()
+	newConfig.Monitoring.DataNode = []config.DataNodeConfig{
+		{Name: "node-1", REST: "https://node-1"},
+		{Name: "node-2", REST: "https://node-2"},
+	}
+
+	registrations := map[string]scraper.Registration{}
+	for _, reg := range scraper.Registered() {
+		registrations[reg.Name] = reg
+	}
+
+	// The scrapers comparing the data-nodes must be created again, when the data-nodes change
+	for _, name := range []string{config.NetworkHistorySegmentsJob, "segment_divergence"} {
+		t.Run(name, func(t *testing.T) {
+			reg, ok := registrations[name]
+			require.True(t, ok)
+			require.NotNil(t, reg.DependsOn)
+			assert.Equal(t, reg.DependsOn(&oldConfig), reg.DependsOn(&oldConfig))
+			assert.NotEqual(t, reg.DependsOn(&oldConfig), reg.DependsOn(&newConfig))
+		})
+	}
+}
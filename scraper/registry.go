@@ -0,0 +1,122 @@
+package scraper
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/sqlstore"
+)
+
+// Registration describes a scraper. Scrapers register themselves in the init function of their package,
+// and the package is imported in main.go.
+type Registration struct {
+	// Name is the unique job name used in the Scheduler.Jobs config section. Must be lower-case.
+	Name string
+	// Title is the human friendly name used in the logs.
+	Title string
+	// MonitoringService is the name the scraper health is reported as in the metrics.monitoring_status table.
+	MonitoringService entities.MonitoringServiceType
+	// DefaultSchedule is used when the job is missing in the Scheduler.Jobs config section.
+	DefaultSchedule config.JobConfig
+	// Enabled returns true if the scraper should run for the given config.
+	Enabled func(cfg *config.Config) bool
+	// DependsOn optionally returns the part of the config the scraper copies when it is created.
+	// When it changes after the config reload, the scraper is created again.
+	DependsOn func(cfg *config.Config) any
+	// Tables are the tables filled in by the scraper, which are covered by the retention policies. They are kept
+	// as long as the data tables of the base policy. The tables of the base policies are already covered.
+	Tables []string
+	// LongTermTables, e.g. the daily aggregates, are kept as long as the daily aggregates of the base policy.
+	LongTermTables []string
+	New            func(deps Dependencies) (Scraper, error)
+}
+
+var registry = struct {
+	mut           sync.Mutex
+	registrations []Registration
+}{}
+
+// Register adds the scraper to the registry. It panics when the registration is invalid or duplicated,
+// because it is called from the init functions.
+func Register(reg Registration) {
+	if len(reg.Name) < 1 || len(reg.MonitoringService) < 1 || reg.Enabled == nil || reg.New == nil {
+		panic(fmt.Sprintf("invalid scraper registration %q: Name, MonitoringService, Enabled and New are required", reg.Name))
+	}
+	if len(reg.Title) < 1 {
+		reg.Title = reg.Name
+	}
+
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	for _, existing := range registry.registrations {
+		if existing.Name == reg.Name || existing.MonitoringService == reg.MonitoringService {
+			panic(fmt.Sprintf("scraper %q or monitoring service %q already registered", reg.Name, reg.MonitoringService))
+		}
+	}
+	registry.registrations = append(registry.registrations, reg)
+
+	if reg.DefaultSchedule.Interval > 0 {
+		config.RegisterDefaultJob(reg.Name, reg.DefaultSchedule)
+	}
+}
+
+// Registered returns all the registered scrapers in the registration order.
+func Registered() []Registration {
+	registry.mut.Lock()
+	defer registry.mut.Unlock()
+
+	return append([]Registration{}, registry.registrations...)
+}
+
+// EnabledServices returns the monitoring services of the scrapers enabled in the config.
+func EnabledServices(cfg *config.Config) []entities.MonitoringServiceType {
+	services := []entities.MonitoringServiceType{}
+	for _, reg := range Registered() {
+		if reg.Enabled(cfg) {
+			services = append(services, reg.MonitoringService)
+		}
+	}
+
+	return services
+}
+
+// RetentionTables returns tables of all the registered scrapers.
+func RetentionTables() []sqlstore.RetentionTable {
+	tables := []sqlstore.RetentionTable{}
+	for _, reg := range Registered() {
+		for _, table := range reg.Tables {
+			tables = append(tables, sqlstore.RetentionTable{Name: table})
+		}
+		for _, table := range reg.LongTermTables {
+			tables = append(tables, sqlstore.RetentionTable{Name: table, LongTerm: true})
+		}
+	}
+
+	return tables
+}
+
+// ValidateConfig checks the parts of the config, which refer to the registered scrapers. It complements
+// config.Validate, which cannot see the registrations.
+func ValidateConfig(cfg *config.Config) error {
+	if !cfg.CometBFT.Stream.Enabled {
+		return nil
+	}
+
+	names := map[string]struct{}{}
+	for _, reg := range Registered() {
+		names[reg.Name] = struct{}{}
+	}
+
+	var errs []error
+	for _, name := range cfg.CometBFT.Stream.Jobs {
+		if _, ok := names[name]; !ok {
+			errs = append(errs, fmt.Errorf("invalid CometBFT.Stream.Jobs %q: no such scraper", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
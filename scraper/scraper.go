@@ -0,0 +1,87 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.vegaprotocol.io/vega/logging"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/metamonitoring"
+	"github.com/vegaprotocol/vega-monitoring/scheduler"
+	"github.com/vegaprotocol/vega-monitoring/services"
+	"github.com/vegaprotocol/vega-monitoring/services/read"
+	"github.com/vegaprotocol/vega-monitoring/services/update"
+)
+
+// Scraper collects data from a data source into the database.
+type Scraper interface {
+	// Run collects the data once. It is called by the scheduler according to the job schedule.
+	// The scraper is reported healthy when Run returns nil. Return an error created with Unhealthy
+	// to report the specific unhealthy reason.
+	Run(ctx context.Context) error
+}
+
+// Func adapts a function to the Scraper interface.
+type Func func(ctx context.Context) error
+
+func (f Func) Run(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Dependencies are the services available to the scrapers when they are created.
+type Dependencies struct {
+	// Config is the config the scraper is created with. It is never changed, the scrapers are created again with
+	// the reloaded config when the part returned by Registration.DependsOn changes.
+	Config        *config.Config
+	Log           *logging.Logger
+	StoreService  *services.StoreService
+	ReadService   *read.ReadService
+	UpdateService *update.UpdateService
+}
+
+// UnhealthyError reports the scraper as unhealthy with the given reason.
+type UnhealthyError struct {
+	Reason entities.UnhealthyReason
+	Err    error
+}
+
+func (e *UnhealthyError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UnhealthyError) Unwrap() error {
+	return e.Err
+}
+
+// Unhealthy wraps the error, so the scraper is reported unhealthy with the given reason.
+func Unhealthy(reason entities.UnhealthyReason, err error) error {
+	return &UnhealthyError{Reason: reason, Err: err}
+}
+
+// NewRunFunc returns the scheduler job running the scraper and publishing its health.
+func NewRunFunc(reg Registration, s Scraper, publisher metamonitoring.MonitoringStatusPublisher, log *logging.Logger) scheduler.RunFunc {
+	return func(ctx context.Context) error {
+		err := s.Run(ctx)
+		if err == nil {
+			if err := publisher.Publish(true); err != nil {
+				log.Error("failed to publish true health check", zap.String("scraper", reg.Name), zap.Error(err))
+			}
+			return nil
+		}
+
+		reason := entities.ReasonUnknown
+		var unhealthy *UnhealthyError
+		if errors.As(err, &unhealthy) {
+			reason = unhealthy.Reason
+		}
+		if err := publisher.PublishWithReason(false, reason); err != nil {
+			log.Error("failed to publish false health check", zap.String("scraper", reg.Name), zap.Error(err))
+		}
+
+		return fmt.Errorf("failed to update %s: %w", reg.Title, err)
+	}
+}
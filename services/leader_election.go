@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/jackc/pgx/v4"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+const (
+	RoleLeader  = "leader"
+	RoleStandby = "standby"
+
+	defaultLeaderElectionRetryInterval = 5 * time.Second
+)
+
+// LeaderElection elects a single leader among all the vega-monitoring instances connected to the same database.
+// The leader holds a session level Postgres advisory lock on a dedicated connection. When the leader releases
+// the lock or its session dies, one of the standby instances takes the lock over.
+type LeaderElection struct {
+	config      config.HighAvailabilityConfig
+	sqlConfig   *config.SQLStoreConfig
+	log         *logging.Logger
+	mut         sync.RWMutex
+	role        string
+	roleChanges []func(role string)
+}
+
+func (s *StoreService) NewLeaderElection(cfg config.HighAvailabilityConfig) *LeaderElection {
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = defaultLeaderElectionRetryInterval
+	}
+
+	return &LeaderElection{
+		config:    cfg,
+		sqlConfig: s.config,
+		log:       s.log.With(zap.String("service", "leader-election")),
+		role:      RoleStandby,
+	}
+}
+
+// OnRoleChange registers a function called every time the role of this instance changes.
+// It must be called before Run.
+func (le *LeaderElection) OnRoleChange(fn func(role string)) {
+	le.roleChanges = append(le.roleChanges, fn)
+}
+
+// IsLeader returns true when this instance holds the leader lock.
+func (le *LeaderElection) IsLeader() bool {
+	return le.Role() == RoleLeader
+}
+
+func (le *LeaderElection) Role() string {
+	le.mut.RLock()
+	defer le.mut.RUnlock()
+
+	return le.role
+}
+
+func (le *LeaderElection) setRole(role string) {
+	le.mut.Lock()
+	le.role = role
+	le.mut.Unlock()
+
+	le.log.Info("Instance role changed", zap.String("role", role))
+	for _, roleChange := range le.roleChanges {
+		roleChange(role)
+	}
+}
+
+// Run campaigns for the leadership until the context is cancelled. Every time this instance becomes
+// the leader, onElected is called with a context, which is cancelled when the leadership is lost.
+// The campaign continues only after onElected has returned. When onElected fails, the instance steps down
+// and campaigns again after the retry interval.
+func (le *LeaderElection) Run(ctx context.Context, onElected func(ctx context.Context) error) {
+	le.setRole(RoleStandby)
+
+	for {
+		conn, err := le.tryLock(ctx)
+		if err != nil {
+			le.log.Error("Failed to acquire leader lock", zap.Error(err))
+		}
+
+		if conn != nil {
+			le.lead(ctx, conn, onElected)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(le.config.RetryInterval):
+			continue
+		}
+	}
+}
+
+// tryLock returns connection holding the advisory lock, or nil if the lock is held by another session.
+func (le *LeaderElection) tryLock(ctx context.Context) (*pgx.Conn, error) {
+	poolConfig, err := le.sqlConfig.GetConnectionConfig().GetPoolConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection config: %w", err)
+	}
+
+	conn, err := pgx.ConnectConfig(ctx, poolConfig.ConnConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", le.config.LockID).Scan(&locked); err != nil {
+		conn.Close(context.Background())
+		return nil, fmt.Errorf("failed to try advisory lock %d: %w", le.config.LockID, err)
+	}
+
+	if !locked {
+		le.log.Debug("Leader lock is held by another instance", zap.Int64("lock-id", le.config.LockID))
+		conn.Close(context.Background())
+		return nil, nil
+	}
+
+	return conn, nil
+}
+
+// lead runs onElected for as long as the session holding the lock is alive.
+func (le *LeaderElection) lead(ctx context.Context, conn *pgx.Conn, onElected func(ctx context.Context) error) {
+	defer func() {
+		// Closing the session releases the lock
+		closeCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.Exec(closeCtx, "SELECT pg_advisory_unlock($1)", le.config.LockID); err != nil {
+			le.log.Debug("Failed to release leader lock", zap.Error(err))
+		}
+		conn.Close(closeCtx)
+	}()
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	le.setRole(RoleLeader)
+	defer le.setRole(RoleStandby)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := onElected(leaderCtx); err != nil && ctx.Err() == nil {
+			le.log.Error("Failed to run as the leader, stepping down", zap.Error(err))
+		}
+	}()
+
+	ticker := time.NewTicker(le.config.RetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			pingCtx, pingCancel := context.WithTimeout(ctx, le.config.RetryInterval)
+			err := conn.Ping(pingCtx)
+			pingCancel()
+			if err != nil && ctx.Err() == nil {
+				le.log.Error("Lost connection holding the leader lock, stepping down", zap.Error(err))
+				cancel()
+				<-done
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,12 @@
+package read
+
+import (
+	"context"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// GetBlockGapsStatuses returns the result of the last scan for missing blocks for each scanned table.
+func (s *ReadService) GetBlockGapsStatuses(ctx context.Context) ([]entities.BlockGapsStatus, error) {
+	return s.storeReadService.NewBlockGaps().GetStatuses(ctx)
+}
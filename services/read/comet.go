@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/config"
 )
 
 func (s *ReadService) GetNetworkLatestBlockHeight(ctx context.Context) (int64, error) {
@@ -18,14 +19,59 @@ func (s *ReadService) GetBlockSigners(ctx context.Context, fromBlock int64, toBl
 	return s.cometClient.GetBlockSignersRange(ctx, fromBlock, toBlock)
 }
 
-func (s *ReadService) GetCometTxs(ctx context.Context, fromBlock int64, toBlock int64) ([]comet.CometTx, error) {
-	return s.cometClient.GetTxsForBlockRange(ctx, fromBlock, toBlock)
+// GetCometTxs returns the txs between the blocks kept by the filter.
+func (s *ReadService) GetCometTxs(ctx context.Context, fromBlock int64, toBlock int64, filter *comet.TxFilter) ([]comet.CometTx, error) {
+	txs, err := s.cometClient.GetTxsForBlockRangeNotFiltered(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	return comet.RemoveExcludedTxTypes(txs, filter), nil
+}
+
+// GetAllCometTxs returns all txs between the blocks, regardless of the filter.
+func (s *ReadService) GetAllCometTxs(ctx context.Context, fromBlock int64, toBlock int64) ([]comet.CometTx, error) {
+	return s.cometClient.GetTxsForBlockRangeNotFiltered(ctx, fromBlock, toBlock)
+}
+
+// GetDecodedTxs returns the txs of the block at the given indexes decoded with the Vega protobuf command definitions.
+func (s *ReadService) GetDecodedTxs(ctx context.Context, block int64, heightIdxs []int) ([]comet.DecodedTx, error) {
+	return s.cometClient.GetDecodedTxs(ctx, block, heightIdxs)
+}
+
+// GetBlockMetas returns the header and the size of the blocks, ordered by height.
+func (s *ReadService) GetBlockMetas(ctx context.Context, fromBlock int64, toBlock int64) ([]comet.BlockMeta, error) {
+	return s.cometClient.GetBlockMetasRange(ctx, fromBlock, toBlock)
+}
+
+func (s *ReadService) GetCometTxFilter() *comet.TxFilter {
+	return s.cometClient.TxFilter()
+}
+
+func (s *ReadService) SetCometTxFilter(cfg config.CometTxFilterConfig) {
+	s.cometClient.SetTxFilter(cfg)
+}
+
+// SubscribeNewBlocks calls onBlock for every new block streamed from CometBFT, until the stream fails.
+func (s *ReadService) SubscribeNewBlocks(ctx context.Context, onBlock comet.NewBlockFunc) error {
+	return s.cometClient.SubscribeNewBlocks(ctx, onBlock)
+}
+
+// CheckCometQuorum checks the CometBFT endpoints agree on the block hash, when the quorum check is enabled.
+func (s *ReadService) CheckCometQuorum(ctx context.Context, block int64) error {
+	if !s.cometClient.QuorumEnabled() {
+		return nil
+	}
+	return s.cometClient.CheckQuorum(ctx, block)
 }
 
 func (s *ReadService) GetValidatorForAddressAtBlock(ctx context.Context, address string, block int64) (*comet.ValidatorData, error) {
 	return s.cometClient.GetValidatorForAddressAtBlock(ctx, address, block)
 }
 
+func (s *ReadService) GetValidatorsForBlock(ctx context.Context, block int64) ([]comet.CometValidators, error) {
+	return s.cometClient.GetValidatorsForBlock(ctx, block)
+}
+
 func (s *ReadService) GetEarliestBlockHeight(ctx context.Context) (int64, error) {
 	return s.cometClient.EarliestBlockHeight(ctx)
 }
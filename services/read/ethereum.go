@@ -1,11 +1,169 @@
 package read
 
-import "math/big"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
-func (s *ReadService) GetAssetPoolBalanceForTokenFromEthereum(tokenAddress, assetPoolAddress string) (*big.Int, error) {
-	return s.ethClient.GetAssetPoolBalanceForToken(tokenAddress, assetPoolAddress)
+	"code.vegaprotocol.io/vega/logging"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/ethutils"
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+var ErrUnknownBridgeChain = errors.New("no bridge chain configured for the chain id")
+
+// BridgeChain is an EVM chain with a Vega bridge. The chain ID is read from the RPC endpoint on the first use,
+// when it is not configured.
+type BridgeChain struct {
+	config config.BridgeChainConfig
+	client *ethutils.EthClient
+
+	chainIDMu sync.Mutex
+	chainID   string
+}
+
+func NewBridgeChain(cfg config.BridgeChainConfig, log *logging.Logger) (*BridgeChain, error) {
+	client, err := ethutils.NewEthClient(cfg.RPCEndpoint, log.Named(fmt.Sprintf("%s-client", cfg.Name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for bridge chain %s: %w", cfg.Name, err)
+	}
+
+	return &BridgeChain{
+		config:  cfg,
+		client:  client,
+		chainID: cfg.ChainID,
+	}, nil
+}
+
+// NewBridgeChains creates the clients of all bridge chains.
+func NewBridgeChains(configs []config.BridgeChainConfig, log *logging.Logger) ([]*BridgeChain, error) {
+	result := make([]*BridgeChain, 0, len(configs))
+	for _, cfg := range configs {
+		chain, err := NewBridgeChain(cfg, log)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, chain)
+	}
+
+	return result, nil
+}
+
+func (c *BridgeChain) Name() string {
+	return c.config.Name
+}
+
+// ChainID returns the configured chain ID, or reads it from the RPC endpoint.
+func (c *BridgeChain) ChainID(ctx context.Context) (string, error) {
+	c.chainIDMu.Lock()
+	defer c.chainIDMu.Unlock()
+
+	if len(c.chainID) < 1 {
+		chainID, err := c.client.ChainID(ctx)
+		if err != nil {
+			return "", fmt.Errorf("could not retrieve %s chain ID: %w", c.config.Name, err)
+		}
+		c.chainID = chainID
+	}
+
+	return c.chainID, nil
 }
 
-func (s *ReadService) GetAssetPoolBalanceForTokenFromArbitrum(tokenAddress, assetPoolAddress string) (*big.Int, error) {
-	return s.arbitrumClient.GetAssetPoolBalanceForToken(tokenAddress, assetPoolAddress)
+// GetAssetPoolBalancesForTokens returns the balances of the tokens in the asset pool of the bridge on the chain
+// with the chain ID, all read at the returned block number. It returns ErrUnknownBridgeChain, when none of the
+// bridge chains has the chain ID.
+func (s *ReadService) GetAssetPoolBalancesForTokens(
+	ctx context.Context,
+	chainID string,
+	tokenAddresses []string,
+) ([]ethutils.TokenBalance, uint64, error) {
+	chain, err := s.bridgeChainFor(ctx, chainID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return chain.client.GetAssetPoolBalancesForTokens(ctx, tokenAddresses, chain.config.AssetPoolAddress, chain.multicallAddress())
+}
+
+// GetAssetPoolBalancesForTokensAtBlock returns the balances of the tokens in the asset pool of the bridge on the
+// chain with the chain ID, read at the given block.
+func (s *ReadService) GetAssetPoolBalancesForTokensAtBlock(
+	ctx context.Context,
+	chainID string,
+	tokenAddresses []string,
+	blockNumber uint64,
+) ([]ethutils.TokenBalance, error) {
+	chain, err := s.bridgeChainFor(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	return chain.client.GetAssetPoolBalancesForTokensAtBlock(
+		ctx, tokenAddresses, chain.config.AssetPoolAddress, chain.multicallAddress(), blockNumber,
+	)
+}
+
+// GetBridgeChainBlockAtTime returns the number of the last block of the chain with the chain ID, which is not
+// after the time. fromBlock is a lower bound of the block, or 0 when unknown.
+func (s *ReadService) GetBridgeChainBlockAtTime(
+	ctx context.Context,
+	chainID string,
+	at time.Time,
+	fromBlock uint64,
+) (uint64, error) {
+	chain, err := s.bridgeChainFor(ctx, chainID)
+	if err != nil {
+		return 0, err
+	}
+
+	return chain.client.BlockNumberAtTime(ctx, at, fromBlock)
+}
+
+// HasBridgeChain returns true, when a bridge chain with the chain ID is configured.
+func (s *ReadService) HasBridgeChain(ctx context.Context, chainID string) (bool, error) {
+	_, err := s.bridgeChainFor(ctx, chainID)
+	if errors.Is(err, ErrUnknownBridgeChain) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *BridgeChain) multicallAddress() string {
+	if len(c.config.MulticallAddress) < 1 {
+		return ethutils.DefaultMulticall3Address
+	}
+
+	return c.config.MulticallAddress
+}
+
+// bridgeChainFor returns the bridge chain with the chain ID. It returns ErrUnknownBridgeChain only when the IDs of
+// all the chains are known and none matches, otherwise the errors of the chains, which IDs could not be read, as
+// the chain may be one of them.
+func (s *ReadService) bridgeChainFor(ctx context.Context, chainID string) (*BridgeChain, error) {
+	var errs []error
+	for _, chain := range s.bridgeChains {
+		id, err := chain.ChainID(ctx)
+		if err != nil {
+			// The asset may be on another chain, so the failing chain must not block it
+			s.log.Warn("Failed to get chain ID of the bridge chain", zap.String("chain", chain.Name()), zap.Error(err))
+			errs = append(errs, err)
+			continue
+		}
+		if id == chainID {
+			return chain, nil
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("failed to find bridge chain for chain id %s: %w", chainID, errors.Join(errs...))
+	}
+
+	return nil, fmt.Errorf("%w %s", ErrUnknownBridgeChain, chainID)
 }
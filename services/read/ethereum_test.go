@@ -0,0 +1,56 @@
+package read
+
+import (
+	"context"
+	"testing"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vegaprotocol/vega-monitoring/config"
+)
+
+func TestBridgeChainFor(t *testing.T) {
+	log := logging.NewTestLogger()
+	// Nothing listens on the port, so the chain ID of the chain cannot be read
+	unreachable := config.BridgeChainConfig{Name: "unreachable", RPCEndpoint: "http://127.0.0.1:1", AssetPoolAddress: "0x1"}
+	ethereum := config.BridgeChainConfig{Name: "ethereum", ChainID: "1", RPCEndpoint: "http://127.0.0.1:1", AssetPoolAddress: "0x1"}
+	arbitrum := config.BridgeChainConfig{Name: "arbitrum", ChainID: "42161", RPCEndpoint: "http://127.0.0.1:1", AssetPoolAddress: "0x2"}
+
+	testScenarios := []struct {
+		name    string
+		chains  []config.BridgeChainConfig
+		chainID string
+		chain   string
+		unknown bool
+	}{
+		{name: "first chain", chains: []config.BridgeChainConfig{ethereum, arbitrum}, chainID: "1", chain: "ethereum"},
+		{name: "second chain", chains: []config.BridgeChainConfig{ethereum, arbitrum}, chainID: "42161", chain: "arbitrum"},
+		{name: "chain after a failing chain", chains: []config.BridgeChainConfig{unreachable, arbitrum}, chainID: "42161", chain: "arbitrum"},
+		{name: "unknown chain", chains: []config.BridgeChainConfig{ethereum, arbitrum}, chainID: "100", unknown: true},
+		{name: "no chains", chainID: "1", unknown: true},
+		{name: "unknown chain with a failing chain", chains: []config.BridgeChainConfig{ethereum, unreachable}, chainID: "100"},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			chains, err := NewBridgeChains(tc.chains, log)
+			require.NoError(t, err)
+			service := &ReadService{bridgeChains: chains, log: log}
+
+			chain, err := service.bridgeChainFor(context.Background(), tc.chainID)
+			switch {
+			case tc.chain != "":
+				require.NoError(t, err)
+				assert.Equal(t, tc.chain, chain.Name())
+			case tc.unknown:
+				assert.ErrorIs(t, err, ErrUnknownBridgeChain)
+			default:
+				// The chain may be the one, which ID could not be read
+				require.Error(t, err)
+				assert.NotErrorIs(t, err, ErrUnknownBridgeChain)
+			}
+		})
+	}
+}
@@ -24,6 +24,9 @@ type MetaMonitoringStatuses struct {
 	PrometheusNodeScanner    *int32
 	PrometheusMetamonitoring *int32
 
+	// Scrapers are statuses of the services registered outside of the built-in ones
+	Scrapers map[entities.MonitoringServiceType]*int32
+
 	UpdateTime time.Time
 }
 
@@ -47,11 +50,15 @@ type MetaMonitoringStatusesExtended struct {
 	PrometheusEthNodeScannerData StatusDetails
 	PrometheusNodeScannerData    StatusDetails
 	PrometheusMetamonitoringData StatusDetails
+
+	// Scrapers are statuses of the services registered outside of the built-in ones
+	Scrapers map[entities.MonitoringServiceType]StatusDetails
 }
 
 func EmptyMetaMonitoringStatusesExtended() *MetaMonitoringStatusesExtended {
 	return &MetaMonitoringStatusesExtended{
 		HealthyOverAll: false,
+		Scrapers:       map[entities.MonitoringServiceType]StatusDetails{},
 		DataNodeData: StatusDetails{
 			Healthy:         true, // We do not use this check anymore
 			UpdatedAt:       time.Unix(0, 0),
@@ -111,6 +118,7 @@ func (s *ReadService) GetMetaMonitoringStatuses(ctx context.Context) (MetaMonito
 		PrometheusEthNodeScanner: &permanentOne,
 		PrometheusNodeScanner:    &permanentOne,
 		PrometheusMetamonitoring: &permanentOne,
+		Scrapers:                 map[entities.MonitoringServiceType]*int32{},
 	}
 
 	logger := s.log.With(zap.String("reader", "MetaMonitoringStatuses"))
@@ -147,11 +155,11 @@ func (s *ReadService) GetMetaMonitoringStatuses(ctx context.Context) (MetaMonito
 		case entities.PromEthereumCallsSvc:
 			result.PrometheusEthereumCallsData = &isHealthyMetricsValue
 		default:
-			logger.Error("Unknown check name", zap.String("check_name", string(check.Service)))
+			result.Scrapers[check.Service] = &isHealthyMetricsValue
 		}
 	}
 
-	if len(checks) != expectedChecks {
+	if len(checks)-len(result.Scrapers) != expectedChecks {
 		logger.Error("Wrong number of checks", zap.Int("expected", expectedChecks), zap.Int("actual", len(checks)), zap.Any("checks", checks))
 	}
 
@@ -214,9 +222,14 @@ func (s *ReadService) GetMetaMonitoringStatusesExtended(ctx context.Context) (*M
 				UnhealthyReason: check.UnhealthyReason,
 			}
 
-		// Unused
+		case entities.DataNodeSvc, entities.PromEthNodeScannerSvc, entities.PromNodeScannerSvc, entities.PromMetamonitoringSvc:
+			// Unused
 		default:
-			logger.Error("Unknown check name", zap.String("check_name", string(check.Service)))
+			result.Scrapers[check.Service] = StatusDetails{
+				Healthy:         check.IsHealthy,
+				UpdatedAt:       check.StatusTime,
+				UnhealthyReason: check.UnhealthyReason,
+			}
 		}
 	}
 
@@ -227,7 +240,7 @@ func (s *ReadService) GetMetaMonitoringStatusesExtended(ctx context.Context) (*M
 		result.NetworkHistorySegmentsData.Healthy &&
 		result.PrometheusEthereumCallsData.Healthy
 
-	if len(checks) != 5 {
+	if len(checks)-len(result.Scrapers) != 5 {
 		logger.Error("Wrong number of checks", zap.Int("expected", 6), zap.Int("actual", len(checks)), zap.Any("checks", checks))
 	}
 
@@ -5,7 +5,6 @@ import (
 
 	"github.com/vegaprotocol/vega-monitoring/clients/coingecko"
 	"github.com/vegaprotocol/vega-monitoring/clients/comet"
-	"github.com/vegaprotocol/vega-monitoring/clients/ethutils"
 	"github.com/vegaprotocol/vega-monitoring/sqlstore"
 )
 
@@ -14,21 +13,20 @@ type ReadService struct {
 	cometClient      *comet.CometClient
 	storeReadService StoreReadService
 	log              *logging.Logger
-	ethClient        *ethutils.EthClient
-	arbitrumClient   *ethutils.EthClient
+	bridgeChains     []*BridgeChain
 }
 
 type StoreReadService interface {
 	NewNetworkHistorySegment() *sqlstore.NetworkHistorySegment
 	NewMonitoringStatus() *sqlstore.MonitoringStatus
+	NewBlockGaps() *sqlstore.BlockGaps
 }
 
-func NewReadService(coingeckoClient *coingecko.CoingeckoClient, cometClient *comet.CometClient, ethClient *ethutils.EthClient, arbitrumClient *ethutils.EthClient, storeReadService StoreReadService, log *logging.Logger) (*ReadService, error) {
+func NewReadService(coingeckoClient *coingecko.CoingeckoClient, cometClient *comet.CometClient, bridgeChains []*BridgeChain, storeReadService StoreReadService, log *logging.Logger) (*ReadService, error) {
 	return &ReadService{
 		coingeckoClient:  coingeckoClient,
 		cometClient:      cometClient,
-		ethClient:        ethClient,
-		arbitrumClient:   arbitrumClient,
+		bridgeChains:     bridgeChains,
 		storeReadService: storeReadService,
 		log:              log,
 	}, nil
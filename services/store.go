@@ -48,10 +48,38 @@ func (s *StoreService) NewCometTxs() *sqlstore.CometTxs {
 	return sqlstore.NewCometTxs(s.connSource)
 }
 
+func (s *StoreService) NewCometCommands() *sqlstore.CometCommands {
+	return sqlstore.NewCometCommands(s.connSource)
+}
+
+func (s *StoreService) NewTxFailures() *sqlstore.TxFailures {
+	return sqlstore.NewTxFailures(s.connSource)
+}
+
+func (s *StoreService) NewSegmentDivergences() *sqlstore.SegmentDivergences {
+	return sqlstore.NewSegmentDivergences(s.connSource)
+}
+
+func (s *StoreService) NewBlocksStats() *sqlstore.BlocksStats {
+	return sqlstore.NewBlocksStats(s.connSource)
+}
+
+func (s *StoreService) NewValidatorHeartbeats() *sqlstore.ValidatorHeartbeats {
+	return sqlstore.NewValidatorHeartbeats(s.connSource)
+}
+
 func (s *StoreService) NewNetworkBalances() *sqlstore.NetworkBalances {
 	return sqlstore.NewNetworkBalances(s.connSource)
 }
 
+func (s *StoreService) NewBalanceExclusions() *sqlstore.BalanceExclusions {
+	return sqlstore.NewBalanceExclusions(s.connSource)
+}
+
+func (s *StoreService) NewBridgeReconciliation() *sqlstore.BridgeReconciliation {
+	return sqlstore.NewBridgeReconciliation(s.connSource)
+}
+
 func (s *StoreService) NewAssetPrices() *sqlstore.AssetPrices {
 	return sqlstore.NewAssetPrices(s.connSource)
 }
@@ -60,6 +88,30 @@ func (s *StoreService) NewMonitoringStatus() *sqlstore.MonitoringStatus {
 	return sqlstore.NewMonitoringStatus(s.connSource)
 }
 
+func (s *StoreService) NewBlockVotes() *sqlstore.BlockVotes {
+	return sqlstore.NewBlockVotes(s.connSource)
+}
+
+func (s *StoreService) NewEpochValidatorSet() *sqlstore.EpochValidatorSet {
+	return sqlstore.NewEpochValidatorSet(s.connSource)
+}
+
+func (s *StoreService) NewValidatorSet() *sqlstore.ValidatorSet {
+	return sqlstore.NewValidatorSet(s.connSource)
+}
+
+func (s *StoreService) NewProposerFairness() *sqlstore.ProposerFairness {
+	return sqlstore.NewProposerFairness(s.connSource)
+}
+
+func (s *StoreService) NewBlockGaps() *sqlstore.BlockGaps {
+	return sqlstore.NewBlockGaps(s.connSource)
+}
+
+func (s *StoreService) NewValidatorNodes() *sqlstore.ValidatorNodes {
+	return sqlstore.NewValidatorNodes(s.connSource)
+}
+
 // Data Node tables
 func (s *StoreService) NewAssets() *vega_sqlstore.Assets {
 	return vega_sqlstore.NewAssets(s.connSource)
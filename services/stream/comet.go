@@ -0,0 +1,168 @@
+// Package stream runs the ingestion jobs on the events streamed from CometBFT.
+package stream
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/metamonitoring"
+)
+
+// statusInterval is how often the status of the stream is published
+const statusInterval = 30 * time.Second
+
+type blockSubscriber interface {
+	SubscribeNewBlocks(ctx context.Context, onBlock comet.NewBlockFunc) error
+}
+
+type jobTrigger interface {
+	// Wake runs the job, unless it is paused
+	Wake(name string) error
+}
+
+// Status is the state of the stream.
+type Status struct {
+	Connected   bool
+	LastHeight  int64
+	LastBlockAt time.Time
+}
+
+// CometStream triggers the jobs on every new block streamed from CometBFT. The jobs keep running on their
+// schedule, so they poll the blocks missed while the stream is disconnected.
+type CometStream struct {
+	subscriber        blockSubscriber
+	jobs              jobTrigger
+	jobNames          []string
+	publisher         metamonitoring.MonitoringStatusPublisher
+	reconnectInterval time.Duration
+	log               *logging.Logger
+
+	mut    sync.Mutex
+	status Status
+
+	// failingJobs are the jobs, which failed to trigger on the last block. It is used only by onBlock, so the
+	// failure is logged once, and not on every block
+	failingJobs map[string]struct{}
+}
+
+func NewCometStream(
+	subscriber blockSubscriber,
+	jobs jobTrigger,
+	jobNames []string,
+	publisher metamonitoring.MonitoringStatusPublisher,
+	reconnectInterval time.Duration,
+	log *logging.Logger,
+) *CometStream {
+	return &CometStream{
+		subscriber:        subscriber,
+		jobs:              jobs,
+		jobNames:          jobNames,
+		publisher:         publisher,
+		reconnectInterval: reconnectInterval,
+		log:               log.With(zap.String("service", "CometStream")),
+		failingJobs:       map[string]struct{}{},
+	}
+}
+
+// Run subscribes to the new blocks, and reconnects after failures, until the context is cancelled.
+func (s *CometStream) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.publishStatus(ctx)
+	}()
+	defer wg.Wait()
+
+	for {
+		err := s.subscriber.SubscribeNewBlocks(ctx, s.onBlock)
+		s.setConnected(false)
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warn("CometBFT stream disconnected, falling back to polling until reconnected", zap.Error(err))
+		s.publish(false)
+
+		select {
+		case <-time.After(s.reconnectInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Status returns the current state of the stream.
+func (s *CometStream) Status() Status {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	return s.status
+}
+
+func (s *CometStream) onBlock(height int64) {
+	s.mut.Lock()
+	reconnected := !s.status.Connected
+	s.status.Connected = true
+	s.status.LastHeight = height
+	s.status.LastBlockAt = time.Now()
+	s.mut.Unlock()
+
+	if reconnected {
+		s.log.Info("CometBFT stream connected", zap.Int64("height", height))
+		s.publish(true)
+	}
+
+	// Jobs process all the blocks since their last run, so a trigger during a run is not lost, and the blocks
+	// missed during a disconnect are caught up by the first triggered run. The paused jobs are not run.
+	for _, name := range s.jobNames {
+		err := s.jobs.Wake(name)
+		_, wasFailing := s.failingJobs[name]
+		switch {
+		case err != nil && !wasFailing:
+			s.log.Warn("Failed to trigger job on new block", zap.String("job", name), zap.Error(err))
+			s.failingJobs[name] = struct{}{}
+		case err == nil && wasFailing:
+			s.log.Info("Triggered job on new block again", zap.String("job", name))
+			delete(s.failingJobs, name)
+		}
+	}
+}
+
+func (s *CometStream) setConnected(connected bool) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.status.Connected = connected
+}
+
+func (s *CometStream) publishStatus(ctx context.Context) {
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.publish(s.Status().Connected)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *CometStream) publish(healthy bool) {
+	var err error
+	if healthy {
+		err = s.publisher.Publish(true)
+	} else {
+		err = s.publisher.PublishWithReason(false, entities.ReasonTargetConnectionFailure)
+	}
+	if err != nil {
+		s.log.Error("Failed to publish CometBFT stream status", zap.Error(err))
+	}
+}
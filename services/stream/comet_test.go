@@ -0,0 +1,107 @@
+package stream
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/config"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/metamonitoring"
+	"github.com/vegaprotocol/vega-monitoring/scheduler"
+)
+
+// blocksSubscriber streams the heights sent to the blocks channel.
+type blocksSubscriber struct {
+	blocks chan int64
+}
+
+func (s *blocksSubscriber) SubscribeNewBlocks(ctx context.Context, onBlock comet.NewBlockFunc) error {
+	for {
+		select {
+		case height := <-s.blocks:
+			onBlock(height)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func TestCometStreamRunsJobsOnNewBlocks(t *testing.T) {
+	testScenarios := []struct {
+		name      string
+		paused    bool
+		triggered bool
+		runs      int
+	}{
+		{
+			name: "job runs on every block",
+			runs: 2,
+		},
+		{
+			name:   "paused job does not run on new blocks",
+			paused: true,
+			runs:   0,
+		},
+		{
+			name:      "paused job runs only when triggered manually",
+			paused:    true,
+			triggered: true,
+			runs:      1,
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			runs := make(chan struct{}, 10)
+			jobs := scheduler.NewScheduler(logging.NewTestLogger())
+			jobs.Add(config.CometTxsJob, config.JobConfig{Interval: time.Hour, InitialDelay: time.Hour}, func(ctx context.Context) error {
+				runs <- struct{}{}
+				return nil
+			})
+			if tc.paused {
+				require.NoError(t, jobs.Pause(config.CometTxsJob))
+			}
+			go jobs.Run(ctx)
+
+			subscriber := &blocksSubscriber{blocks: make(chan int64)}
+			publisher := metamonitoring.NewNopService().StatusPublisher(entities.CometStreamSvc)
+			cometStream := NewCometStream(subscriber, jobs, []string{config.CometTxsJob}, publisher, time.Second, logging.NewTestLogger())
+			go cometStream.Run(ctx)
+
+			for height := int64(1); height <= 2; height++ {
+				subscriber.blocks <- height
+				if !tc.paused {
+					select {
+					case <-runs:
+					case <-time.After(time.Second):
+						t.Fatalf("Job did not run on block %d", height)
+					}
+				}
+			}
+			if tc.triggered {
+				require.NoError(t, jobs.Trigger(config.CometTxsJob))
+				select {
+				case <-runs:
+				case <-time.After(time.Second):
+					t.Fatal("Triggered job did not run")
+				}
+			}
+
+			// Give the scheduler the time to run the paused job, if it wrongly did
+			time.Sleep(50 * time.Millisecond)
+			statuses := jobs.Statuses()
+			require.Len(t, statuses, 1)
+			assert.Equal(t, int64(tc.runs), statuses[0].Runs)
+			assert.Equal(t, int64(2), cometStream.Status().LastHeight)
+		})
+	}
+}
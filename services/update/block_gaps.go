@@ -0,0 +1,175 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.vegaprotocol.io/vega/logging"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type findGapsFunc func(ctx context.Context, fromHeight int64, toHeight int64) ([]entities.BlockRange, error)
+
+type updateRangeFunc func(ctx context.Context, fromBlock int64, toBlock int64) error
+
+// BackfillBlockSignersGaps finds blocks missing in the metrics.block_signers table between the first and the last
+// stored block, and fills in up to maxBlocks of the latest missing blocks.
+func (us *UpdateService) BackfillBlockSignersGaps(ctx context.Context, maxBlocks int64) error {
+	blockSigner := us.storeService.NewBlockSigner()
+
+	firstBlock, err := blockSigner.GetEarliestBlockInStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the earliest block signers block: %w", err)
+	}
+	lastBlock, err := blockSigner.GetLastestBlockInStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the latest block signers block: %w", err)
+	}
+
+	availableBlock, err := us.earliestAvailableBlock(ctx)
+	if err != nil {
+		return err
+	}
+
+	return us.backfillGaps(
+		ctx,
+		entities.BlockGapsBlockSigners,
+		entities.BlockRange{FromHeight: max(firstBlock, availableBlock), ToHeight: lastBlock},
+		maxBlocks,
+		us.storeService.NewBlockGaps().GetBlockSignersGaps,
+		us.UpdateBlockSigners,
+	)
+}
+
+// BackfillCometTxsGaps finds blocks never processed by the comet txs updater between the first and the last
+// processed block, and fills in up to maxBlocks of the latest missing blocks.
+func (us *UpdateService) BackfillCometTxsGaps(ctx context.Context, maxBlocks int64) error {
+	cometTxs := us.storeService.NewCometTxs()
+
+	firstBlock, err := cometTxs.GetEarliestProcessedBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the earliest processed comet txs block: %w", err)
+	}
+	lastBlock, err := cometTxs.GetLatestBlockInStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the latest processed comet txs block: %w", err)
+	}
+
+	availableBlock, err := us.earliestAvailableBlock(ctx)
+	if err != nil {
+		return err
+	}
+	// Ranges of blocks, which are not available anymore, are not needed
+	if err := cometTxs.DeleteProcessedBlocksBefore(ctx, availableBlock); err != nil {
+		return fmt.Errorf("failed to delete old processed comet txs blocks: %w", err)
+	}
+
+	return us.backfillGaps(
+		ctx,
+		entities.BlockGapsCometTxs,
+		entities.BlockRange{FromHeight: max(firstBlock, availableBlock), ToHeight: lastBlock},
+		maxBlocks,
+		us.storeService.NewBlockGaps().GetCometTxsGaps,
+		us.UpdateCometTxs,
+	)
+}
+
+// earliestAvailableBlock returns the first block available in both the data-node and CometBFT.
+// Older blocks cannot be filled in.
+func (us *UpdateService) earliestAvailableBlock(ctx context.Context) (int64, error) {
+	earliestDataNodeBlock, err := us.storeService.NewBlocks().GetEarliestBlockHeight(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the earliest data-node block: %w", err)
+	}
+	earliestCometBlock, err := us.readService.GetEarliestBlockHeight(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the earliest comet block: %w", err)
+	}
+
+	return max(*earliestDataNodeBlock, earliestCometBlock), nil
+}
+
+// backfillGaps scans the window for gaps, stores the result, and updates the latest missing blocks with the
+// range update function.
+func (us *UpdateService) backfillGaps(
+	ctx context.Context,
+	table string,
+	window entities.BlockRange,
+	maxBlocks int64,
+	findGaps findGapsFunc,
+	updateRange updateRangeFunc,
+) error {
+	logger := us.log.With(zap.String(UpdaterType, "BackfillBlockGaps"), zap.String("table", table))
+
+	if window.ToHeight <= 0 || window.Len() < 1 {
+		logger.Debug("No blocks in store, nothing to scan")
+		return nil
+	}
+
+	gaps, err := findGaps(ctx, window.FromHeight, window.ToHeight)
+	if err != nil {
+		return err
+	}
+
+	status := entities.BlockGapsStatus{
+		TableName:  table,
+		FromHeight: window.FromHeight,
+		ToHeight:   window.ToHeight,
+		Gaps:       int32(len(gaps)),
+		UpdatedAt:  time.Now(),
+	}
+	for _, gap := range gaps {
+		status.MissingHeights += gap.Len()
+	}
+
+	logger.Debug(
+		"Scanned for missing blocks",
+		zap.Int64("from-block", window.FromHeight),
+		zap.Int64("to-block", window.ToHeight),
+		zap.Int32("gaps", status.Gaps),
+		zap.Int64("missing-blocks", status.MissingHeights),
+	)
+
+	backfillErr := fillLatestGaps(ctx, table, gaps, maxBlocks, updateRange, &status, logger)
+
+	if err := us.storeService.NewBlockGaps().UpsertStatus(ctx, status); err != nil {
+		return err
+	}
+
+	return backfillErr
+}
+
+// fillLatestGaps updates up to maxBlocks of the latest missing blocks, the latest gaps first, and removes the filled
+// in blocks from the status. A gap filled in only partially is still counted in the status.
+func fillLatestGaps(
+	ctx context.Context,
+	table string,
+	gaps []entities.BlockRange,
+	maxBlocks int64,
+	updateRange updateRangeFunc,
+	status *entities.BlockGapsStatus,
+	logger *logging.Logger,
+) error {
+	remaining := maxBlocks
+	for idx := len(gaps) - 1; idx >= 0 && remaining > 0; idx-- {
+		gap := gaps[idx]
+		if gap.Len() > remaining {
+			gap.FromHeight = gap.ToHeight - remaining + 1
+		}
+
+		logger.Info("Filling in missing blocks", zap.Int64("from-block", gap.FromHeight), zap.Int64("to-block", gap.ToHeight))
+		if err := updateRange(ctx, gap.FromHeight, gap.ToHeight); err != nil {
+			return fmt.Errorf("failed to fill in missing %s blocks from %d to %d: %w", table, gap.FromHeight, gap.ToHeight, err)
+		}
+		if gap.Len() == gaps[idx].Len() {
+			status.Gaps--
+		}
+		remaining -= gap.Len()
+		status.MissingHeights -= gap.Len()
+	}
+
+	return nil
+}
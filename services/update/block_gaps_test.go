@@ -0,0 +1,118 @@
+package update
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"code.vegaprotocol.io/vega/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+func TestFillLatestGaps(t *testing.T) {
+	gaps := []entities.BlockRange{
+		{FromHeight: 10, ToHeight: 20},
+		{FromHeight: 50, ToHeight: 60},
+	}
+
+	testScenarios := []struct {
+		name           string
+		gaps           []entities.BlockRange
+		maxBlocks      int64
+		failAt         int64
+		expectedErr    bool
+		updated        []entities.BlockRange
+		gapsLeft       int32
+		missingHeights int64
+	}{
+		{
+			name:           "no gaps",
+			gaps:           []entities.BlockRange{},
+			maxBlocks:      100,
+			updated:        nil,
+			gapsLeft:       0,
+			missingHeights: 0,
+		},
+		{
+			name:           "all gaps filled in, the latest first",
+			gaps:           gaps,
+			maxBlocks:      100,
+			updated:        []entities.BlockRange{{FromHeight: 50, ToHeight: 60}, {FromHeight: 10, ToHeight: 20}},
+			gapsLeft:       0,
+			missingHeights: 0,
+		},
+		{
+			name:           "max blocks fill in exactly the latest gap",
+			gaps:           gaps,
+			maxBlocks:      11,
+			updated:        []entities.BlockRange{{FromHeight: 50, ToHeight: 60}},
+			gapsLeft:       1,
+			missingHeights: 11,
+		},
+		{
+			name:           "older gap filled in partially up to max blocks",
+			gaps:           gaps,
+			maxBlocks:      15,
+			updated:        []entities.BlockRange{{FromHeight: 50, ToHeight: 60}, {FromHeight: 17, ToHeight: 20}},
+			gapsLeft:       1,
+			missingHeights: 7,
+		},
+		{
+			name:           "latest gap filled in partially up to max blocks",
+			gaps:           gaps,
+			maxBlocks:      5,
+			updated:        []entities.BlockRange{{FromHeight: 56, ToHeight: 60}},
+			gapsLeft:       2,
+			missingHeights: 17,
+		},
+		{
+			name:           "update of the older gap fails",
+			gaps:           gaps,
+			maxBlocks:      100,
+			failAt:         10,
+			expectedErr:    true,
+			updated:        []entities.BlockRange{{FromHeight: 50, ToHeight: 60}, {FromHeight: 10, ToHeight: 20}},
+			gapsLeft:       1,
+			missingHeights: 11,
+		},
+		{
+			name:           "update of the latest gap fails",
+			gaps:           gaps,
+			maxBlocks:      100,
+			failAt:         50,
+			expectedErr:    true,
+			updated:        []entities.BlockRange{{FromHeight: 50, ToHeight: 60}},
+			gapsLeft:       2,
+			missingHeights: 22,
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			status := entities.BlockGapsStatus{Gaps: int32(len(tc.gaps))}
+			for _, gap := range tc.gaps {
+				status.MissingHeights += gap.Len()
+			}
+
+			var updated []entities.BlockRange
+			updateRange := func(ctx context.Context, fromBlock int64, toBlock int64) error {
+				updated = append(updated, entities.BlockRange{FromHeight: fromBlock, ToHeight: toBlock})
+				if fromBlock == tc.failAt {
+					return errors.New("failed")
+				}
+				return nil
+			}
+
+			err := fillLatestGaps(context.Background(), entities.BlockGapsBlockSigners, tc.gaps, tc.maxBlocks, updateRange, &status, logging.NewTestLogger())
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.updated, updated)
+			assert.Equal(t, tc.gapsLeft, status.Gaps)
+			assert.Equal(t, tc.missingHeights, status.MissingHeights)
+		})
+	}
+}
@@ -20,10 +20,19 @@ func (us *UpdateService) UpdateBlockSignersAllNew(ctx context.Context) error {
 }
 
 func (us *UpdateService) UpdateBlockSigners(ctx context.Context, fromBlock int64, toBlock int64) error {
+	return us.UpdateBlockSignersWithProgress(ctx, fromBlock, toBlock, nil)
+}
+
+// UpdateBlockSignersWithProgress works as UpdateBlockSigners, and reports the number of processed blocks after each batch.
+func (us *UpdateService) UpdateBlockSignersWithProgress(ctx context.Context, fromBlock int64, toBlock int64, progress ProgressFunc) error {
 	blockSigner := us.storeService.NewBlockSigner()
+	blockVotes := us.storeService.NewBlockVotes()
 
 	logger := us.log.With(zap.String(UpdaterType, "UpdateBlockSigners"))
 
+	// Missed blocks are tracked only for the latest blocks, not when a range is updated explicitly
+	trackSigning := toBlock <= 0 && us.signingReporter != nil
+
 	// get Last Block
 	if toBlock <= 0 {
 		logger.Debug("getting network toBlock network height")
@@ -75,6 +84,10 @@ func (us *UpdateService) UpdateBlockSigners(ctx context.Context, fromBlock int64
 	if fromBlock > toBlock {
 		return fmt.Errorf("cannot update Block Signers, from block '%d' is greater than to block '%d'", fromBlock, toBlock)
 	}
+	var onBlock BlockSignedFunc
+	if trackSigning {
+		onBlock = us.trackSigning(toBlock)
+	}
 	// Update in batches
 	logger.Debug(
 		"Update Block Signers in batches",
@@ -90,11 +103,18 @@ func (us *UpdateService) UpdateBlockSigners(ctx context.Context, fromBlock int64
 		if batchLastBlock > toBlock {
 			batchLastBlock = toBlock
 		}
-		count, err := UpdateBlockRange(ctx, batchFirstBlock, batchLastBlock, us.readService, blockSigner, logger)
+		count, err := UpdateBlockRange(ctx, batchFirstBlock, batchLastBlock, us.readService, blockSigner, blockVotes, onBlock, logger)
 		if err != nil {
 			return fmt.Errorf("failed to update block range: %w", err)
 		}
 		totalCount += count
+		if progress != nil {
+			progress(batchLastBlock-fromBlock+1, toBlock-fromBlock+1)
+		}
+	}
+
+	if trackSigning {
+		us.reportSigning(ctx)
 	}
 
 	logger.Debug(
@@ -112,6 +132,8 @@ func UpdateBlockRange(
 	toBlock int64,
 	readService *read.ReadService,
 	blockSignerStore *sqlstore.BlockSigner,
+	blockVoteStore *sqlstore.BlockVotes,
+	onBlock BlockSignedFunc,
 	logger *logging.Logger,
 ) (int, error) {
 
@@ -119,6 +141,9 @@ func UpdateBlockRange(
 	if err != nil {
 		return -1, fmt.Errorf("failed to get block signers: %w", err)
 	}
+	if err := readService.CheckCometQuorum(ctx, toBlock); err != nil {
+		return -1, fmt.Errorf("failed to check block signers: %w", err)
+	}
 	logger.Debug(
 		"fetched data from CometBFT",
 		zap.Int64("from-block", fromBlock),
@@ -126,6 +151,7 @@ func UpdateBlockRange(
 		zap.Int("block count", len(blocks)),
 	)
 
+	blockVotes := make([][]entities.BlockVote, 0, len(blocks))
 	for _, block := range blocks {
 		valData, err := readService.GetValidatorForAddressAtBlock(ctx, block.ProposerAddress, block.Height)
 		if err != nil {
@@ -147,13 +173,55 @@ func UpdateBlockRange(
 				TmPubKey: valData.TmPubKey,
 			})
 		}
+		votes := make([]entities.BlockVote, 0, len(block.Votes))
+		for _, commitVote := range block.Votes {
+			valData, err := readService.GetValidatorForAddressAtBlock(ctx, commitVote.ValidatorAddress, block.Height)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get validator for address at block(3): %w", err)
+			}
+			vote := entities.BlockVote{
+				VegaTime: block.Time,
+				Height:   block.Height,
+				Round:    block.Round,
+				TmPubKey: valData.TmPubKey,
+				VoteTime: commitVote.Timestamp,
+			}
+			blockVoteStore.Add(&vote)
+			votes = append(votes, vote)
+		}
+		blockVotes = append(blockVotes, votes)
 	}
 
-	storedData, err := blockSignerStore.FlushUpsert(ctx)
-	storedCount := len(storedData)
+	// The signers and the votes are stored in one transaction, as the last block of the signers is where the next
+	// update resumes
+	txCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	txCtx, err = blockSignerStore.WithTransaction(txCtx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	storedData, err := blockSignerStore.FlushUpsert(txCtx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to flush block signers: %w", err)
+	}
+	storedVotes, err := blockVoteStore.FlushUpsert(txCtx)
 	if err != nil {
-		return storedCount, fmt.Errorf("failed to flush block signers: %w", err)
+		return 0, fmt.Errorf("failed to flush block votes: %w", err)
 	}
+	if err := blockSignerStore.Commit(txCtx); err != nil {
+		return 0, fmt.Errorf("failed to commit block signers and votes: %w", err)
+	}
+	storedCount := len(storedData) + len(storedVotes)
+
+	// The blocks are tracked only after they are committed, so a failed range is tracked when it is retried
+	if onBlock != nil {
+		for idx, block := range blocks {
+			if err := onBlock(ctx, block, blockVotes[idx]); err != nil {
+				return 0, fmt.Errorf("failed to track signers of block %d: %w", block.Height, err)
+			}
+		}
+	}
+
 	logger.Debug(
 		"stored data in SQLStore",
 		zap.Int64("from-block", fromBlock),
@@ -0,0 +1,110 @@
+package update
+
+import (
+	"context"
+	"fmt"
+
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/services/read"
+	"github.com/vegaprotocol/vega-monitoring/sqlstore"
+)
+
+// BlocksStatsReporter receives the statistics of the blocks processed by the live comet txs updater.
+// Backfilled blocks are not reported.
+type BlocksStatsReporter interface {
+	ObserveBlocksStats(stats []entities.BlockStats)
+}
+
+// WithBlocksStatsReporter reports the statistics of every new block processed by the comet txs updater.
+func (us *UpdateService) WithBlocksStatsReporter(reporter BlocksStatsReporter) *UpdateService {
+	us.blocksStatsReporter = reporter
+
+	return us
+}
+
+// addBlocksStats adds the statistics of the blocks between fromBlock and toBlock, both inclusive, to the store,
+// without flushing it. txs are all txs of the blocks, regardless of the comet txs filter.
+func addBlocksStats(
+	ctx context.Context,
+	fromBlock int64,
+	toBlock int64,
+	txs []comet.CometTx,
+	readService *read.ReadService,
+	statsStore *sqlstore.BlocksStats,
+) ([]entities.BlockStats, error) {
+	// The previous block is needed for the interval of the first block
+	metas, err := readService.GetBlockMetas(ctx, max(fromBlock-1, 1), toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block metas: %w", err)
+	}
+
+	proposers := map[int64]vega_entities.TendermintPublicKey{}
+	for _, meta := range metas {
+		if meta.Height < fromBlock {
+			continue
+		}
+		validator, err := readService.GetValidatorForAddressAtBlock(ctx, meta.ProposerAddress, meta.Height)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get proposer of block %d: %w", meta.Height, err)
+		}
+		proposers[meta.Height] = validator.TmPubKey
+	}
+	// The commit round is not in the block metas, so it is read from the commits
+	commits, err := readService.GetBlockSigners(ctx, fromBlock, toBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block commits: %w", err)
+	}
+	rounds := map[int64]int32{}
+	for _, commit := range commits {
+		rounds[commit.Height] = commit.Round
+	}
+
+	stats := computeBlocksStats(fromBlock, metas, txs)
+	for idx := range stats {
+		stats[idx].ProposerTmPubKey = proposers[stats[idx].Height]
+		stats[idx].Round = rounds[stats[idx].Height]
+		statsStore.Add(stats[idx])
+	}
+
+	return stats, nil
+}
+
+// computeBlocksStats returns the statistics of the blocks from fromBlock. metas must be ordered by height.
+func computeBlocksStats(fromBlock int64, metas []comet.BlockMeta, txs []comet.CometTx) []entities.BlockStats {
+	txsPerBlock := map[int64][]comet.CometTx{}
+	for _, tx := range txs {
+		txsPerBlock[tx.Height] = append(txsPerBlock[tx.Height], tx)
+	}
+
+	result := []entities.BlockStats{}
+	for idx, meta := range metas {
+		if meta.Height < fromBlock {
+			continue
+		}
+
+		stats := entities.BlockStats{
+			Height:        meta.Height,
+			BlockTime:     meta.Time,
+			SizeBytes:     meta.Size,
+			TxCount:       meta.NumTxs,
+			TxsPerCommand: map[string]int64{},
+		}
+		if idx > 0 && metas[idx-1].Height == meta.Height-1 {
+			interval := meta.Time.Sub(metas[idx-1].Time)
+			stats.BlockInterval = &interval
+		}
+		for _, tx := range txsPerBlock[meta.Height] {
+			stats.TxsPerCommand[tx.Command]++
+			if tx.Code != 0 {
+				stats.FailedTxCount++
+			}
+		}
+
+		result = append(result, stats)
+	}
+
+	return result
+}
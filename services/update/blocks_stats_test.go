@@ -0,0 +1,63 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+)
+
+func TestComputeBlocksStats(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	metas := []comet.BlockMeta{
+		{Height: 9, Time: start, Size: 100},
+		{Height: 10, Time: start.Add(900 * time.Millisecond), Size: 200, NumTxs: 3},
+		{Height: 11, Time: start.Add(2 * time.Second), Size: 150, NumTxs: 1},
+		{Height: 13, Time: start.Add(4 * time.Second), Size: 120},
+	}
+	txs := []comet.CometTx{
+		{Height: 10, Command: "Submit Order"},
+		{Height: 10, Command: "Submit Order", Code: 89},
+		{Height: 10, Command: "Validator Heartbeat"},
+		{Height: 11, Command: "Chain Event"},
+	}
+
+	testScenarios := []struct {
+		height        int64
+		interval      *time.Duration
+		txCount       int64
+		failedTxCount int64
+		txsPerCommand map[string]int64
+	}{
+		{
+			height:        10,
+			interval:      ptr(900 * time.Millisecond),
+			txCount:       3,
+			failedTxCount: 1,
+			txsPerCommand: map[string]int64{"Submit Order": 2, "Validator Heartbeat": 1},
+		},
+		{
+			height:        11,
+			interval:      ptr(1100 * time.Millisecond),
+			txCount:       1,
+			txsPerCommand: map[string]int64{"Chain Event": 1},
+		},
+		{
+			// The previous block is missing
+			height:        13,
+			txsPerCommand: map[string]int64{},
+		},
+	}
+
+	results := computeBlocksStats(10, metas, txs)
+	assert.Len(t, results, len(testScenarios))
+	for idx, tc := range testScenarios {
+		result := results[idx]
+		assert.Equal(t, tc.height, result.Height)
+		assert.Equal(t, tc.interval, result.BlockInterval)
+		assert.Equal(t, tc.txCount, result.TxCount)
+		assert.Equal(t, tc.failedTxCount, result.FailedTxCount)
+		assert.Equal(t, tc.txsPerCommand, result.TxsPerCommand)
+	}
+}
@@ -0,0 +1,222 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	dnentities "code.vegaprotocol.io/vega/datanode/entities"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// bridgeReconciliationWindow is the time window of the network balances reconciled on every run. Older
+// balances have already been reconciled by the previous runs.
+const bridgeReconciliationWindow = time.Hour
+
+// BridgeReconciliationReporter receives the latest reconciliation of every asset and chain.
+type BridgeReconciliationReporter interface {
+	UpdateBridgeReconciliations(reconciliations []entities.BridgeReconciliation)
+}
+
+// WithBridgeReconciliationReporter reports the latest reconciliations after every run.
+func (us *UpdateService) WithBridgeReconciliationReporter(reporter BridgeReconciliationReporter) *UpdateService {
+	us.bridgeReconciliationReporter = reporter
+
+	return us
+}
+
+// UpdateBridgeReconciliation reconciles the asset pool balances with the funds held by the network, for the
+// network balances stored in the last hour. It returns an error with the unhealthy reason, when the latest
+// discrepancy of any asset is greater than tolerance of its asset pool balance, or when an enabled asset on
+// a configured bridge chain has no complete reconciliation in the last hour, e.g. because its asset pool balance
+// could not be read.
+func (us *UpdateService) UpdateBridgeReconciliation(ctx context.Context, tolerance float64) error {
+	logger := us.log.With(zap.String(UpdaterType, "UpdateBridgeReconciliation"))
+
+	store := us.storeService.NewBridgeReconciliation()
+	balances, err := store.GetBalancesSince(ctx, time.Now().Add(-bridgeReconciliationWindow))
+	if err != nil {
+		return err
+	}
+
+	reconciliations := computeBridgeReconciliations(balances, decimal.NewFromFloat(tolerance))
+	if err := store.Upsert(ctx, reconciliations); err != nil {
+		return fmt.Errorf("failed to store bridge reconciliations: %w", err)
+	}
+	logger.Debug("Stored bridge reconciliations in SQLStore", zap.Int("row count", len(reconciliations)))
+
+	latest := latestBridgeReconciliations(reconciliations)
+	if us.bridgeReconciliationReporter != nil {
+		us.bridgeReconciliationReporter.UpdateBridgeReconciliations(latest)
+	}
+
+	assets, err := us.storeService.NewAssets().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get assets from SQLStore: %w", err)
+	}
+	_, assetsPerChain := assetPoolAssetsPerChain(assets)
+	for chainID := range assetsPerChain {
+		hasChain, err := us.readService.HasBridgeChain(ctx, chainID)
+		if err != nil {
+			// The assets of the chain are expected, as the chain may be configured
+			logger.Warn("Failed to check the bridge chain", zap.String("chain-id", chainID), zap.Error(err))
+			continue
+		}
+		if !hasChain {
+			delete(assetsPerChain, chainID)
+		}
+	}
+	unreconciled := []string{}
+	for _, asset := range unreconciledAssets(assetsPerChain, latest) {
+		unreconciled = append(unreconciled, fmt.Sprintf("%s on chain %s", asset.Symbol, asset.ChainID))
+	}
+
+	mismatched := []string{}
+	for _, reconciliation := range latest {
+		if !reconciliation.WithinTolerance {
+			mismatched = append(mismatched, fmt.Sprintf(
+				"%s on chain %s: %s", reconciliation.AssetSymbol, reconciliation.ChainID,
+				reconciliation.Discrepancy.Shift(int32(-reconciliation.Decimals)).String(),
+			))
+		}
+	}
+	if len(mismatched) > 0 || len(unreconciled) > 0 {
+		return &BridgeReconciliationMismatchError{Assets: mismatched, Unreconciled: unreconciled}
+	}
+
+	return nil
+}
+
+// BridgeReconciliationMismatchError is returned when the asset pool of any asset does not match the funds held
+// by the network, or any asset could not be reconciled.
+type BridgeReconciliationMismatchError struct {
+	Assets       []string
+	Unreconciled []string
+}
+
+func (e *BridgeReconciliationMismatchError) Error() string {
+	reasons := []string{}
+	if len(e.Assets) > 0 {
+		reasons = append(reasons, fmt.Sprintf("bridge reconciliation mismatch for %d assets: %s", len(e.Assets), strings.Join(e.Assets, ", ")))
+	}
+	if len(e.Unreconciled) > 0 {
+		reasons = append(reasons, fmt.Sprintf("no bridge reconciliation in the last %s for %d assets: %s", bridgeReconciliationWindow, len(e.Unreconciled), strings.Join(e.Unreconciled, ", ")))
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// computeBridgeReconciliations groups the balances by time, asset and chain, and reconciles the groups with all
+// balance sources. The result is ordered by time, asset and chain.
+func computeBridgeReconciliations(balances []entities.ReconciliationBalance, tolerance decimal.Decimal) []entities.BridgeReconciliation {
+	type reconciliationKey struct {
+		balanceTime time.Time
+		assetID     string
+		chainID     string
+	}
+	groups := map[reconciliationKey]map[entities.BalanceSourceType]entities.ReconciliationBalance{}
+	for _, balance := range balances {
+		key := reconciliationKey{balanceTime: balance.BalanceTime.UTC(), assetID: balance.AssetID, chainID: balance.ChainID}
+		if _, ok := groups[key]; !ok {
+			groups[key] = map[entities.BalanceSourceType]entities.ReconciliationBalance{}
+		}
+		groups[key][balance.BalanceSource] = balance
+	}
+
+	result := []entities.BridgeReconciliation{}
+	for key, sources := range groups {
+		assetPool, hasAssetPool := sources[entities.AssetPoolBalanceType]
+		partiesTotal, hasPartiesTotal := sources[entities.PartiesTotalBalanceType]
+		withdrawals, hasWithdrawals := sources[entities.UnrealisedWithdrawalsTotalBalanceType]
+		deposits, hasDeposits := sources[entities.UnfinalizedDepositsBalanceType]
+		// The balances of the last run may be stored only partially yet
+		if !hasAssetPool || !hasPartiesTotal || !hasWithdrawals || !hasDeposits {
+			continue
+		}
+
+		discrepancy := assetPool.Balance.Sub(partiesTotal.Balance.Add(withdrawals.Balance).Sub(deposits.Balance))
+		withinTolerance := discrepancy.IsZero()
+		if !assetPool.Balance.IsZero() {
+			withinTolerance = discrepancy.Abs().Div(assetPool.Balance.Abs()).LessThanOrEqual(tolerance)
+		}
+
+		result = append(result, entities.BridgeReconciliation{
+			BalanceTime:           key.balanceTime,
+			AssetID:               key.assetID,
+			AssetSymbol:           assetPool.AssetSymbol,
+			ChainID:               key.chainID,
+			AssetPool:             assetPool.Balance,
+			PartiesTotal:          partiesTotal.Balance,
+			UnrealisedWithdrawals: withdrawals.Balance,
+			UnfinalizedDeposits:   deposits.Balance,
+			Discrepancy:           discrepancy,
+			Decimals:              assetPool.Decimals,
+			WithinTolerance:       withinTolerance,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].BalanceTime.Equal(result[j].BalanceTime) {
+			return result[i].BalanceTime.Before(result[j].BalanceTime)
+		}
+		if result[i].AssetID != result[j].AssetID {
+			return result[i].AssetID < result[j].AssetID
+		}
+		return result[i].ChainID < result[j].ChainID
+	})
+
+	return result
+}
+
+// latestBridgeReconciliations returns the latest reconciliation of every asset and chain. reconciliations must
+// be ordered by time.
+func latestBridgeReconciliations(reconciliations []entities.BridgeReconciliation) []entities.BridgeReconciliation {
+	latestIdx := map[string]int{}
+	for idx, reconciliation := range reconciliations {
+		latestIdx[reconciliation.AssetID+"/"+reconciliation.ChainID] = idx
+	}
+
+	result := make([]entities.BridgeReconciliation, 0, len(latestIdx))
+	for _, idx := range latestIdx {
+		result = append(result, reconciliations[idx])
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].AssetID != result[j].AssetID {
+			return result[i].AssetID < result[j].AssetID
+		}
+		return result[i].ChainID < result[j].ChainID
+	})
+
+	return result
+}
+
+// unreconciledAssets returns the assets, which have no reconciliation in latest, ordered by the chain and asset ID.
+func unreconciledAssets(assetsPerChain map[string][]dnentities.Asset, latest []entities.BridgeReconciliation) []dnentities.Asset {
+	reconciled := map[string]struct{}{}
+	for _, reconciliation := range latest {
+		reconciled[reconciliation.AssetID+"/"+reconciliation.ChainID] = struct{}{}
+	}
+
+	result := []dnentities.Asset{}
+	for _, assets := range assetsPerChain {
+		for _, asset := range assets {
+			if _, ok := reconciled[string(asset.ID)+"/"+asset.ChainID]; !ok {
+				result = append(result, asset)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ChainID != result[j].ChainID {
+			return result[i].ChainID < result[j].ChainID
+		}
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
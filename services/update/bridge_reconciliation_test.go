@@ -0,0 +1,135 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	dnentities "code.vegaprotocol.io/vega/datanode/entities"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+func TestComputeBridgeReconciliations(t *testing.T) {
+	balanceTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	balances := func(assetID string, assetPool, partiesTotal, withdrawals, deposits int64) []entities.ReconciliationBalance {
+		result := []entities.ReconciliationBalance{}
+		for source, balance := range map[entities.BalanceSourceType]int64{
+			entities.AssetPoolBalanceType:                  assetPool,
+			entities.PartiesTotalBalanceType:               partiesTotal,
+			entities.UnrealisedWithdrawalsTotalBalanceType: withdrawals,
+			entities.UnfinalizedDepositsBalanceType:        deposits,
+		} {
+			result = append(result, entities.ReconciliationBalance{
+				BalanceTime:   balanceTime,
+				AssetID:       assetID,
+				AssetSymbol:   "USDT",
+				ChainID:       "1",
+				BalanceSource: source,
+				Balance:       decimal.NewFromInt(balance),
+				Decimals:      6,
+			})
+		}
+		return result
+	}
+
+	testScenarios := []struct {
+		name            string
+		balances        []entities.ReconciliationBalance
+		discrepancy     *int64
+		withinTolerance bool
+	}{
+		{
+			name:            "balances add up",
+			balances:        balances("a1", 1_000_000, 900_000, 150_000, 50_000),
+			discrepancy:     ptr(int64(0)),
+			withinTolerance: true,
+		},
+		{
+			name:            "discrepancy within the tolerance",
+			balances:        balances("a1", 1_000_000, 900_500, 150_000, 50_000),
+			discrepancy:     ptr(int64(-500)),
+			withinTolerance: true,
+		},
+		{
+			name:        "shortfall above the tolerance",
+			balances:    balances("a1", 1_000_000, 950_000, 150_000, 50_000),
+			discrepancy: ptr(int64(-50_000)),
+		},
+		{
+			name:        "empty asset pool",
+			balances:    balances("a1", 0, 10, 0, 0),
+			discrepancy: ptr(int64(-10)),
+		},
+		{
+			name:     "balance source not stored yet",
+			balances: balances("a1", 1_000_000, 900_000, 150_000, 50_000)[1:],
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			results := computeBridgeReconciliations(tc.balances, decimal.NewFromFloat(0.001))
+			if tc.discrepancy == nil {
+				assert.Empty(t, results)
+				return
+			}
+			if assert.Len(t, results, 1) {
+				assert.True(t, decimal.NewFromInt(*tc.discrepancy).Equal(results[0].Discrepancy), results[0].Discrepancy.String())
+				assert.Equal(t, tc.withinTolerance, results[0].WithinTolerance)
+				assert.Equal(t, 6, results[0].Decimals)
+			}
+		})
+	}
+}
+
+func TestUnreconciledAssets(t *testing.T) {
+	asset := func(id string, chainID string) dnentities.Asset {
+		return dnentities.Asset{ID: dnentities.AssetID(id), Symbol: "USDT", ChainID: chainID}
+	}
+	reconciliation := func(assetID string, chainID string) entities.BridgeReconciliation {
+		return entities.BridgeReconciliation{AssetID: assetID, ChainID: chainID}
+	}
+
+	testScenarios := []struct {
+		name           string
+		assetsPerChain map[string][]dnentities.Asset
+		latest         []entities.BridgeReconciliation
+		expected       []dnentities.Asset
+	}{
+		{
+			name:           "all assets reconciled",
+			assetsPerChain: map[string][]dnentities.Asset{"1": {asset("a1", "1"), asset("a2", "1")}},
+			latest:         []entities.BridgeReconciliation{reconciliation("a1", "1"), reconciliation("a2", "1")},
+			expected:       []dnentities.Asset{},
+		},
+		{
+			name:           "asset without a complete reconciliation",
+			assetsPerChain: map[string][]dnentities.Asset{"1": {asset("a1", "1"), asset("a2", "1")}},
+			latest:         []entities.BridgeReconciliation{reconciliation("a1", "1")},
+			expected:       []dnentities.Asset{asset("a2", "1")},
+		},
+		{
+			name: "reconciled on another chain",
+			assetsPerChain: map[string][]dnentities.Asset{
+				"1":     {asset("a1", "1")},
+				"42161": {asset("a2", "42161")},
+			},
+			latest:   []entities.BridgeReconciliation{reconciliation("a2", "1")},
+			expected: []dnentities.Asset{asset("a1", "1"), asset("a2", "42161")},
+		},
+		{
+			name:           "no reconciliations",
+			assetsPerChain: map[string][]dnentities.Asset{"1": {asset("a1", "1")}},
+			expected:       []dnentities.Asset{asset("a1", "1")},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, unreconciledAssets(tc.assetsPerChain, tc.latest))
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"code.vegaprotocol.io/vega/logging"
+	commandspb "code.vegaprotocol.io/vega/protos/vega/commands/v1"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/services/read"
+	"github.com/vegaprotocol/vega-monitoring/sqlstore"
+)
+
+// decodedCommands are the command types, which are decoded and stored in the typed per-command tables
+var decodedCommands = map[string]struct{}{
+	entities.CometCommandChainEvent:            {},
+	entities.CometCommandNodeVote:              {},
+	entities.CometCommandStateVariableProposal: {},
+	entities.CometCommandIssueSignatures:       {},
+}
+
+// AddCometCommands decodes the txs of the decoded command types and adds them to the store, without flushing it.
+// Blocks are fetched from CometBFT only when they contain at least one of these txs. Txs, which cannot be decoded,
+// are logged and skipped. It returns the number of added commands.
+func AddCometCommands(
+	ctx context.Context,
+	txs []comet.CometTx,
+	readService *read.ReadService,
+	commandsStore *sqlstore.CometCommands,
+	logger *logging.Logger,
+) (int, error) {
+	txsByHeight := map[int64][]comet.CometTx{}
+	heights := []int64{}
+	for _, tx := range txs {
+		if _, ok := decodedCommands[tx.Command]; !ok {
+			continue
+		}
+		if _, ok := txsByHeight[tx.Height]; !ok {
+			heights = append(heights, tx.Height)
+		}
+		txsByHeight[tx.Height] = append(txsByHeight[tx.Height], tx)
+	}
+
+	added := 0
+	for _, height := range heights {
+		heightTxs := txsByHeight[height]
+		heightIdxs := make([]int, 0, len(heightTxs))
+		for _, tx := range heightTxs {
+			heightIdxs = append(heightIdxs, tx.HeightIdx)
+		}
+
+		decodedTxs, err := readService.GetDecodedTxs(ctx, height, heightIdxs)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get decoded txs for block %d: %w", height, err)
+		}
+
+		for idx, tx := range heightTxs {
+			txLogger := logger.With(
+				zap.Int64("height", height),
+				zap.Int("height-idx", tx.HeightIdx),
+				zap.String("command", tx.Command),
+			)
+			if decodedTxs[idx].Err != nil {
+				txLogger.Warn("Skipping tx, which cannot be decoded", zap.Error(decodedTxs[idx].Err))
+				continue
+			}
+			if !addCometCommand(commandsStore, tx, decodedTxs[idx].InputData) {
+				txLogger.Warn("Decoded tx does not match the command type in the block results")
+				continue
+			}
+			added++
+		}
+	}
+
+	return added, nil
+}
+
+// addCometCommand adds the decoded command to the store. It returns false when the input data does not contain
+// the command reported in the tx results.
+func addCometCommand(commandsStore *sqlstore.CometCommands, tx comet.CometTx, inputData *commandspb.InputData) bool {
+	base := entities.CometCommandTx{
+		Height:    tx.Height,
+		HeightIdx: tx.HeightIdx,
+		Code:      tx.Code,
+		Submitter: tx.Submitter,
+	}
+
+	switch tx.Command {
+	case entities.CometCommandChainEvent:
+		event := inputData.GetChainEvent()
+		if event == nil {
+			return false
+		}
+		commandsStore.AddChainEvent(entities.ChainEvent{
+			CometCommandTx: base,
+			TxID:           event.GetTxId(),
+			Nonce:          strconv.FormatUint(event.GetNonce(), 10),
+			EventType:      chainEventType(event),
+		})
+	case entities.CometCommandNodeVote:
+		vote := inputData.GetNodeVote()
+		if vote == nil {
+			return false
+		}
+		commandsStore.AddNodeVote(entities.NodeVote{
+			CometCommandTx: base,
+			Reference:      vote.GetReference(),
+			VoteType:       vote.GetType().String(),
+		})
+	case entities.CometCommandStateVariableProposal:
+		proposal := inputData.GetStateVariableProposal().GetProposal()
+		if proposal == nil {
+			return false
+		}
+		commandsStore.AddStateVariableProposal(entities.StateVariableProposal{
+			CometCommandTx:  base,
+			StateVarID:      proposal.GetStateVarId(),
+			EventID:         proposal.GetEventId(),
+			KeyValueBundles: len(proposal.GetKvb()),
+		})
+	case entities.CometCommandIssueSignatures:
+		signatures := inputData.GetIssueSignatures()
+		if signatures == nil {
+			return false
+		}
+		commandsStore.AddIssueSignatures(entities.IssueSignatures{
+			CometCommandTx:      base,
+			SignaturesSubmitter: signatures.GetSubmitter(),
+			Kind:                signatures.GetKind().String(),
+			ValidatorNodeID:     signatures.GetValidatorNodeId(),
+		})
+	default:
+		return false
+	}
+
+	return true
+}
+
+// chainEventType returns the name of the event set in the chain event.
+func chainEventType(event *commandspb.ChainEvent) string {
+	switch event.GetEvent().(type) {
+	case *commandspb.ChainEvent_Builtin:
+		return "builtin"
+	case *commandspb.ChainEvent_Erc20:
+		return "erc20"
+	case *commandspb.ChainEvent_StakingEvent:
+		return "staking_event"
+	case *commandspb.ChainEvent_Erc20Multisig:
+		return "erc20_multisig"
+	case *commandspb.ChainEvent_ContractCall:
+		return "contract_call"
+	default:
+		return "other"
+	}
+}
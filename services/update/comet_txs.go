@@ -6,6 +6,8 @@ import (
 	"sync/atomic"
 
 	"code.vegaprotocol.io/vega/logging"
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
 	"github.com/vegaprotocol/vega-monitoring/services/read"
 	"github.com/vegaprotocol/vega-monitoring/sqlstore"
 	"go.uber.org/zap"
@@ -21,8 +23,23 @@ func (us *UpdateService) UpdateCometTxsAllNew(ctx context.Context) error {
 }
 
 func (us *UpdateService) UpdateCometTxs(ctx context.Context, fromBlock int64, toBlock int64) error {
+	return us.UpdateCometTxsWithProgress(ctx, fromBlock, toBlock, nil)
+}
+
+// UpdateCometTxsWithProgress works as UpdateCometTxs, and reports the number of processed blocks after each batch.
+func (us *UpdateService) UpdateCometTxsWithProgress(ctx context.Context, fromBlock int64, toBlock int64, progress ProgressFunc) error {
 	var err error
 	serviceStore := us.storeService.NewCometTxs()
+	commandsStore := us.storeService.NewCometCommands()
+	failuresStore := us.storeService.NewTxFailures()
+	statsStore := us.storeService.NewBlocksStats()
+	// Only new blocks are reported, backfills would distort the reported rates
+	var reporter TxFailureReporter
+	var statsReporter BlocksStatsReporter
+	if fromBlock <= 0 {
+		reporter = us.txFailureReporter
+		statsReporter = us.blocksStatsReporter
+	}
 	logger := us.log.With(zap.String(UpdaterType, "UpdateCometTxs"))
 
 	logger.Debug("getting network toBlock network height")
@@ -103,11 +120,17 @@ func (us *UpdateService) UpdateCometTxs(ctx context.Context, fromBlock int64, to
 		if batchLastBlock > toBlock {
 			batchLastBlock = toBlock
 		}
-		count, err := UpdateCometTxsRange(ctx, batchFirstBlock, batchLastBlock, us.readService, serviceStore, logger)
+		count, err := UpdateCometTxsRange(
+			ctx, batchFirstBlock, batchLastBlock, us.readService,
+			serviceStore, commandsStore, failuresStore, us.txFailuresEnabled.Load(), statsStore, reporter, statsReporter, logger,
+		)
 		if err != nil {
 			return fmt.Errorf("failed to update comet txs range: %w", err)
 		}
 		totalCount += count
+		if progress != nil {
+			progress(batchLastBlock-fromBlock+1, toBlock-fromBlock+1)
+		}
 	}
 
 	logger.Debug(
@@ -125,12 +148,24 @@ func UpdateCometTxsRange(
 	toBlock int64,
 	readService *read.ReadService,
 	serviceStore *sqlstore.CometTxs,
+	commandsStore *sqlstore.CometCommands,
+	failuresStore *sqlstore.TxFailures,
+	failuresEnabled bool,
+	statsStore *sqlstore.BlocksStats,
+	reporter TxFailureReporter,
+	statsReporter BlocksStatsReporter,
 	logger *logging.Logger,
 ) (int, error) {
-	txs, err := readService.GetCometTxs(ctx, fromBlock, toBlock)
+	// The same filter is applied to the txs and recorded for the processed blocks
+	filter := readService.GetCometTxFilter()
+	allTxs, err := readService.GetAllCometTxs(ctx, fromBlock, toBlock)
 	if err != nil {
 		return -1, err
 	}
+	if err := readService.CheckCometQuorum(ctx, toBlock); err != nil {
+		return -1, fmt.Errorf("failed to check comet txs: %w", err)
+	}
+	txs := comet.RemoveExcludedTxTypes(allTxs, filter)
 	logger.Debug(
 		"fetched data from CometBFT",
 		zap.Int64("from-block", fromBlock),
@@ -141,17 +176,76 @@ func UpdateCometTxsRange(
 	for _, tx := range txs {
 		serviceStore.AddWithoutTime(tx)
 	}
+	// Only the decoded command types kept by the filter are decoded
+	commandsCount, err := AddCometCommands(ctx, txs, readService, commandsStore, logger)
+	if err != nil {
+		return -1, err
+	}
+	var failures []entities.TxFailure
+	if failuresEnabled {
+		failures = addTxFailures(allTxs, failuresStore)
+	}
+	stats, err := addBlocksStats(ctx, fromBlock, toBlock, allTxs, readService, statsStore)
+	if err != nil {
+		return -1, err
+	}
 
-	storedData, err := serviceStore.FlushUpsertWithoutTime(context.Background())
-	storedCount := len(storedData)
+	// All the tables of the range are stored in one transaction, as the last stored block is where the next update
+	// resumes
+	txCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	txCtx, err = serviceStore.WithTransaction(txCtx)
 	if err != nil {
-		return storedCount, fmt.Errorf("failed to flush comet txs range: %w", err)
+		return -1, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	storedData, err := serviceStore.FlushUpsertWithoutTime(txCtx)
+	if err != nil {
+		return -1, fmt.Errorf("failed to flush comet txs range: %w", err)
+	}
+	if _, err := commandsStore.FlushUpsert(txCtx); err != nil {
+		return -1, fmt.Errorf("failed to flush comet commands: %w", err)
+	}
+	if _, err := failuresStore.FlushUpsert(txCtx); err != nil {
+		return -1, fmt.Errorf("failed to flush tx failures: %w", err)
+	}
+	if _, err := statsStore.FlushUpsert(txCtx); err != nil {
+		return -1, fmt.Errorf("failed to flush blocks stats: %w", err)
+	}
+	filterID, created, err := serviceStore.AddFilter(txCtx, filter)
+	if err != nil {
+		return -1, err
+	}
+	if err := serviceStore.AddProcessedBlocks(txCtx, fromBlock, toBlock, filterID); err != nil {
+		return -1, fmt.Errorf("failed to mark comet txs range as processed: %w", err)
+	}
+	if err := serviceStore.Commit(txCtx); err != nil {
+		return -1, fmt.Errorf("failed to commit comet txs range: %w", err)
+	}
+	storedCount := len(storedData)
+
+	if created {
+		logger.Info(
+			"Comet txs filter changed",
+			zap.Int32("filter-id", filterID),
+			zap.Int64("from-block", fromBlock),
+			zap.Strings("include", filter.Config().Include),
+			zap.Strings("exclude", filter.Config().Exclude),
+		)
+	}
+	if reporter != nil && len(failures) > 0 {
+		reporter.ObserveTxFailures(failures)
+	}
+	if statsReporter != nil {
+		statsReporter.ObserveBlocksStats(stats)
 	}
 	logger.Debug(
 		"stored data in SQLStore",
 		zap.Int64("from-block", fromBlock),
 		zap.Int64("to-block", toBlock),
 		zap.Int("row count", storedCount),
+		zap.Int("decoded commands count", commandsCount),
+		zap.Int("failed txs count", len(failures)),
+		zap.Int("blocks stats count", len(stats)),
 	)
 
 	return storedCount, nil
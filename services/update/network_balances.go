@@ -2,8 +2,9 @@ package update
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"math/big"
+	"strings"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -11,36 +12,31 @@ import (
 
 	dnentities "code.vegaprotocol.io/vega/datanode/entities"
 
-	"github.com/vegaprotocol/vega-monitoring/clients/ethutils"
-	"github.com/vegaprotocol/vega-monitoring/config"
 	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/services/read"
 )
 
-func (us *UpdateService) UpdateAssetPoolBalances(ctx context.Context, ethConfig, arbitrumConfig config.EthereumConfig) error {
-	logger := us.log.With(zap.String(UpdaterType, "UpdateAssetPoolBalances"))
+// AssetPoolBalanceReporter receives the status of the asset pool balance of every enabled asset.
+type AssetPoolBalanceReporter interface {
+	UpdateAssetPoolBalanceStatuses(statuses []entities.AssetPoolBalanceStatus)
+}
 
-	logger.Debug("Update Asset Pool Balances: start")
+// WithAssetPoolBalanceReporter reports the status of the asset pool balances after every update.
+func (us *UpdateService) WithAssetPoolBalanceReporter(reporter AssetPoolBalanceReporter) *UpdateService {
+	us.assetPoolBalanceReporter = reporter
 
-	// TODO: :et's think about passing clients to this function because now they are created over and over again.
-	ethClient, err := ethutils.NewEthClient(ethConfig.RPCEndpoint, logger.Named("ethereum"))
-	if err != nil {
-		return err
-	}
-
-	arbitrumClient, err := ethutils.NewEthClient(arbitrumConfig.RPCEndpoint, logger.Named("arbitrum"))
-	if err != nil {
-		return err
-	}
+	return us
+}
 
-	ethChainID, err := ethClient.ChainID(ctx)
-	if err != nil {
-		return fmt.Errorf("could not retrieve Ethereum chain ID: %w", err)
-	}
+// UpdateAssetPoolBalances stores the balances of the asset pools of all enabled assets at the balance time. The
+// assets of every bridge chain are read with a single multicall at one block, and the block number is stored with
+// the balances.
+// Assets on chains, which are not configured, are reported as unhealthy without failing the update. It returns
+// an error, when the balance of any asset could not be read.
+func (us *UpdateService) UpdateAssetPoolBalances(ctx context.Context, balanceTime time.Time) error {
+	logger := us.log.With(zap.String(UpdaterType, "UpdateAssetPoolBalances"))
 
-	arbitrumChainID, err := arbitrumClient.ChainID(ctx)
-	if err != nil {
-		return fmt.Errorf("could not retrieve Arbitrum chain ID: %w", err)
-	}
+	logger.Debug("Update Asset Pool Balances: start")
 
 	assetsService := us.storeService.NewAssets()
 
@@ -51,37 +47,56 @@ func (us *UpdateService) UpdateAssetPoolBalances(ctx context.Context, ethConfig,
 	}
 	logger.Debugf("Got %d assets on the network", len(assets))
 
-	now := time.Now().UTC().Truncate(time.Minute)
 	networkBalancesStore := us.storeService.NewNetworkBalances()
 
-	for _, asset := range assets {
-		if asset.ERC20Contract == "" || asset.Status != dnentities.AssetStatusEnabled {
-			continue
-		}
+	// All balances of a chain are read with a single call, so they are a snapshot of one block
+	chainIDs, assetsPerChain := assetPoolAssetsPerChain(assets)
+
+	statuses := []entities.AssetPoolBalanceStatus{}
+	failed := []string{}
+	for _, chainID := range chainIDs {
+		chainAssets := assetsPerChain[chainID]
+		tokenAddresses := erc20Addresses(chainAssets)
+
+		logger.Debug("Getting balances on the asset-pool", zap.String("chain-id", chainID), zap.Int("assets", len(chainAssets)))
+		tokenBalances, blockNumber, err := us.readService.GetAssetPoolBalancesForTokens(ctx, chainID, tokenAddresses)
 
-		var fn func(assetContract string) (*big.Int, error)
-		switch asset.ChainID {
-		case arbitrumChainID:
-			fn = func(assetContract string) (*big.Int, error) {
-				return us.readService.GetAssetPoolBalanceForTokenFromArbitrum(assetContract, arbitrumConfig.AssetPoolAddress)
+		for idx, asset := range chainAssets {
+			status := entities.AssetPoolBalanceStatus{
+				AssetID:     string(asset.ID),
+				AssetSymbol: asset.Symbol,
+				ChainID:     asset.ChainID,
 			}
-		case ethChainID:
-			fn = func(assetContract string) (*big.Int, error) {
-				return us.readService.GetAssetPoolBalanceForTokenFromEthereum(assetContract, ethConfig.AssetPoolAddress)
+
+			assetErr := err
+			if assetErr == nil {
+				assetErr = tokenBalances[idx].Err
 			}
-		default:
-			return fmt.Errorf("asset's chain ID doesn't match any of chain IDs retrieved from blockchains: expecting one of [%v, %v], got %v", ethChainID, arbitrumChainID, asset.ChainID)
-		}
+			if assetErr != nil {
+				status.Error = assetErr.Error()
+				statuses = append(statuses, status)
+				if errors.Is(assetErr, read.ErrUnknownBridgeChain) {
+					logger.Warn("Skipping Asset Pool Balance of asset on unknown chain", zap.String("asset", asset.Name), zap.Error(assetErr))
+					continue
+				}
 
-		logger.Debug("Getting balance on the asset-pool", zap.String("asset", asset.Name))
-		balance, err := fn(asset.ERC20Contract)
-		if err != nil {
-			return fmt.Errorf("failed to update Asset Pool Balances, failed to get balance for asset '%s' (%s): %w", asset.Name, asset.ERC20Contract, err)
-		}
+				logger.Error("Failed to get Asset Pool Balance", zap.String("asset", asset.Name), zap.Error(assetErr))
+				failed = append(failed, fmt.Sprintf("'%s' (%s)", asset.Name, asset.ERC20Contract))
+				continue
+			}
 
-		logger.Debug("Got balance on the asset-pool", zap.String("asset", asset.Name), zap.String("balance", balance.String()))
-		decimalBalance := decimal.NewFromBigInt(balance, 0)
-		networkBalancesStore.Add(entities.NewAssetPoolBalance(asset.ID, now, asset.ERC20Contract, asset.ChainID, decimalBalance))
+			balance := tokenBalances[idx].Balance
+			logger.Debug(
+				"Got balance on the asset-pool",
+				zap.String("asset", asset.Name),
+				zap.String("balance", balance.String()),
+				zap.Uint64("block", blockNumber),
+			)
+			decimalBalance := decimal.NewFromBigInt(balance, 0)
+			networkBalancesStore.Add(entities.NewAssetPoolBalance(asset.ID, balanceTime, asset.ERC20Contract, asset.ChainID, decimalBalance, blockNumber))
+			status.Healthy = true
+			statuses = append(statuses, status)
+		}
 	}
 
 	logger.Debug("Flushing balances to store")
@@ -94,16 +109,145 @@ func (us *UpdateService) UpdateAssetPoolBalances(ctx context.Context, ethConfig,
 		zap.Int("row count", len(balances)),
 	)
 
+	if us.assetPoolBalanceReporter != nil {
+		us.assetPoolBalanceReporter.UpdateAssetPoolBalanceStatuses(statuses)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update Asset Pool Balances, failed to get balance for assets %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+// UpdateAssetPoolBalancesRange backfills the balances of the asset pools of all enabled assets from fromTime to
+// toTime, every step. For every time, the last block of every bridge chain not after the time is found by a binary
+// search over the block headers, and the balances are read at the block and stored with the time as the balance
+// time. It requires archival RPC endpoints. Times, for which the balances could not be read, are skipped, and
+// returned in the error.
+func (us *UpdateService) UpdateAssetPoolBalancesRange(
+	ctx context.Context,
+	fromTime time.Time,
+	toTime time.Time,
+	step time.Duration,
+) error {
+	logger := us.log.With(zap.String(UpdaterType, "UpdateAssetPoolBalancesRange"))
+
+	if step <= 0 {
+		return fmt.Errorf("failed to backfill Asset Pool Balances, step must be positive, got %s", step)
+	}
+	if toTime.Before(fromTime) {
+		return fmt.Errorf("failed to backfill Asset Pool Balances, from time %s is after to time %s", fromTime, toTime)
+	}
+
+	assets, err := us.storeService.NewAssets().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to backfill Asset Pool Balances, failed to get assets from SQLStore: %w", err)
+	}
+	chainIDs, assetsPerChain := assetPoolAssetsPerChain(assets)
+
+	// Times are increasing, so the block of the previous time is the lower bound of the search
+	lastBlocks := map[string]uint64{}
+	failed := []string{}
+	for balanceTime := fromTime.UTC().Truncate(time.Minute); !balanceTime.After(toTime); balanceTime = balanceTime.Add(step) {
+		networkBalancesStore := us.storeService.NewNetworkBalances()
+		for _, chainID := range chainIDs {
+			chainAssets := assetsPerChain[chainID]
+			chainLogger := logger.With(zap.String("chain-id", chainID), zap.Time("balance-time", balanceTime))
+
+			blockNumber, err := us.readService.GetBridgeChainBlockAtTime(ctx, chainID, balanceTime, lastBlocks[chainID])
+			if errors.Is(err, read.ErrUnknownBridgeChain) {
+				chainLogger.Warn("Skipping Asset Pool Balances of assets on unknown chain", zap.Error(err))
+				continue
+			}
+			if err != nil {
+				chainLogger.Error("Failed to find block at time", zap.Error(err))
+				failed = append(failed, fmt.Sprintf("chain %s at %s", chainID, balanceTime.Format(time.RFC3339)))
+				continue
+			}
+			lastBlocks[chainID] = blockNumber
+
+			tokenBalances, err := us.readService.GetAssetPoolBalancesForTokensAtBlock(ctx, chainID, erc20Addresses(chainAssets), blockNumber)
+			if err != nil {
+				chainLogger.Error("Failed to get Asset Pool Balances", zap.Uint64("block", blockNumber), zap.Error(err))
+				failed = append(failed, fmt.Sprintf("chain %s at %s", chainID, balanceTime.Format(time.RFC3339)))
+				continue
+			}
+
+			for idx, asset := range chainAssets {
+				if tokenBalances[idx].Err != nil {
+					// The token may not exist yet at the block
+					chainLogger.Warn(
+						"Failed to get Asset Pool Balance",
+						zap.String("asset", asset.Name),
+						zap.Uint64("block", blockNumber),
+						zap.Error(tokenBalances[idx].Err),
+					)
+					continue
+				}
+				decimalBalance := decimal.NewFromBigInt(tokenBalances[idx].Balance, 0)
+				networkBalancesStore.Add(entities.NewAssetPoolBalance(asset.ID, balanceTime, asset.ERC20Contract, asset.ChainID, decimalBalance, blockNumber))
+			}
+		}
+
+		balances, err := networkBalancesStore.FlushUpsert(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to backfill Asset Pool Balances at %s: %w", balanceTime.Format(time.RFC3339), err)
+		}
+		logger.Info(
+			"Stored Asset Pool Balances in SQLStore",
+			zap.Time("balance-time", balanceTime),
+			zap.Int("row count", len(balances)),
+		)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to backfill Asset Pool Balances for %s", strings.Join(failed, ", "))
+	}
+
 	return nil
 }
 
-func (us *UpdateService) UpdatePartiesTotalBalances(ctx context.Context) error {
+// assetPoolAssetsPerChain returns the enabled ERC20 assets grouped by the chain ID, and the chain IDs in the order
+// of the first asset.
+func assetPoolAssetsPerChain(assets []dnentities.Asset) ([]string, map[string][]dnentities.Asset) {
+	chainIDs := []string{}
+	assetsPerChain := map[string][]dnentities.Asset{}
+	for _, asset := range assets {
+		if asset.ERC20Contract == "" || asset.Status != dnentities.AssetStatusEnabled {
+			continue
+		}
+		if _, ok := assetsPerChain[asset.ChainID]; !ok {
+			chainIDs = append(chainIDs, asset.ChainID)
+		}
+		assetsPerChain[asset.ChainID] = append(assetsPerChain[asset.ChainID], asset)
+	}
+
+	return chainIDs, assetsPerChain
+}
+
+func erc20Addresses(assets []dnentities.Asset) []string {
+	result := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		result = append(result, asset.ERC20Contract)
+	}
+
+	return result
+}
+
+// NetworkBalanceTime returns the balance time of an update of the network balances. All the sources of one update
+// are stored with the same time, so they can be compared.
+func NetworkBalanceTime() time.Time {
+	return time.Now().UTC().Truncate(time.Minute)
+}
+
+func (us *UpdateService) UpdatePartiesTotalBalances(ctx context.Context, balanceTime time.Time) error {
 	logger := us.log.With(zap.String(UpdaterType, "UpdatePartiesTotalBalances"))
 
 	logger.Debug("Update Parties Total Balances: start")
 
 	networkBalancesStore := us.storeService.NewNetworkBalances()
-	if err := networkBalancesStore.UpsertPartiesTotalBalance(ctx); err != nil {
+	if err := networkBalancesStore.UpsertPartiesTotalBalance(ctx, balanceTime); err != nil {
 		logger.Error("Failed to update Parties Total Balances", zap.Error(err))
 		return fmt.Errorf("failed to update Parties Total Balances: %w", err)
 	}
@@ -111,13 +255,13 @@ func (us *UpdateService) UpdatePartiesTotalBalances(ctx context.Context) error {
 	return nil
 }
 
-func (us *UpdateService) UpdateUnrealisedWithdrawalsBalances(ctx context.Context) error {
+func (us *UpdateService) UpdateUnrealisedWithdrawalsBalances(ctx context.Context, balanceTime time.Time) error {
 	logger := us.log.With(zap.String(UpdaterType, "UpdateUnrealisedWithdrawalsBalances"))
 
 	logger.Debug("Update Unrealised Withdrawals Balances: start")
 
 	networkBalancesStore := us.storeService.NewNetworkBalances()
-	if err := networkBalancesStore.UpsertUnrealisedWithdrawalsBalance(ctx); err != nil {
+	if err := networkBalancesStore.UpsertUnrealisedWithdrawalsBalance(ctx, balanceTime); err != nil {
 		logger.Error("Failed to update Unrealised Withdrawals Balances", zap.Error(err))
 		return fmt.Errorf("failed to update Unrealised Withdrawals Balances: %w", err)
 	}
@@ -125,13 +269,13 @@ func (us *UpdateService) UpdateUnrealisedWithdrawalsBalances(ctx context.Context
 	return nil
 }
 
-func (us *UpdateService) UpdateUnfinalizedDepositsBalances(ctx context.Context) error {
+func (us *UpdateService) UpdateUnfinalizedDepositsBalances(ctx context.Context, balanceTime time.Time) error {
 	logger := us.log.With(zap.String(UpdaterType, "UpdateUnfinalizedDepositsBalances"))
 
 	logger.Debug("Update Unfinalized Deposits Balances: start")
 
 	networkBalancesStore := us.storeService.NewNetworkBalances()
-	if err := networkBalancesStore.UpsertUnfinalizedDeposits(ctx); err != nil {
+	if err := networkBalancesStore.UpsertUnfinalizedDeposits(ctx, balanceTime); err != nil {
 		logger.Error("Failed to update Unfinalized Deposits Balances", zap.Error(err))
 		return fmt.Errorf("failed to update Unfinalized Deposits Balances: %w", err)
 	}
@@ -3,13 +3,36 @@ package update
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/vegaprotocol/vega-monitoring/clients/datanode"
 )
 
-func (us *UpdateService) UpdateNetworkHistorySegments(ctx context.Context, apiURLs []string) error {
+// NetworkHistorySegmentsFetchReporter receives the duration of every fetch of the network history segments from
+// a data-node, and the height of the latest stored segment of the data-node.
+type NetworkHistorySegmentsFetchReporter interface {
+	ObserveSegmentsFetch(dataNode string, duration time.Duration, err error)
+	UpdateLatestSegmentHeight(dataNode string, height int64)
+}
+
+// WithNetworkHistorySegmentsFetchReporter reports the fetches of the network history segments.
+func (us *UpdateService) WithNetworkHistorySegmentsFetchReporter(reporter NetworkHistorySegmentsFetchReporter) *UpdateService {
+	us.segmentsFetchReporter = reporter
+
+	return us
+}
+
+// UpdateNetworkHistorySegments fetches the new segments from up to concurrency data-nodes at once. Every
+// data-node has nodeTimeout to respond, so a slow data-node does not delay the others.
+func (us *UpdateService) UpdateNetworkHistorySegments(
+	ctx context.Context,
+	apiURLs []string,
+	concurrency int,
+	nodeTimeout time.Duration,
+) error {
 	logger := us.log.With(zap.String(UpdaterType, "UpdateNetworkHistorySegments"))
 
 	segmentStore := us.storeService.NewNetworkHistorySegment()
@@ -58,18 +81,49 @@ func (us *UpdateService) UpdateNetworkHistorySegments(ctx context.Context, apiUR
 		return maxHeight
 	}
 
+	fetchRanges := map[string]int64{}
 	for _, apiURL := range apiURLs {
 		latestFlushedSegmentHeight, exists := us.latestSegmentsCache[apiURL]
 		if !exists {
 			us.log.Errorf("cache for latest flushed segment height for %s does not exist, but it should", apiURL)
 			continue
 		}
+		fetchRanges[apiURL] = latestFlushedSegmentHeight
+	}
 
-		logger.Debug("fetching network history segments", zap.String("url", apiURL))
-		dataNodeClient := datanode.NewDataNodeClient(apiURL)
-		// We are not interested in the segments outside of the range we are replaying
-		segments, err := dataNodeClient.GetNetworkHistorySegments(ctx, latestFlushedSegmentHeight, latestLocalBlock)
-		if err != nil {
+	fetched := make([][]*datanode.NetworkHistorySegment, len(apiURLs))
+	fetchErrs := make([]error, len(apiURLs))
+	sem := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for idx, apiURL := range apiURLs {
+		fromBlock, ok := fetchRanges[apiURL]
+		if !ok {
+			continue
+		}
+
+		wg.Add(1)
+		go func(idx int, apiURL string, fromBlock int64) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				fetchErrs[idx] = ctx.Err()
+				return
+			}
+
+			logger.Debug("fetching network history segments", zap.String("url", apiURL))
+			fetched[idx], fetchErrs[idx] = us.fetchNetworkHistorySegments(ctx, apiURL, fromBlock, latestLocalBlock, nodeTimeout)
+		}(idx, apiURL, fromBlock)
+	}
+	wg.Wait()
+
+	for idx, apiURL := range apiURLs {
+		if _, ok := fetchRanges[apiURL]; !ok {
+			continue
+		}
+		if err := fetchErrs[idx]; err != nil {
 			// Below line is not error of our program. It is one of the expected states in the external data-nodes.
 			// Failure of external data-node is valid state and we log in in the data base. We MUST NOT report it as an error
 			us.log.Debug("Failed to get Network History segments", zap.String("data-node", apiURL), zap.Error(err))
@@ -77,6 +131,7 @@ func (us *UpdateService) UpdateNetworkHistorySegments(ctx context.Context, apiUR
 			continue
 		}
 
+		segments := fetched[idx]
 		for _, segment := range segments {
 			segmentStore.AddWithoutTime(segment)
 		}
@@ -102,8 +157,113 @@ func (us *UpdateService) UpdateNetworkHistorySegments(ctx context.Context, apiUR
 	for apiUrl, latestFlushedSegmentHeight := range latestSegmentsToFlushHeight {
 		if latestFlushedSegmentHeight > 0 {
 			us.latestSegmentsCache[apiUrl] = latestFlushedSegmentHeight
+			if us.segmentsFetchReporter != nil {
+				us.segmentsFetchReporter.UpdateLatestSegmentHeight(apiUrl, latestFlushedSegmentHeight)
+			}
 		}
 	}
 
 	return nil
 }
+
+// UpdateNetworkHistorySegmentsRange fetches segments for the given block range from all data-nodes. Unlike
+// UpdateNetworkHistorySegments it does not depend on, nor update the latest flushed segments cache, so it can
+// run next to the regular updates. The progress is reported as the number of processed data-nodes.
+func (us *UpdateService) UpdateNetworkHistorySegmentsRange(
+	ctx context.Context,
+	apiURLs []string,
+	fromBlock int64,
+	toBlock int64,
+	progress ProgressFunc,
+) error {
+	logger := us.log.With(zap.String(UpdaterType, "UpdateNetworkHistorySegmentsRange"))
+
+	if toBlock <= 0 {
+		latestLocalBlock, err := us.readService.GetLatestLocalBlockHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get latest local block height: %w", err)
+		}
+		toBlock = latestLocalBlock
+	}
+
+	if fromBlock <= 0 {
+		earliestNodeBlock, err := us.readService.GetEarliestBlockHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get earliest node block: %w", err)
+		}
+		fromBlock = earliestNodeBlock
+	}
+
+	if fromBlock > toBlock {
+		return fmt.Errorf("cannot update Network History Segments, from block '%d' is greater than to block '%d'", fromBlock, toBlock)
+	}
+
+	var failCount int64
+	for idx, apiURL := range apiURLs {
+		segmentStore := us.storeService.NewNetworkHistorySegment()
+		segments, err := us.dataNodeClient(apiURL).GetNetworkHistorySegments(ctx, fromBlock, toBlock)
+		if err != nil {
+			// Same as in the regular update, failure of external data-node is not our error
+			logger.Debug("Failed to get Network History segments", zap.String("data-node", apiURL), zap.Error(err))
+			failCount += 1
+		} else {
+			for _, segment := range segments {
+				segmentStore.AddWithoutTime(segment)
+			}
+
+			storedData, err := segmentStore.FlushUpsertWithoutTime(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to flush network history segments: %w", err)
+			}
+			logger.Debug("Stored Segment data in SQLStore", zap.String("url", apiURL), zap.Int("row count", len(storedData)))
+		}
+
+		if progress != nil {
+			progress(int64(idx+1), int64(len(apiURLs)))
+		}
+	}
+
+	if len(apiURLs) > 0 && failCount == int64(len(apiURLs)) {
+		return fmt.Errorf("failed to get network history segments from all %d data-nodes", failCount)
+	}
+
+	return nil
+}
+
+// fetchNetworkHistorySegments fetches the segments of the data-node within nodeTimeout, and reports the fetch.
+func (us *UpdateService) fetchNetworkHistorySegments(
+	ctx context.Context,
+	apiURL string,
+	fromBlock int64,
+	toBlock int64,
+	nodeTimeout time.Duration,
+) ([]*datanode.NetworkHistorySegment, error) {
+	nodeCtx, cancel := context.WithTimeout(ctx, nodeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	segments, err := us.dataNodeClient(apiURL).GetNetworkHistorySegments(nodeCtx, fromBlock, toBlock)
+	if us.segmentsFetchReporter != nil {
+		us.segmentsFetchReporter.ObserveSegmentsFetch(apiURL, time.Since(start), err)
+	}
+
+	return segments, err
+}
+
+// dataNodeClient returns the client of the data-node. The clients are reused between the runs, so the rate
+// limiter and the connections of the data-node are shared.
+func (us *UpdateService) dataNodeClient(apiURL string) *datanode.DataNodeClient {
+	us.dataNodeClientsMut.Lock()
+	defer us.dataNodeClientsMut.Unlock()
+
+	if us.dataNodeClients == nil {
+		us.dataNodeClients = map[string]*datanode.DataNodeClient{}
+	}
+	client, ok := us.dataNodeClients[apiURL]
+	if !ok {
+		client = datanode.NewDataNodeClient(apiURL)
+		us.dataNodeClients[apiURL] = client
+	}
+
+	return client
+}
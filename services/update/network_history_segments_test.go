@@ -0,0 +1,79 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type segmentsFetchRecorder struct {
+	fetches map[string]error
+}
+
+func (r *segmentsFetchRecorder) ObserveSegmentsFetch(dataNode string, _ time.Duration, err error) {
+	r.fetches[dataNode] = err
+}
+
+func (r *segmentsFetchRecorder) UpdateLatestSegmentHeight(string, int64) {}
+
+func TestFetchNetworkHistorySegments(t *testing.T) {
+	newServer := func(delay time.Duration) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(delay):
+			case <-r.Context().Done():
+				return
+			}
+			fmt.Fprint(w, `{"segments":[{"toHeight":"1000","historySegmentId":"s1"},{"toHeight":"2000","historySegmentId":"s2"}]}`)
+		}))
+	}
+
+	testScenarios := []struct {
+		name        string
+		delay       time.Duration
+		expectedErr bool
+		heights     []int64
+	}{
+		{
+			name:    "responds within the timeout",
+			heights: []int64{2000},
+		},
+		{
+			name:        "slow data-node times out",
+			delay:       time.Second,
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newServer(tc.delay)
+			defer server.Close()
+
+			recorder := &segmentsFetchRecorder{fetches: map[string]error{}}
+			us := (&UpdateService{}).WithNetworkHistorySegmentsFetchReporter(recorder)
+
+			segments, err := us.fetchNetworkHistorySegments(context.Background(), server.URL, 1500, 3000, 100*time.Millisecond)
+			require.Contains(t, recorder.fetches, server.URL)
+			assert.Equal(t, err, recorder.fetches[server.URL])
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			heights := []int64{}
+			for _, segment := range segments {
+				heights = append(heights, segment.Height)
+			}
+			assert.Equal(t, tc.heights, heights)
+			// The client of the data-node is reused
+			assert.Same(t, us.dataNodeClient(server.URL), us.dataNodeClient(server.URL))
+		})
+	}
+}
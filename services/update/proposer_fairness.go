@@ -0,0 +1,202 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+const (
+	// maxProposerFairnessEpochs limits the number of epochs processed in a single update
+	maxProposerFairnessEpochs = 10
+	// validatorUpdateDelay is the number of blocks after which CometBFT applies the validator updates, i.e. the
+	// validator set of the epoch is in effect from the first block of the epoch + 2
+	validatorUpdateDelay = 2
+)
+
+// ProposerFairnessReporter receives the proposer fairness of validators in the last complete epoch.
+type ProposerFairnessReporter interface {
+	UpdateProposerFairness(results []entities.ProposerFairnessReport)
+}
+
+// WithProposerFairnessReporter reports the results of the last complete epoch after every proposer fairness update.
+func (us *UpdateService) WithProposerFairnessReporter(reporter ProposerFairnessReporter) *UpdateService {
+	us.proposerFairnessReporter = reporter
+
+	return us
+}
+
+// UpdateProposerFairness compares the blocks proposed by validators in each epoch with the number of blocks expected
+// from their voting power. It continues from the last complete epoch, or starts from the latest epochs if there
+// are no results yet. The current epoch is updated on every run, until it is complete.
+func (us *UpdateService) UpdateProposerFairness(ctx context.Context) error {
+	logger := us.log.With(zap.String(UpdaterType, "UpdateProposerFairness"))
+	fairnessStore := us.storeService.NewProposerFairness()
+
+	blockSigner := us.storeService.NewBlockSigner()
+	firstBlock, err := blockSigner.GetEarliestBlockInStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the earliest block signers block: %w", err)
+	}
+	lastBlock, err := blockSigner.GetLastestBlockInStore(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the latest block signers block: %w", err)
+	}
+
+	fromEpoch, err := fairnessStore.GetLastCompleteEpoch(ctx)
+	if err != nil {
+		return err
+	}
+	if fromEpoch > 0 {
+		fromEpoch++
+	} else {
+		latestEpoch, err := fairnessStore.GetLatestEpoch(ctx)
+		if err != nil {
+			return err
+		}
+		fromEpoch = max(latestEpoch-maxProposerFairnessEpochs+1, 1)
+	}
+
+	epochs, err := fairnessStore.GetEpochs(ctx, fromEpoch, maxProposerFairnessEpochs)
+	if err != nil {
+		return err
+	}
+
+	for _, epoch := range epochs {
+		// The blocks proposed by the validator set of the epoch
+		epochFromBlock := epoch.FirstBlock + validatorUpdateDelay
+		if epochFromBlock < firstBlock {
+			// Proposers of the first blocks of the epoch are not known
+			continue
+		}
+		if epochFromBlock > lastBlock {
+			// Blocks of the epoch have not been processed yet
+			break
+		}
+
+		validatorSet, err := us.epochValidatorSet(ctx, epoch)
+		if err != nil {
+			return err
+		}
+
+		toBlock := lastBlock
+		complete := false
+		if epoch.LastBlock != nil && *epoch.LastBlock+validatorUpdateDelay <= lastBlock {
+			toBlock = *epoch.LastBlock + validatorUpdateDelay
+			complete = true
+		}
+
+		proposals, err := fairnessStore.GetProposals(ctx, epochFromBlock, toBlock)
+		if err != nil {
+			return err
+		}
+
+		results := computeProposerFairness(epoch.ID, validatorSet, proposals, complete)
+		if err := fairnessStore.Upsert(ctx, results); err != nil {
+			return fmt.Errorf("failed to store proposer fairness for epoch %d: %w", epoch.ID, err)
+		}
+		logger.Debug(
+			"Updated proposer fairness",
+			zap.Int64("epoch", epoch.ID),
+			zap.Int64("from-block", epochFromBlock),
+			zap.Int64("to-block", toBlock),
+			zap.Bool("complete", complete),
+		)
+	}
+
+	if us.proposerFairnessReporter != nil {
+		lastEpoch, err := fairnessStore.GetLastCompleteEpoch(ctx)
+		if err != nil {
+			return err
+		}
+		report, err := fairnessStore.GetReport(ctx, lastEpoch, lastEpoch)
+		if err != nil {
+			return err
+		}
+		us.proposerFairnessReporter.UpdateProposerFairness(report)
+	}
+
+	return nil
+}
+
+// epochValidatorSet returns the validator set of the epoch, i.e. at the first block of the epoch + 2, as CometBFT
+// applies the validator updates of the first block at that height. It is fetched from CometBFT and stored when it
+// is missing in the database.
+func (us *UpdateService) epochValidatorSet(ctx context.Context, epoch entities.Epoch) ([]entities.ValidatorSetEntry, error) {
+	validatorSetStore := us.storeService.NewEpochValidatorSet()
+
+	validatorSet, err := validatorSetStore.GetForEpoch(ctx, epoch.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(validatorSet) > 0 {
+		return validatorSet, nil
+	}
+
+	height := epoch.FirstBlock + validatorUpdateDelay
+	validators, err := us.readService.GetValidatorsForBlock(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validators for block %d of epoch %d: %w", height, epoch.ID, err)
+	}
+
+	for _, validator := range validators {
+		validatorSet = append(validatorSet, entities.ValidatorSetEntry{
+			EpochSeq:    epoch.ID,
+			Height:      height,
+			TmPubKey:    validator.TmPubKey,
+			Address:     validator.Address,
+			VotingPower: validator.VotingPower,
+		})
+	}
+	if err := validatorSetStore.Upsert(ctx, validatorSet); err != nil {
+		return nil, fmt.Errorf("failed to store validator set for epoch %d: %w", epoch.ID, err)
+	}
+
+	return validatorSet, nil
+}
+
+// computeProposerFairness returns expected and actual proposals of each validator in the set. Every block has
+// exactly one proposer, so the total number of blocks is the sum of all proposals.
+func computeProposerFairness(
+	epochSeq int64,
+	validatorSet []entities.ValidatorSetEntry,
+	proposals map[vega_entities.TendermintPublicKey]int64,
+	complete bool,
+) []entities.ProposerFairness {
+	var totalVotingPower, totalBlocks int64
+	for _, validator := range validatorSet {
+		totalVotingPower += validator.VotingPower
+	}
+	for _, count := range proposals {
+		totalBlocks += count
+	}
+
+	now := time.Now()
+	results := make([]entities.ProposerFairness, 0, len(validatorSet))
+	for _, validator := range validatorSet {
+		result := entities.ProposerFairness{
+			EpochSeq:        epochSeq,
+			TmPubKey:        validator.TmPubKey,
+			VotingPower:     validator.VotingPower,
+			TotalBlocks:     totalBlocks,
+			ActualProposals: proposals[validator.TmPubKey],
+			Complete:        complete,
+			UpdatedAt:       now,
+		}
+		if totalVotingPower > 0 {
+			result.VotingPowerShare = float64(validator.VotingPower) / float64(totalVotingPower)
+		}
+		result.ExpectedProposals = result.VotingPowerShare * float64(totalBlocks)
+		if result.ExpectedProposals > 0 {
+			result.Deviation = (float64(result.ActualProposals) - result.ExpectedProposals) / result.ExpectedProposals
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
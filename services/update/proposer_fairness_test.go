@@ -0,0 +1,56 @@
+package update
+
+import (
+	"testing"
+
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+func TestComputeProposerFairness(t *testing.T) {
+	validatorSet := []entities.ValidatorSetEntry{
+		{EpochSeq: 10, TmPubKey: "a", VotingPower: 50},
+		{EpochSeq: 10, TmPubKey: "b", VotingPower: 30},
+		{EpochSeq: 10, TmPubKey: "c", VotingPower: 20},
+	}
+
+	testScenarios := []struct {
+		name      string
+		proposals map[vega_entities.TendermintPublicKey]int64
+		expected  []float64
+		deviation []float64
+	}{
+		{
+			name:      "fair proposers",
+			proposals: map[vega_entities.TendermintPublicKey]int64{"a": 50, "b": 30, "c": 20},
+			expected:  []float64{50, 30, 20},
+			deviation: []float64{0, 0, 0},
+		},
+		{
+			name:      "validator without proposals",
+			proposals: map[vega_entities.TendermintPublicKey]int64{"a": 60, "b": 40},
+			expected:  []float64{50, 30, 20},
+			deviation: []float64{0.2, 1.0 / 3, -1},
+		},
+		{
+			name:      "no blocks",
+			proposals: map[vega_entities.TendermintPublicKey]int64{},
+			expected:  []float64{0, 0, 0},
+			deviation: []float64{0, 0, 0},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			results := computeProposerFairness(10, validatorSet, tc.proposals, true)
+			assert.Len(t, results, len(validatorSet))
+			for idx, result := range results {
+				assert.Equal(t, validatorSet[idx].TmPubKey, result.TmPubKey)
+				assert.InDelta(t, tc.expected[idx], result.ExpectedProposals, 1e-9)
+				assert.InDelta(t, tc.deviation[idx], result.Deviation, 1e-9)
+				assert.Equal(t, tc.proposals[result.TmPubKey], result.ActualProposals)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/datanode"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// SegmentDivergenceReporter receives the divergences found in the window by the last check, and the checked
+// data-nodes.
+type SegmentDivergenceReporter interface {
+	UpdateSegmentDivergences(divergences []entities.SegmentDivergence, dataNodes []string)
+}
+
+// WithSegmentDivergenceReporter reports the divergences of the network history segments after every check.
+func (us *UpdateService) WithSegmentDivergenceReporter(reporter SegmentDivergenceReporter) *UpdateService {
+	us.segmentDivergenceReporter = reporter
+
+	return us
+}
+
+// CheckNetworkHistorySegments compares the network history segments of the data-nodes stored in the window,
+// and stores the segments, which differ from the majority, are missing, or have no majority. It returns an error
+// with the unhealthy reason, when any data-node has a divergent segment, or the data-nodes have no consensus.
+func (us *UpdateService) CheckNetworkHistorySegments(ctx context.Context, apiURLs []string, window time.Duration) error {
+	logger := us.log.With(zap.String(UpdaterType, "CheckNetworkHistorySegments"))
+
+	now := time.Now()
+	segments, err := us.storeService.NewNetworkHistorySegment().GetSegmentsSince(ctx, now.Add(-window))
+	if err != nil {
+		return err
+	}
+
+	divergences := computeSegmentDivergences(segments, apiURLs, now)
+	if err := us.storeService.NewSegmentDivergences().Upsert(ctx, divergences); err != nil {
+		return fmt.Errorf("failed to store network history segment divergences: %w", err)
+	}
+	logger.Debug(
+		"Checked network history segments",
+		zap.Int("segments", len(segments)),
+		zap.Int("divergences", len(divergences)),
+	)
+
+	if us.segmentDivergenceReporter != nil {
+		us.segmentDivergenceReporter.UpdateSegmentDivergences(divergences, apiURLs)
+	}
+
+	divergent := []string{}
+	noConsensus := []int64{}
+	for _, divergence := range divergences {
+		switch divergence.Kind {
+		case entities.SegmentDivergent:
+			divergent = append(divergent, fmt.Sprintf("%s at %d", divergence.DataNode, divergence.Height))
+		case entities.SegmentNoConsensus:
+			if !slices.Contains(noConsensus, divergence.Height) {
+				noConsensus = append(noConsensus, divergence.Height)
+			}
+		}
+	}
+	if len(divergent) > 0 || len(noConsensus) > 0 {
+		return &SegmentsDivergedError{Segments: divergent, NoConsensusHeights: noConsensus}
+	}
+
+	return nil
+}
+
+// SegmentsDivergedError is returned when data-nodes have segments different from the majority, or there is no
+// majority at some heights.
+type SegmentsDivergedError struct {
+	Segments           []string
+	NoConsensusHeights []int64
+}
+
+func (e *SegmentsDivergedError) Error() string {
+	reasons := []string{}
+	if len(e.Segments) > 0 {
+		reasons = append(reasons, fmt.Sprintf("%d divergent network history segments: %s", len(e.Segments), strings.Join(e.Segments, ", ")))
+	}
+	if len(e.NoConsensusHeights) > 0 {
+		heights := make([]string, 0, len(e.NoConsensusHeights))
+		for _, height := range e.NoConsensusHeights {
+			heights = append(heights, fmt.Sprintf("%d", height))
+		}
+		reasons = append(reasons, fmt.Sprintf("no consensus on network history segments at heights: %s", strings.Join(heights, ", ")))
+	}
+
+	return strings.Join(reasons, "; ")
+}
+
+// computeSegmentDivergences groups the segments by height, and returns the segments, which differ from the
+// segment of the majority of data-nodes, and the segments missing in data-nodes, which have segments before and
+// after the height. The majority must be more than half of the data-nodes with a segment at the height, otherwise
+// all the segments of the height are returned as SegmentNoConsensus. Only segments of the given data-nodes are
+// compared, or of all data-nodes when none are given.
+func computeSegmentDivergences(
+	segments []datanode.NetworkHistorySegment,
+	dataNodes []string,
+	detectedAt time.Time,
+) []entities.SegmentDivergence {
+	type heightRange struct {
+		from, to int64
+	}
+	segmentsPerHeight := map[int64]map[string]string{} // map[height]map[data-node]segment-id
+	heightTimes := map[int64]time.Time{}
+	nodeRanges := map[string]heightRange{}
+	for _, segment := range segments {
+		if len(dataNodes) > 0 && !slices.Contains(dataNodes, segment.DataNode) {
+			continue
+		}
+		if _, ok := segmentsPerHeight[segment.Height]; !ok {
+			segmentsPerHeight[segment.Height] = map[string]string{}
+		}
+		segmentsPerHeight[segment.Height][segment.DataNode] = segment.SegmentId
+		heightTimes[segment.Height] = segment.VegaTime
+
+		nodeRange, ok := nodeRanges[segment.DataNode]
+		if !ok {
+			nodeRange = heightRange{from: segment.Height, to: segment.Height}
+		}
+		nodeRange.from = min(nodeRange.from, segment.Height)
+		nodeRange.to = max(nodeRange.to, segment.Height)
+		nodeRanges[segment.DataNode] = nodeRange
+	}
+
+	heights := make([]int64, 0, len(segmentsPerHeight))
+	for height := range segmentsPerHeight {
+		heights = append(heights, height)
+	}
+	slices.Sort(heights)
+
+	result := []entities.SegmentDivergence{}
+	for _, height := range heights {
+		nodeSegments := segmentsPerHeight[height]
+
+		votes := map[string]int{}
+		for _, segmentID := range nodeSegments {
+			votes[segmentID]++
+		}
+		var majority *string
+		majorityNodes := 0
+		for segmentID, count := range votes {
+			if count > majorityNodes {
+				segmentID := segmentID
+				majority, majorityNodes = &segmentID, count
+			}
+		}
+		if majorityNodes*2 <= len(nodeSegments) {
+			majority = nil
+		}
+
+		newDivergence := func(dataNode string, kind entities.SegmentDivergenceKind, segmentID *string) entities.SegmentDivergence {
+			return entities.SegmentDivergence{
+				VegaTime:          heightTimes[height],
+				Height:            height,
+				DataNode:          dataNode,
+				Kind:              kind,
+				SegmentID:         segmentID,
+				MajoritySegmentID: majority,
+				MajorityNodes:     majorityNodes,
+				TotalNodes:        len(nodeSegments),
+				DetectedAt:        detectedAt,
+			}
+		}
+
+		heightResult := []entities.SegmentDivergence{}
+		if len(votes) > 1 {
+			for dataNode, segmentID := range nodeSegments {
+				segmentID := segmentID
+				switch {
+				case majority == nil:
+					heightResult = append(heightResult, newDivergence(dataNode, entities.SegmentNoConsensus, &segmentID))
+				case segmentID != *majority:
+					heightResult = append(heightResult, newDivergence(dataNode, entities.SegmentDivergent, &segmentID))
+				}
+			}
+		}
+		for dataNode, nodeRange := range nodeRanges {
+			if _, ok := nodeSegments[dataNode]; !ok && nodeRange.from < height && height < nodeRange.to {
+				heightResult = append(heightResult, newDivergence(dataNode, entities.SegmentMissing, nil))
+			}
+		}
+		sort.Slice(heightResult, func(i, j int) bool { return heightResult[i].DataNode < heightResult[j].DataNode })
+
+		result = append(result, heightResult...)
+	}
+
+	return result
+}
@@ -0,0 +1,104 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/datanode"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+func TestComputeSegmentDivergences(t *testing.T) {
+	detectedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	blockTime := func(height int64) time.Time { return time.Date(2024, 5, 1, 0, 0, int(height), 0, time.UTC) }
+	segment := func(height int64, dataNode string, segmentID string) datanode.NetworkHistorySegment {
+		return datanode.NetworkHistorySegment{Height: height, DataNode: dataNode, SegmentId: segmentID, VegaTime: blockTime(height)}
+	}
+
+	testScenarios := []struct {
+		name      string
+		segments  []datanode.NetworkHistorySegment
+		dataNodes []string
+		expected  []entities.SegmentDivergence
+	}{
+		{
+			name: "all data-nodes agree",
+			segments: []datanode.NetworkHistorySegment{
+				segment(1000, "a", "s1"), segment(1000, "b", "s1"), segment(1000, "c", "s1"),
+			},
+			expected: []entities.SegmentDivergence{},
+		},
+		{
+			name: "one data-node differs from the majority",
+			segments: []datanode.NetworkHistorySegment{
+				segment(1000, "a", "s1"), segment(1000, "b", "s1"), segment(1000, "c", "x1"),
+			},
+			expected: []entities.SegmentDivergence{
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "c", Kind: entities.SegmentDivergent, SegmentID: ptr("x1"), MajoritySegmentID: ptr("s1"), MajorityNodes: 2, TotalNodes: 3, DetectedAt: detectedAt},
+			},
+		},
+		{
+			name: "no majority",
+			segments: []datanode.NetworkHistorySegment{
+				segment(1000, "a", "s1"), segment(1000, "b", "x1"),
+			},
+			expected: []entities.SegmentDivergence{
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "a", Kind: entities.SegmentNoConsensus, SegmentID: ptr("s1"), MajorityNodes: 1, TotalNodes: 2, DetectedAt: detectedAt},
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "b", Kind: entities.SegmentNoConsensus, SegmentID: ptr("x1"), MajorityNodes: 1, TotalNodes: 2, DetectedAt: detectedAt},
+			},
+		},
+		{
+			name: "tie of two data-nodes each",
+			segments: []datanode.NetworkHistorySegment{
+				segment(1000, "a", "s1"), segment(1000, "b", "s1"), segment(1000, "c", "x1"), segment(1000, "d", "x1"),
+			},
+			expected: []entities.SegmentDivergence{
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "a", Kind: entities.SegmentNoConsensus, SegmentID: ptr("s1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "b", Kind: entities.SegmentNoConsensus, SegmentID: ptr("s1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "c", Kind: entities.SegmentNoConsensus, SegmentID: ptr("x1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "d", Kind: entities.SegmentNoConsensus, SegmentID: ptr("x1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+			},
+		},
+		{
+			name: "plurality is not a majority",
+			segments: []datanode.NetworkHistorySegment{
+				segment(1000, "a", "s1"), segment(1000, "b", "s1"), segment(1000, "c", "x1"), segment(1000, "d", "y1"),
+			},
+			expected: []entities.SegmentDivergence{
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "a", Kind: entities.SegmentNoConsensus, SegmentID: ptr("s1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "b", Kind: entities.SegmentNoConsensus, SegmentID: ptr("s1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "c", Kind: entities.SegmentNoConsensus, SegmentID: ptr("x1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+				{VegaTime: blockTime(1000), Height: 1000, DataNode: "d", Kind: entities.SegmentNoConsensus, SegmentID: ptr("y1"), MajorityNodes: 2, TotalNodes: 4, DetectedAt: detectedAt},
+			},
+		},
+		{
+			name: "segment missing between segments of the data-node",
+			segments: []datanode.NetworkHistorySegment{
+				segment(1000, "a", "s1"), segment(1000, "b", "s1"),
+				segment(2000, "a", "s2"),
+				segment(3000, "a", "s3"), segment(3000, "b", "s3"),
+				// c has not produced segments yet at 1000 and 2000, so they are not missing
+				segment(4000, "a", "s4"), segment(4000, "b", "s4"), segment(4000, "c", "s4"),
+			},
+			expected: []entities.SegmentDivergence{
+				{VegaTime: blockTime(2000), Height: 2000, DataNode: "b", Kind: entities.SegmentMissing, MajoritySegmentID: ptr("s2"), MajorityNodes: 1, TotalNodes: 1, DetectedAt: detectedAt},
+			},
+		},
+		{
+			name: "only the given data-nodes are compared",
+			segments: []datanode.NetworkHistorySegment{
+				segment(1000, "a", "s1"), segment(1000, "b", "s1"), segment(1000, "removed", "x1"),
+			},
+			dataNodes: []string{"a", "b"},
+			expected:  []entities.SegmentDivergence{},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, computeSegmentDivergences(tc.segments, tc.dataNodes, detectedAt))
+		})
+	}
+}
@@ -1,19 +1,48 @@
 package update
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+
 	"code.vegaprotocol.io/vega/logging"
+	"github.com/vegaprotocol/vega-monitoring/clients/datanode"
 	"github.com/vegaprotocol/vega-monitoring/services"
 	"github.com/vegaprotocol/vega-monitoring/services/read"
 )
 
 const UpdaterType = "updater"
 
+// ProgressFunc is called after each processed batch with the number of processed and total units of work,
+// e.g. blocks or data-nodes.
+type ProgressFunc func(processed int64, total int64)
+
 type UpdateService struct {
 	readService  *read.ReadService
 	storeService *services.StoreService
 	log          *logging.Logger
 
 	latestSegmentsCache map[string]int64 // map[data-node-url]block-height // TODO: Make this struct or something...
+	dataNodeClientsMut  sync.Mutex
+	dataNodeClients     map[string]*datanode.DataNodeClient // map[data-node-url]client
+
+	signingReporter         ValidatorSigningReporter
+	signingTracker          *signingTracker
+	validatorNamesMut       sync.Mutex
+	validatorNamesCache     map[string]string // map[tendermint-pub-key]node-name
+	validatorNamesUpdatedAt time.Time
+
+	proposerFairnessReporter   ProposerFairnessReporter
+	validatorSetReporter       ValidatorSetReporter
+	txFailureReporter          TxFailureReporter
+	txFailuresEnabled          atomic.Bool
+	validatorHeartbeatReporter ValidatorHeartbeatReporter
+	blocksStatsReporter        BlocksStatsReporter
+	segmentDivergenceReporter  SegmentDivergenceReporter
+	segmentsFetchReporter      NetworkHistorySegmentsFetchReporter
+
+	bridgeReconciliationReporter BridgeReconciliationReporter
+	assetPoolBalanceReporter     AssetPoolBalanceReporter
 }
 
 func NewUpdateService(
@@ -0,0 +1,99 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+	"github.com/vegaprotocol/vega-monitoring/sqlstore"
+)
+
+// TxFailureReporter receives the failed txs found by the live comet txs updater. Backfilled blocks are not reported.
+type TxFailureReporter interface {
+	ObserveTxFailures(failures []entities.TxFailure)
+}
+
+// WithTxFailureReporter reports the failed txs of every new block processed by the comet txs updater.
+func (us *UpdateService) WithTxFailureReporter(reporter TxFailureReporter) *UpdateService {
+	us.txFailureReporter = reporter
+
+	return us
+}
+
+// SetTxFailuresEnabled sets whether the comet txs updater stores and reports the failed txs. It is safe to call
+// while the updater runs, so the setting can be reloaded.
+func (us *UpdateService) SetTxFailuresEnabled(enabled bool) {
+	us.txFailuresEnabled.Store(enabled)
+}
+
+// addTxFailures classifies the txs with a non-zero result code and adds them to the store, without flushing it.
+// All commands are added, regardless of the comet txs filter.
+func addTxFailures(txs []comet.CometTx, failuresStore *sqlstore.TxFailures) []entities.TxFailure {
+	failures := txFailures(txs)
+	for _, failure := range failures {
+		failuresStore.Add(failure)
+	}
+
+	return failures
+}
+
+// CheckValidatorTxFailures returns an error with the unhealthy reason, when validators have more rejected
+// validator commands within the window than the threshold allows.
+func (us *UpdateService) CheckValidatorTxFailures(ctx context.Context, window time.Duration, maxFailures int64) error {
+	counts, err := us.storeService.NewTxFailures().GetCountsSince(ctx, time.Now().Add(-window), entities.ValidatorCommands)
+	if err != nil {
+		return err
+	}
+
+	var total int64
+	details := []string{}
+	for _, count := range counts {
+		total += count.Failures
+		details = append(details, fmt.Sprintf("%s: %d x %s", count.Submitter, count.Failures, count.Command))
+	}
+
+	if total > maxFailures {
+		return &ValidatorTxsRejectedError{
+			Failures: total,
+			Window:   window,
+			Details:  details,
+		}
+	}
+
+	return nil
+}
+
+// ValidatorTxsRejectedError is returned when the number of rejected validator commands exceeds the threshold.
+type ValidatorTxsRejectedError struct {
+	Failures int64
+	Window   time.Duration
+	Details  []string
+}
+
+func (e *ValidatorTxsRejectedError) Error() string {
+	return fmt.Sprintf("%d validator txs rejected in the last %s: %s", e.Failures, e.Window, strings.Join(e.Details, ", "))
+}
+
+func txFailures(txs []comet.CometTx) []entities.TxFailure {
+	failures := []entities.TxFailure{}
+	for _, tx := range txs {
+		category := comet.ClassifyTxError(tx.Code, tx.Info)
+		if category == comet.TxErrorNone {
+			continue
+		}
+		failures = append(failures, entities.TxFailure{
+			Height:    tx.Height,
+			HeightIdx: tx.HeightIdx,
+			Code:      tx.Code,
+			Submitter: tx.Submitter,
+			Command:   tx.Command,
+			Category:  string(category),
+			Info:      tx.Info,
+		})
+	}
+
+	return failures
+}
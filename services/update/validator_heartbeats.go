@@ -0,0 +1,168 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// ValidatorHeartbeatReporter receives the heartbeat cadence of the validators after every check.
+type ValidatorHeartbeatReporter interface {
+	UpdateValidatorHeartbeats(heartbeats []entities.ValidatorHeartbeat)
+}
+
+// WithValidatorHeartbeatReporter reports the heartbeat cadence of the validators after every check.
+func (us *UpdateService) WithValidatorHeartbeatReporter(reporter ValidatorHeartbeatReporter) *UpdateService {
+	us.validatorHeartbeatReporter = reporter
+
+	return us
+}
+
+// UpdateValidatorHeartbeats derives the heartbeat cadence of every validator from the Validator Heartbeat comet txs
+// in the window, and stores it. The window ends at the latest stored comet tx, so a delayed comet txs update does
+// not make the heartbeats late. It returns an error with the unhealthy reason, when any validator has not sent
+// a successful heartbeat for longer than maxSinceLast.
+func (us *UpdateService) UpdateValidatorHeartbeats(ctx context.Context, window time.Duration, maxSinceLast time.Duration) error {
+	heartbeatsStore := us.storeService.NewValidatorHeartbeats()
+
+	checkedAt, err := heartbeatsStore.GetLatestTxTime(ctx)
+	if err != nil {
+		return err
+	}
+	if checkedAt == nil {
+		return nil
+	}
+
+	txs, err := heartbeatsStore.GetHeartbeatTxs(ctx, checkedAt.Add(-window), *checkedAt)
+	if err != nil {
+		return err
+	}
+	validators, err := heartbeatsStore.GetValidators(ctx)
+	if err != nil {
+		return err
+	}
+
+	heartbeats := computeValidatorHeartbeats(*checkedAt, validators, txs, maxSinceLast)
+	if err := heartbeatsStore.Upsert(ctx, heartbeats); err != nil {
+		return fmt.Errorf("failed to store validator heartbeats: %w", err)
+	}
+
+	if us.validatorHeartbeatReporter != nil {
+		us.validatorHeartbeatReporter.UpdateValidatorHeartbeats(heartbeats)
+	}
+
+	late := []string{}
+	for _, heartbeat := range heartbeats {
+		if !heartbeat.Status.IsAlerting() {
+			continue
+		}
+		name := heartbeat.Submitter
+		if heartbeat.Name != nil {
+			name = *heartbeat.Name
+		}
+		late = append(late, fmt.Sprintf("%s: %s", name, heartbeat.Status))
+	}
+	if len(late) > 0 {
+		return &ValidatorHeartbeatsLateError{MaxSinceLast: maxSinceLast, Validators: late}
+	}
+
+	return nil
+}
+
+// ValidatorHeartbeatsLateError is returned when validators have not sent a successful heartbeat in time.
+type ValidatorHeartbeatsLateError struct {
+	MaxSinceLast time.Duration
+	Validators   []string
+}
+
+func (e *ValidatorHeartbeatsLateError) Error() string {
+	return fmt.Sprintf(
+		"%d validators without a successful heartbeat in the last %s: %s",
+		len(e.Validators), e.MaxSinceLast, strings.Join(e.Validators, ", "),
+	)
+}
+
+// computeValidatorHeartbeats returns the heartbeat cadence of the validators and of other submitters of heartbeats.
+// Only the validators can be LATE or MISSING, the other submitters are NOT_VALIDATOR. txs must be ordered by time.
+func computeValidatorHeartbeats(
+	checkedAt time.Time,
+	validators []entities.HeartbeatValidator,
+	txs []entities.HeartbeatTx,
+	maxSinceLast time.Duration,
+) []entities.ValidatorHeartbeat {
+	bySubmitter := map[string]*entities.ValidatorHeartbeat{}
+	order := []string{}
+	add := func(submitter string, name *string) *entities.ValidatorHeartbeat {
+		if heartbeat, ok := bySubmitter[submitter]; ok {
+			return heartbeat
+		}
+		heartbeat := &entities.ValidatorHeartbeat{CheckedAt: checkedAt, Submitter: submitter, Name: name}
+		bySubmitter[submitter] = heartbeat
+		order = append(order, submitter)
+		return heartbeat
+	}
+	isValidator := make(map[string]bool, len(validators))
+	for _, validator := range validators {
+		add(validator.Submitter, validator.Name)
+		isValidator[validator.Submitter] = true
+	}
+
+	lastFailed := map[string]bool{}
+	intervalsSum := map[string]float64{}
+	for _, tx := range txs {
+		heartbeat := add(tx.Submitter, nil)
+		if tx.Code != 0 {
+			code := tx.Code
+			heartbeat.FailedHeartbeats++
+			heartbeat.LastFailedCode = &code
+			lastFailed[tx.Submitter] = true
+			continue
+		}
+		lastFailed[tx.Submitter] = false
+
+		if heartbeat.LastHeartbeatAt != nil {
+			interval := tx.VegaTime.Sub(*heartbeat.LastHeartbeatAt).Seconds()
+			intervalsSum[tx.Submitter] += interval
+			if heartbeat.MaxIntervalSeconds == nil || interval > *heartbeat.MaxIntervalSeconds {
+				heartbeat.MaxIntervalSeconds = &interval
+			}
+		}
+		heartbeat.Heartbeats++
+		vegaTime, height := tx.VegaTime, tx.Height
+		heartbeat.LastHeartbeatAt = &vegaTime
+		heartbeat.LastHeartbeatHeight = &height
+	}
+
+	result := make([]entities.ValidatorHeartbeat, 0, len(order))
+	for _, submitter := range order {
+		heartbeat := bySubmitter[submitter]
+		if heartbeat.Heartbeats > 1 {
+			avg := intervalsSum[submitter] / float64(heartbeat.Heartbeats-1)
+			heartbeat.AvgIntervalSeconds = &avg
+		}
+
+		switch {
+		case !isValidator[submitter]:
+			heartbeat.Status = entities.HeartbeatNotValidator
+		case heartbeat.LastHeartbeatAt == nil:
+			heartbeat.Status = entities.HeartbeatMissing
+		case checkedAt.Sub(*heartbeat.LastHeartbeatAt) > maxSinceLast:
+			heartbeat.Status = entities.HeartbeatLate
+		case lastFailed[submitter]:
+			heartbeat.Status = entities.HeartbeatFailing
+		default:
+			heartbeat.Status = entities.HeartbeatOK
+		}
+		if heartbeat.LastHeartbeatAt != nil {
+			sinceLast := checkedAt.Sub(*heartbeat.LastHeartbeatAt).Seconds()
+			heartbeat.SinceLastSeconds = &sinceLast
+		}
+
+		result = append(result, *heartbeat)
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+package update
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+func TestComputeValidatorHeartbeats(t *testing.T) {
+	checkedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutesAgo int) time.Time { return checkedAt.Add(-time.Duration(minutesAgo) * time.Minute) }
+	validators := []entities.HeartbeatValidator{{Submitter: "a"}, {Submitter: "b"}, {Submitter: "c"}, {Submitter: "d"}}
+
+	txs := []entities.HeartbeatTx{
+		{VegaTime: at(55), Height: 1, Submitter: "e"},
+		{VegaTime: at(50), Height: 2, Submitter: "a"},
+		{VegaTime: at(50), Height: 2, Submitter: "b"},
+		{VegaTime: at(40), Height: 3, Submitter: "a"},
+		{VegaTime: at(30), Height: 4, Submitter: "c"},
+		{VegaTime: at(20), Height: 5, Submitter: "a"},
+		{VegaTime: at(10), Height: 6, Submitter: "c", Code: 89},
+		{VegaTime: at(5), Height: 7, Submitter: "f", Code: 89},
+	}
+
+	testScenarios := []struct {
+		submitter   string
+		status      entities.HeartbeatStatus
+		heartbeats  int64
+		failed      int64
+		avgInterval *float64
+		maxInterval *float64
+		sinceLast   *float64
+	}{
+		{submitter: "a", status: entities.HeartbeatOK, heartbeats: 3, avgInterval: ptr(900.0), maxInterval: ptr(1200.0), sinceLast: ptr(1200.0)},
+		{submitter: "b", status: entities.HeartbeatLate, heartbeats: 1, sinceLast: ptr(3000.0)},
+		{submitter: "c", status: entities.HeartbeatFailing, heartbeats: 1, failed: 1, sinceLast: ptr(1800.0)},
+		{submitter: "d", status: entities.HeartbeatMissing},
+		{submitter: "e", status: entities.HeartbeatNotValidator, heartbeats: 1, sinceLast: ptr(3300.0)},
+		{submitter: "f", status: entities.HeartbeatNotValidator, failed: 1},
+	}
+
+	results := computeValidatorHeartbeats(checkedAt, validators, txs, 45*time.Minute)
+	assert.Len(t, results, len(testScenarios))
+	for idx, tc := range testScenarios {
+		t.Run(tc.submitter, func(t *testing.T) {
+			result := results[idx]
+			assert.Equal(t, tc.submitter, result.Submitter)
+			assert.Equal(t, tc.status, result.Status)
+			assert.Equal(t, tc.status == entities.HeartbeatLate || tc.status == entities.HeartbeatMissing, result.Status.IsAlerting())
+			assert.Equal(t, tc.heartbeats, result.Heartbeats)
+			assert.Equal(t, tc.failed, result.FailedHeartbeats)
+			assert.Equal(t, tc.avgInterval, result.AvgIntervalSeconds)
+			assert.Equal(t, tc.maxInterval, result.MaxIntervalSeconds)
+			assert.Equal(t, tc.sinceLast, result.SinceLastSeconds)
+			assert.Equal(t, checkedAt, result.CheckedAt)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,227 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// ValidatorSetReporter receives the validator set after every update of the validator set history,
+// and the changes found in the update. names is map[tendermint-pub-key]node-name.
+type ValidatorSetReporter interface {
+	UpdateValidatorSet(height int64, members []entities.ValidatorSetMember, names map[string]string)
+	ObserveValidatorSetChanges(changes []entities.ValidatorSetChange, names map[string]string)
+}
+
+// WithValidatorSetReporter reports the current validator set and its changes after every validator set update.
+func (us *UpdateService) WithValidatorSetReporter(reporter ValidatorSetReporter) *UpdateService {
+	us.validatorSetReporter = reporter
+
+	return us
+}
+
+// UpdateValidatorSet checks the CometBFT validator set after the last processed height, up to maxBlocks heights,
+// and stores the validators, which joined, left or changed the voting power. Vega changes the validator set only
+// at the epoch boundaries, so the set is read only where the updates of the epochs are applied, and at the last
+// height of the range, which catches any other change. The first run stores the current validator set as
+// a snapshot.
+func (us *UpdateService) UpdateValidatorSet(ctx context.Context, maxBlocks int64) error {
+	logger := us.log.With(zap.String(UpdaterType, "UpdateValidatorSet"))
+	validatorSetStore := us.storeService.NewValidatorSet()
+
+	latestCometBlock, err := us.readService.GetNetworkLatestBlockHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the latest comet block: %w", err)
+	}
+	latestDataNodeBlock, err := us.storeService.NewBlocks().GetLastBlockHeight(ctx)
+	if err != nil {
+		return err
+	}
+	// Changes are stored with the time of the block from the data-node
+	latestBlock := min(latestCometBlock, *latestDataNodeBlock)
+
+	lastHeight, err := validatorSetStore.GetLastProcessedHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	members := map[string]entities.ValidatorSetMember{}
+	if lastHeight > 0 {
+		current, err := validatorSetStore.GetAtHeight(ctx, lastHeight)
+		if err != nil {
+			return err
+		}
+		for _, member := range current {
+			members[member.TmPubKey.String()] = member
+		}
+	}
+
+	fromBlock := lastHeight + 1
+	if lastHeight <= 0 {
+		fromBlock = latestBlock
+	}
+	toBlock := min(latestBlock, fromBlock+maxBlocks-1)
+	if fromBlock > toBlock {
+		logger.Debug("No new blocks", zap.Int64("last-processed-block", lastHeight))
+		return nil
+	}
+
+	var (
+		changes   []entities.ValidatorSetChange
+		processed = lastHeight
+		fetchErr  error
+	)
+	heights, err := us.validatorSetHeights(ctx, fromBlock, toBlock)
+	if err != nil {
+		return err
+	}
+	for _, height := range heights {
+		validators, err := us.readService.GetValidatorsForBlock(ctx, height)
+		if err != nil {
+			fetchErr = fmt.Errorf("failed to get validators for block %d: %w", height, err)
+			break
+		}
+
+		heightChanges := diffValidatorSet(height, members, validators, lastHeight <= 0)
+		applyValidatorSetChanges(members, heightChanges)
+		changes = append(changes, heightChanges...)
+		processed = height
+	}
+
+	if processed > lastHeight {
+		if err := validatorSetStore.AddChanges(ctx, changes, processed); err != nil {
+			return err
+		}
+		logger.Debug("Checked validator set", zap.Int64("from-block", fromBlock), zap.Int64("to-block", processed), zap.Int("changes", len(changes)))
+	}
+
+	names := us.validatorNames(ctx)
+	for _, change := range changes {
+		if change.Change == entities.ValidatorSetSnapshot {
+			continue
+		}
+		logger.Info("Validator set changed",
+			zap.Int64("height", change.Height),
+			zap.String("change", string(change.Change)),
+			zap.String("tm-pub-key", change.TmPubKey.String()),
+			zap.String("name", names[change.TmPubKey.String()]),
+			zap.Int64("voting-power", change.VotingPower),
+			zap.Int64("previous-voting-power", change.PreviousVotingPower),
+		)
+	}
+
+	if us.validatorSetReporter != nil && processed > lastHeight {
+		current := make([]entities.ValidatorSetMember, 0, len(members))
+		for _, member := range members {
+			current = append(current, member)
+		}
+		sort.Slice(current, func(i, j int) bool {
+			return current[i].TmPubKey < current[j].TmPubKey
+		})
+		us.validatorSetReporter.ObserveValidatorSetChanges(changes, names)
+		us.validatorSetReporter.UpdateValidatorSet(processed, current, names)
+	}
+
+	return fetchErr
+}
+
+// validatorSetHeights returns the heights between fromBlock and toBlock, both inclusive, where the validator set
+// can change, i.e. where CometBFT applies the validator updates of the epochs starting in the range, and toBlock.
+func (us *UpdateService) validatorSetHeights(ctx context.Context, fromBlock int64, toBlock int64) ([]int64, error) {
+	firstBlocks, err := us.storeService.NewValidatorSet().GetEpochFirstBlocks(
+		ctx, fromBlock-validatorUpdateDelay, toBlock-validatorUpdateDelay,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	heights := make([]int64, 0, len(firstBlocks)+1)
+	for _, firstBlock := range firstBlocks {
+		heights = append(heights, firstBlock+validatorUpdateDelay)
+	}
+	if len(heights) < 1 || heights[len(heights)-1] != toBlock {
+		heights = append(heights, toBlock)
+	}
+
+	return heights, nil
+}
+
+// diffValidatorSet returns the changes of the validator set at the height compared to the previous members.
+// previous is map[tendermint-pub-key]member. If snapshot is true, all validators are returned as a snapshot.
+func diffValidatorSet(
+	height int64,
+	previous map[string]entities.ValidatorSetMember,
+	validators []comet.CometValidators,
+	snapshot bool,
+) []entities.ValidatorSetChange {
+	changes := []entities.ValidatorSetChange{}
+	seen := make(map[string]struct{}, len(validators))
+
+	for _, validator := range validators {
+		tmPubKey := validator.TmPubKey.String()
+		seen[tmPubKey] = struct{}{}
+
+		change := entities.ValidatorSetChange{
+			Height:           height,
+			TmPubKey:         validator.TmPubKey,
+			Address:          validator.Address,
+			VotingPower:      validator.VotingPower,
+			ProposerPriority: validator.ProposerPriority,
+		}
+		member, ok := previous[tmPubKey]
+		switch {
+		case snapshot:
+			change.Change = entities.ValidatorSetSnapshot
+		case !ok:
+			change.Change = entities.ValidatorJoined
+		case member.VotingPower != validator.VotingPower:
+			change.Change = entities.ValidatorVotingPowerChanged
+			change.PreviousVotingPower = member.VotingPower
+		default:
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	if !snapshot {
+		for tmPubKey, member := range previous {
+			if _, ok := seen[tmPubKey]; ok {
+				continue
+			}
+			changes = append(changes, entities.ValidatorSetChange{
+				Height:              height,
+				TmPubKey:            member.TmPubKey,
+				Address:             member.Address,
+				Change:              entities.ValidatorLeft,
+				PreviousVotingPower: member.VotingPower,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].TmPubKey < changes[j].TmPubKey
+	})
+
+	return changes
+}
+
+// applyValidatorSetChanges updates members, map[tendermint-pub-key]member, with the changes.
+func applyValidatorSetChanges(members map[string]entities.ValidatorSetMember, changes []entities.ValidatorSetChange) {
+	for _, change := range changes {
+		tmPubKey := change.TmPubKey.String()
+		if change.Change == entities.ValidatorLeft {
+			delete(members, tmPubKey)
+			continue
+		}
+		members[tmPubKey] = entities.ValidatorSetMember{
+			TmPubKey:    change.TmPubKey,
+			Address:     change.Address,
+			VotingPower: change.VotingPower,
+		}
+	}
+}
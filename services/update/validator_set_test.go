@@ -0,0 +1,60 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+func TestDiffValidatorSet(t *testing.T) {
+	previous := map[string]entities.ValidatorSetMember{
+		"a": {TmPubKey: "a", Address: "A", VotingPower: 10},
+		"b": {TmPubKey: "b", Address: "B", VotingPower: 20},
+	}
+
+	testScenarios := []struct {
+		name       string
+		validators []comet.CometValidators
+		snapshot   bool
+		result     []entities.ValidatorSetChange
+	}{
+		{
+			name: "no changes",
+			validators: []comet.CometValidators{
+				{TmPubKey: "a", Address: "A", VotingPower: 10, ProposerPriority: 5},
+				{TmPubKey: "b", Address: "B", VotingPower: 20, ProposerPriority: -5},
+			},
+			result: []entities.ValidatorSetChange{},
+		},
+		{
+			name: "joined, left and voting power changed",
+			validators: []comet.CometValidators{
+				{TmPubKey: "a", Address: "A", VotingPower: 15, ProposerPriority: 1},
+				{TmPubKey: "c", Address: "C", VotingPower: 30, ProposerPriority: 2},
+			},
+			result: []entities.ValidatorSetChange{
+				{Height: 7, TmPubKey: "a", Address: "A", Change: entities.ValidatorVotingPowerChanged, VotingPower: 15, PreviousVotingPower: 10, ProposerPriority: 1},
+				{Height: 7, TmPubKey: "b", Address: "B", Change: entities.ValidatorLeft, PreviousVotingPower: 20},
+				{Height: 7, TmPubKey: "c", Address: "C", Change: entities.ValidatorJoined, VotingPower: 30, ProposerPriority: 2},
+			},
+		},
+		{
+			name: "snapshot",
+			validators: []comet.CometValidators{
+				{TmPubKey: "c", Address: "C", VotingPower: 30},
+			},
+			snapshot: true,
+			result: []entities.ValidatorSetChange{
+				{Height: 7, TmPubKey: "c", Address: "C", Change: entities.ValidatorSetSnapshot, VotingPower: 30},
+			},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.result, diffValidatorSet(7, previous, tc.validators, tc.snapshot))
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package update
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/vegaprotocol/vega-monitoring/clients/comet"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+const (
+	// defaultSigningWindow is the number of the latest blocks used to calculate the signed ratio of validators
+	defaultSigningWindow int64 = 100
+	validatorNamesTTL          = 10 * time.Minute
+)
+
+// ValidatorSigningReporter receives the signing status of the active validators after every update of block signers,
+// and the votes of validators in the latest blocks.
+type ValidatorSigningReporter interface {
+	UpdateValidatorSigning(statuses []entities.ValidatorSigningStatus)
+	// ObserveBlockVotes is called once for every block. names is map[tendermint-pub-key]node-name.
+	ObserveBlockVotes(votes []entities.BlockVote, names map[string]string)
+}
+
+// BlockSignedFunc is called for every processed block with the votes of validators for the block, after
+// the block signers and votes have been committed.
+type BlockSignedFunc func(ctx context.Context, block comet.BlockSignersData, votes []entities.BlockVote) error
+
+// WithSigningReporter enables tracking of missed blocks and votes of validators in the block signers updater.
+func (us *UpdateService) WithSigningReporter(reporter ValidatorSigningReporter) *UpdateService {
+	us.signingReporter = reporter
+	us.signingTracker = newSigningTracker(defaultSigningWindow)
+
+	return us
+}
+
+// trackSigning returns a BlockSignedFunc, which tracks the blocks signed by validators in the active validator set
+// at each height, and reports the votes. Only the last blocks of the signing window up to toBlock are tracked.
+func (us *UpdateService) trackSigning(toBlock int64) BlockSignedFunc {
+	return func(ctx context.Context, block comet.BlockSignersData, votes []entities.BlockVote) error {
+		height := block.Height
+		if !us.signingTracker.shouldTrack(height, toBlock) {
+			return nil
+		}
+
+		validators, err := us.readService.GetValidatorsForBlock(ctx, height)
+		if err != nil {
+			return fmt.Errorf("failed to get validators for block %d: %w", height, err)
+		}
+
+		signers := make(map[string]struct{}, len(block.SignerAddresses))
+		for _, address := range block.SignerAddresses {
+			signers[address] = struct{}{}
+		}
+
+		activeSet := make(map[string]bool, len(validators))
+		for _, validator := range validators {
+			_, signed := signers[validator.Address]
+			activeSet[validator.TmPubKey.String()] = signed
+		}
+
+		us.signingTracker.add(height, activeSet)
+		us.signingReporter.ObserveBlockVotes(votes, us.validatorNames(ctx))
+
+		return nil
+	}
+}
+
+func (us *UpdateService) reportSigning(ctx context.Context) {
+	if us.signingReporter == nil {
+		return
+	}
+
+	us.signingReporter.UpdateValidatorSigning(us.signingTracker.statuses(us.validatorNames(ctx)))
+}
+
+// validatorNames returns map[tendermint-pub-key]node-name. Names are refreshed every validatorNamesTTL,
+// and the previous names are used if the refresh fails.
+func (us *UpdateService) validatorNames(ctx context.Context) map[string]string {
+	us.validatorNamesMut.Lock()
+	defer us.validatorNamesMut.Unlock()
+
+	if us.validatorNamesCache != nil && time.Since(us.validatorNamesUpdatedAt) < validatorNamesTTL {
+		return us.validatorNamesCache
+	}
+
+	nodes, err := us.storeService.NewValidatorNodes().GetAll(ctx)
+	if err != nil {
+		us.log.Warn("Failed to refresh validator names", zap.Error(err))
+		return us.validatorNamesCache
+	}
+
+	names := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		names[node.TmPubKey.String()] = node.Name
+	}
+	us.validatorNamesCache = names
+	us.validatorNamesUpdatedAt = time.Now()
+
+	return names
+}
+
+type validatorSigning struct {
+	// signed is a ring buffer with the signing results of the last blocks in the window
+	signed           []bool
+	next             int
+	missedStreak     int64
+	lastSignedHeight int64
+	lastSeenHeight   int64
+}
+
+type signingTracker struct {
+	mut        sync.Mutex
+	window     int64
+	lastHeight int64
+	validators map[string]*validatorSigning // map[tendermint-pub-key]
+}
+
+func newSigningTracker(window int64) *signingTracker {
+	return &signingTracker{
+		window:     window,
+		validators: map[string]*validatorSigning{},
+	}
+}
+
+func (t *signingTracker) shouldTrack(height int64, toBlock int64) bool {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	return height > t.lastHeight && height > toBlock-t.window
+}
+
+// add records the signing result at the given height for each validator in the active set.
+// activeSet is map[tendermint-pub-key]signed.
+func (t *signingTracker) add(height int64, activeSet map[string]bool) {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	if height <= t.lastHeight {
+		return
+	}
+	// Blocks have not been tracked for longer than the window, so start from scratch
+	if height-t.lastHeight > t.window {
+		t.validators = map[string]*validatorSigning{}
+	}
+	t.lastHeight = height
+
+	for tmPubKey, signed := range activeSet {
+		validator, ok := t.validators[tmPubKey]
+		if !ok {
+			validator = &validatorSigning{}
+			t.validators[tmPubKey] = validator
+		}
+
+		if int64(len(validator.signed)) < t.window {
+			validator.signed = append(validator.signed, signed)
+		} else {
+			validator.signed[validator.next] = signed
+			validator.next = (validator.next + 1) % len(validator.signed)
+		}
+
+		if signed {
+			validator.missedStreak = 0
+			validator.lastSignedHeight = height
+		} else {
+			validator.missedStreak++
+		}
+		validator.lastSeenHeight = height
+	}
+
+	// Forget validators, which have not been in the active set for the whole window
+	for tmPubKey, validator := range t.validators {
+		if height-validator.lastSeenHeight >= t.window {
+			delete(t.validators, tmPubKey)
+		}
+	}
+}
+
+// statuses returns the signing status of validators, which are in the active set at the last tracked height.
+func (t *signingTracker) statuses(names map[string]string) []entities.ValidatorSigningStatus {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+
+	result := []entities.ValidatorSigningStatus{}
+	for tmPubKey, validator := range t.validators {
+		if validator.lastSeenHeight != t.lastHeight {
+			continue
+		}
+
+		status := entities.ValidatorSigningStatus{
+			TmPubKey:           tmPubKey,
+			Name:               names[tmPubKey],
+			MissedBlocksStreak: validator.missedStreak,
+			WindowBlocks:       int64(len(validator.signed)),
+			LastSignedHeight:   validator.lastSignedHeight,
+		}
+		for _, signed := range validator.signed {
+			if signed {
+				status.SignedBlocks++
+			}
+		}
+		result = append(result, status)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TmPubKey < result[j].TmPubKey
+	})
+
+	return result
+}
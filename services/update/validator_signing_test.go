@@ -0,0 +1,81 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+func TestSigningTracker(t *testing.T) {
+	type block struct {
+		height    int64
+		activeSet map[string]bool
+	}
+
+	testScenarios := []struct {
+		name   string
+		window int64
+		blocks []block
+		result []entities.ValidatorSigningStatus
+	}{
+		{
+			name:   "missed blocks streak",
+			window: 10,
+			blocks: []block{
+				{height: 1, activeSet: map[string]bool{"a": true, "b": true}},
+				{height: 2, activeSet: map[string]bool{"a": true, "b": false}},
+				{height: 3, activeSet: map[string]bool{"a": true, "b": false}},
+			},
+			result: []entities.ValidatorSigningStatus{
+				{TmPubKey: "a", Name: "node-a", MissedBlocksStreak: 0, SignedBlocks: 3, WindowBlocks: 3, LastSignedHeight: 3},
+				{TmPubKey: "b", MissedBlocksStreak: 2, SignedBlocks: 1, WindowBlocks: 3, LastSignedHeight: 1},
+			},
+		},
+		{
+			name:   "rolling window",
+			window: 2,
+			blocks: []block{
+				{height: 1, activeSet: map[string]bool{"a": false}},
+				{height: 2, activeSet: map[string]bool{"a": true}},
+				{height: 3, activeSet: map[string]bool{"a": true}},
+			},
+			result: []entities.ValidatorSigningStatus{
+				{TmPubKey: "a", Name: "node-a", MissedBlocksStreak: 0, SignedBlocks: 2, WindowBlocks: 2, LastSignedHeight: 3},
+			},
+		},
+		{
+			name:   "validator left the active set",
+			window: 10,
+			blocks: []block{
+				{height: 1, activeSet: map[string]bool{"a": true, "b": true}},
+				{height: 2, activeSet: map[string]bool{"a": true}},
+			},
+			result: []entities.ValidatorSigningStatus{
+				{TmPubKey: "a", Name: "node-a", MissedBlocksStreak: 0, SignedBlocks: 2, WindowBlocks: 2, LastSignedHeight: 2},
+			},
+		},
+		{
+			name:   "old and repeated blocks are ignored",
+			window: 10,
+			blocks: []block{
+				{height: 5, activeSet: map[string]bool{"a": false}},
+				{height: 4, activeSet: map[string]bool{"a": true}},
+				{height: 5, activeSet: map[string]bool{"a": true}},
+			},
+			result: []entities.ValidatorSigningStatus{
+				{TmPubKey: "a", Name: "node-a", MissedBlocksStreak: 1, SignedBlocks: 0, WindowBlocks: 1, LastSignedHeight: 0},
+			},
+		},
+	}
+
+	for _, tc := range testScenarios {
+		t.Run(tc.name, func(t *testing.T) {
+			tracker := newSigningTracker(tc.window)
+			for _, b := range tc.blocks {
+				tracker.add(b.height, b.activeSet)
+			}
+			assert.Equal(t, tc.result, tracker.statuses(map[string]string{"a": "node-a"}))
+		})
+	}
+}
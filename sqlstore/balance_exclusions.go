@@ -0,0 +1,92 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// activeBalanceExclusionsQuery selects the ids of the active exclusions of the kind given in the first
+// parameter, which apply to the network of the data-node.
+const activeBalanceExclusionsQuery = `
+	SELECT e.id FROM metrics.balance_exclusions e
+	WHERE e.kind = $1
+		AND (e.network = '' OR e.network IN (SELECT id FROM chain))
+		AND (e.expires_at IS NULL OR e.expires_at > NOW())`
+
+type BalanceExclusions struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewBalanceExclusions(connectionSource *vega_sqlstore.ConnectionSource) *BalanceExclusions {
+	return &BalanceExclusions{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// Add stores the exclusion, or replaces the exclusion with the same kind, id and network.
+func (be *BalanceExclusions) Add(ctx context.Context, exclusion entities.BalanceExclusion) error {
+	if err := exclusion.Kind.IsValid(); err != nil {
+		return err
+	}
+
+	if _, err := be.Connection.Exec(ctx, `
+		INSERT INTO metrics.balance_exclusions (
+			kind,
+			id,
+			network,
+			reason,
+			author,
+			expires_at)
+		VALUES ( $1, decode($2, 'hex'), COALESCE($3, ''), $4, $5, $6 )
+		ON CONFLICT (kind, id, network) DO UPDATE
+		SET
+			reason=EXCLUDED.reason,
+			author=EXCLUDED.author,
+			created_at=NOW(),
+			expires_at=EXCLUDED.expires_at`,
+		exclusion.Kind,
+		exclusion.ID,
+		exclusion.Network,
+		exclusion.Reason,
+		exclusion.Author,
+		exclusion.ExpiresAt,
+	); err != nil {
+		return fmt.Errorf("failed to add balance exclusion %s %s: %w", exclusion.Kind, exclusion.ID, err)
+	}
+
+	return nil
+}
+
+// List returns all exclusions, including the expired ones, ordered by the time they were added.
+func (be *BalanceExclusions) List(ctx context.Context) ([]entities.BalanceExclusion, error) {
+	result := []entities.BalanceExclusion{}
+
+	if err := pgxscan.Select(ctx, be.Connection, &result,
+		`SELECT kind, encode(id, 'hex') AS id, NULLIF(network, '') AS network, reason, author, created_at, expires_at
+		FROM metrics.balance_exclusions
+		ORDER BY created_at, kind, id, network`,
+	); err != nil {
+		return nil, fmt.Errorf("failed to list balance exclusions: %w", err)
+	}
+
+	return result, nil
+}
+
+// Remove deletes the exclusion of the network, or the exclusion for all networks when network is nil, and returns
+// false when it does not exist.
+func (be *BalanceExclusions) Remove(ctx context.Context, kind entities.BalanceExclusionKind, id string, network *string) (bool, error) {
+	tag, err := be.Connection.Exec(ctx,
+		`DELETE FROM metrics.balance_exclusions WHERE kind = $1 AND id = decode($2, 'hex') AND network = COALESCE($3, '')`,
+		kind, id, network,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove balance exclusion %s %s: %w", kind, id, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,140 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type BlockGaps struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewBlockGaps(connectionSource *vega_sqlstore.ConnectionSource) *BlockGaps {
+	return &BlockGaps{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// GetBlockSignersGaps returns ranges of blocks between fromHeight and toHeight without the proposer
+// in the metrics.block_signers table. Every block has a proposer, so these blocks have never been processed.
+func (bg *BlockGaps) GetBlockSignersGaps(ctx context.Context, fromHeight int64, toHeight int64) ([]entities.BlockRange, error) {
+	result := []entities.BlockRange{}
+
+	// Consecutive missing heights have the same difference between the height and the row number
+	if err := pgxscan.Select(ctx, bg.Connection, &result,
+		`SELECT MIN(height) AS from_height, MAX(height) AS to_height
+		FROM (
+			SELECT
+				blocks.height,
+				blocks.height - ROW_NUMBER() OVER (ORDER BY blocks.height) AS island
+			FROM blocks
+			WHERE
+				blocks.height BETWEEN $1 AND $2
+				AND NOT EXISTS (
+					SELECT 1 FROM metrics.block_signers
+					WHERE
+						metrics.block_signers.vega_time = blocks.vega_time
+						AND metrics.block_signers.role = 'ROLE_PROPOSER'
+				)
+		) missing
+		GROUP BY island
+		ORDER BY from_height`,
+		fromHeight, toHeight,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get block signers gaps: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetCometTxsGaps returns ranges of blocks between fromHeight and toHeight, which are not covered
+// by any range in the metrics.comet_txs_processed_blocks table.
+func (bg *BlockGaps) GetCometTxsGaps(ctx context.Context, fromHeight int64, toHeight int64) ([]entities.BlockRange, error) {
+	result := []entities.BlockRange{}
+
+	// covered_to is the highest block processed by all the ranges starting before the current one
+	if err := pgxscan.Select(ctx, bg.Connection, &result,
+		`WITH ranges AS (
+			SELECT
+				from_height,
+				to_height,
+				COALESCE(
+					MAX(to_height) OVER (ORDER BY from_height, to_height ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING),
+					$1::BIGINT - 1
+				) AS covered_to
+			FROM metrics.comet_txs_processed_blocks
+			WHERE
+				to_height >= $1::BIGINT
+				AND from_height <= $2::BIGINT
+		)
+		SELECT from_height, to_height FROM (
+			SELECT
+				GREATEST(covered_to + 1, $1::BIGINT) AS from_height,
+				from_height - 1 AS to_height
+			FROM ranges
+			WHERE from_height > GREATEST(covered_to + 1, $1::BIGINT)
+			UNION ALL
+			SELECT
+				GREATEST(MAX(to_height) + 1, $1::BIGINT) AS from_height,
+				$2::BIGINT AS to_height
+			FROM ranges
+			HAVING COALESCE(MAX(to_height), $1::BIGINT - 1) < $2::BIGINT
+		) gaps
+		ORDER BY from_height`,
+		fromHeight, toHeight,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get comet txs gaps: %w", err)
+	}
+
+	return result, nil
+}
+
+func (bg *BlockGaps) UpsertStatus(ctx context.Context, status entities.BlockGapsStatus) error {
+	if _, err := bg.Connection.Exec(ctx, `
+		INSERT INTO metrics.block_gaps (
+			table_name,
+			from_height,
+			to_height,
+			gaps,
+			missing_heights,
+			updated_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (table_name) DO UPDATE
+		SET
+			from_height=EXCLUDED.from_height,
+			to_height=EXCLUDED.to_height,
+			gaps=EXCLUDED.gaps,
+			missing_heights=EXCLUDED.missing_heights,
+			updated_at=EXCLUDED.updated_at`,
+		status.TableName,
+		status.FromHeight,
+		status.ToHeight,
+		status.Gaps,
+		status.MissingHeights,
+		status.UpdatedAt,
+	); err != nil {
+		return fmt.Errorf("failed to upsert block gaps status for %s: %w", status.TableName, err)
+	}
+
+	return nil
+}
+
+func (bg *BlockGaps) GetStatuses(ctx context.Context) ([]entities.BlockGapsStatus, error) {
+	result := []entities.BlockGapsStatus{}
+
+	if err := pgxscan.Select(ctx, bg.Connection, &result,
+		`SELECT table_name, from_height, to_height, gaps, missing_heights, updated_at
+		FROM metrics.block_gaps
+		ORDER BY table_name`,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get block gaps statuses: %w", err)
+	}
+
+	return result, nil
+}
@@ -125,6 +125,28 @@ func (bs *BlockSigner) GetLastestBlockInStore(ctx context.Context) (int64, error
 	return result.Height, nil
 }
 
+// GetEarliestBlockInStore returns the first block with stored signers, or 0 if the table is empty.
+func (bs *BlockSigner) GetEarliestBlockInStore(ctx context.Context) (int64, error) {
+	result := &struct {
+		Height int64 `db:"height"`
+	}{}
+
+	if err := pgxscan.Get(ctx, bs.Connection, result,
+		`SELECT blocks.height
+		FROM metrics.block_signers, blocks
+		WHERE
+			metrics.block_signers.vega_time = blocks.vega_time
+		ORDER BY metrics.block_signers.vega_time ASC
+		LIMIT 1`,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return result.Height, nil
+}
+
 // SELECT s.i AS missing_height
 // FROM generate_series(1,(SELECT MAX(height) FROM m_block_signers)) s(i)
 // LEFT OUTER JOIN m_block_signers ON (m_block_signers.height = s.i)
@@ -0,0 +1,75 @@
+package sqlstore
+
+import (
+	"context"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type BlockVotes struct {
+	*vega_sqlstore.ConnectionSource
+	votes []*entities.BlockVote
+}
+
+func NewBlockVotes(connectionSource *vega_sqlstore.ConnectionSource) *BlockVotes {
+	return &BlockVotes{
+		ConnectionSource: connectionSource,
+	}
+}
+
+func (bv *BlockVotes) Add(data *entities.BlockVote) {
+	bv.votes = append(bv.votes, data)
+}
+
+func (bv *BlockVotes) Upsert(ctx context.Context, vote *entities.BlockVote) error {
+	_, err := bv.Connection.Exec(ctx, `
+		INSERT INTO metrics.block_votes (
+			vega_time,
+			height,
+			round,
+			tendermint_pub_key,
+			vote_time)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (vega_time, tendermint_pub_key) DO UPDATE
+		SET
+			height=EXCLUDED.height,
+			round=EXCLUDED.round,
+			vote_time=EXCLUDED.vote_time`,
+		vote.VegaTime,
+		vote.Height,
+		vote.Round,
+		vote.TmPubKey,
+		vote.VoteTime,
+	)
+
+	return err
+}
+
+func (bv *BlockVotes) FlushUpsert(ctx context.Context) ([]*entities.BlockVote, error) {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		// We cannot keep those rows in memory because they will be added again
+		bv.votes = nil
+	}()
+
+	blockCtx, err := bv.WithTransaction(blockCtx)
+	if err != nil {
+		return nil, NewUpsertErr(StoreBlockVotes, ErrAcquireTx, err)
+	}
+
+	for _, vote := range bv.votes {
+		if err := bv.Upsert(blockCtx, vote); err != nil {
+			return nil, NewUpsertErr(StoreBlockVotes, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := bv.Commit(blockCtx); err != nil {
+		return nil, NewUpsertErr(StoreBlockVotes, ErrUpsertCommit, err)
+	}
+
+	return bv.votes, nil
+}
@@ -0,0 +1,103 @@
+package sqlstore
+
+import (
+	"context"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type BlocksStats struct {
+	*vega_sqlstore.ConnectionSource
+	stats []entities.BlockStats
+}
+
+func NewBlocksStats(connectionSource *vega_sqlstore.ConnectionSource) *BlocksStats {
+	return &BlocksStats{
+		ConnectionSource: connectionSource,
+	}
+}
+
+func (bs *BlocksStats) Add(stats entities.BlockStats) {
+	bs.stats = append(bs.stats, stats)
+}
+
+func (bs *BlocksStats) Upsert(ctx context.Context, stats entities.BlockStats) error {
+	var intervalSeconds *float64
+	if stats.BlockInterval != nil {
+		seconds := stats.BlockInterval.Seconds()
+		intervalSeconds = &seconds
+	}
+
+	_, err := bs.Connection.Exec(ctx, `
+		INSERT INTO metrics.blocks_stats (
+			vega_time,
+			height,
+			block_interval_seconds,
+			proposer,
+			round,
+			size_bytes,
+			tx_count,
+			failed_tx_count,
+			txs_per_command)
+		VALUES
+			(
+				(SELECT vega_time FROM blocks WHERE height = $1),
+				$1,
+				$2,
+				$3,
+				$4,
+				$5,
+				$6,
+				$7,
+				$8
+			)
+		ON CONFLICT (vega_time, height) DO UPDATE
+		SET
+			block_interval_seconds=EXCLUDED.block_interval_seconds,
+			proposer=EXCLUDED.proposer,
+			round=EXCLUDED.round,
+			size_bytes=EXCLUDED.size_bytes,
+			tx_count=EXCLUDED.tx_count,
+			failed_tx_count=EXCLUDED.failed_tx_count,
+			txs_per_command=EXCLUDED.txs_per_command`,
+		stats.Height,
+		intervalSeconds,
+		stats.ProposerTmPubKey,
+		stats.Round,
+		stats.SizeBytes,
+		stats.TxCount,
+		stats.FailedTxCount,
+		stats.TxsPerCommand,
+	)
+
+	return err
+}
+
+// FlushUpsert stores all added blocks in a single transaction.
+func (bs *BlocksStats) FlushUpsert(ctx context.Context) ([]entities.BlockStats, error) {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		// We cannot keep those rows in memory because they will be added again
+		bs.stats = nil
+	}()
+
+	blockCtx, err := bs.WithTransaction(blockCtx)
+	if err != nil {
+		return nil, NewUpsertErr(StoreBlocksStats, ErrAcquireTx, err)
+	}
+
+	for _, stats := range bs.stats {
+		if err := bs.Upsert(blockCtx, stats); err != nil {
+			return nil, NewUpsertErr(StoreBlocksStats, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := bs.Commit(blockCtx); err != nil {
+		return nil, NewUpsertErr(StoreBlocksStats, ErrUpsertCommit, err)
+	}
+
+	return bs.stats, nil
+}
@@ -0,0 +1,102 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type BridgeReconciliation struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewBridgeReconciliation(connectionSource *vega_sqlstore.ConnectionSource) *BridgeReconciliation {
+	return &BridgeReconciliation{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// GetBalancesSince returns the network balances stored after since, with the symbol and decimals of the asset.
+func (br *BridgeReconciliation) GetBalancesSince(ctx context.Context, since time.Time) ([]entities.ReconciliationBalance, error) {
+	result := []entities.ReconciliationBalance{}
+
+	if err := pgxscan.Select(ctx, br.Connection, &result,
+		`SELECT
+			nb.balance_time,
+			encode(nb.asset_id, 'hex') AS asset_id,
+			a.symbol AS asset_symbol,
+			nb.chain_id,
+			nb.balance_source,
+			nb.balance,
+			a.decimals
+		FROM metrics.network_balances nb
+		JOIN assets_current a ON (a.id = nb.asset_id)
+		WHERE nb.balance_time > $1
+		ORDER BY nb.balance_time`,
+		since,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get network balances since %s: %w", since, err)
+	}
+
+	return result, nil
+}
+
+// Upsert stores the reconciliations in a single transaction.
+func (br *BridgeReconciliation) Upsert(ctx context.Context, reconciliations []entities.BridgeReconciliation) error {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blockCtx, err := br.WithTransaction(blockCtx)
+	if err != nil {
+		return NewUpsertErr(StoreBridgeReconciliation, ErrAcquireTx, err)
+	}
+
+	for _, reconciliation := range reconciliations {
+		if _, err := br.Connection.Exec(blockCtx, `
+			INSERT INTO metrics.bridge_reconciliation (
+				balance_time,
+				asset_id,
+				chain_id,
+				asset_pool,
+				parties_total,
+				unrealised_withdrawals,
+				unfinalized_deposits,
+				discrepancy,
+				decimals,
+				within_tolerance)
+			VALUES ( $1, decode($2, 'hex'), $3, $4, $5, $6, $7, $8, $9, $10 )
+			ON CONFLICT (balance_time, asset_id, chain_id) DO UPDATE
+			SET
+				asset_pool=EXCLUDED.asset_pool,
+				parties_total=EXCLUDED.parties_total,
+				unrealised_withdrawals=EXCLUDED.unrealised_withdrawals,
+				unfinalized_deposits=EXCLUDED.unfinalized_deposits,
+				discrepancy=EXCLUDED.discrepancy,
+				decimals=EXCLUDED.decimals,
+				within_tolerance=EXCLUDED.within_tolerance`,
+			reconciliation.BalanceTime,
+			reconciliation.AssetID,
+			reconciliation.ChainID,
+			reconciliation.AssetPool,
+			reconciliation.PartiesTotal,
+			reconciliation.UnrealisedWithdrawals,
+			reconciliation.UnfinalizedDeposits,
+			reconciliation.Discrepancy,
+			reconciliation.Decimals,
+			reconciliation.WithinTolerance,
+		); err != nil {
+			return NewUpsertErr(StoreBridgeReconciliation, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := br.Commit(blockCtx); err != nil {
+		return NewUpsertErr(StoreBridgeReconciliation, ErrUpsertCommit, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package sqlstore
+
+import (
+	"context"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+// CometCommands stores the decoded comet txs in the typed per-command tables.
+type CometCommands struct {
+	*vega_sqlstore.ConnectionSource
+	chainEvents            []entities.ChainEvent
+	nodeVotes              []entities.NodeVote
+	stateVariableProposals []entities.StateVariableProposal
+	issueSignatures        []entities.IssueSignatures
+}
+
+func NewCometCommands(connectionSource *vega_sqlstore.ConnectionSource) *CometCommands {
+	return &CometCommands{
+		ConnectionSource: connectionSource,
+	}
+}
+
+func (c *CometCommands) AddChainEvent(event entities.ChainEvent) {
+	c.chainEvents = append(c.chainEvents, event)
+}
+
+func (c *CometCommands) AddNodeVote(vote entities.NodeVote) {
+	c.nodeVotes = append(c.nodeVotes, vote)
+}
+
+func (c *CometCommands) AddStateVariableProposal(proposal entities.StateVariableProposal) {
+	c.stateVariableProposals = append(c.stateVariableProposals, proposal)
+}
+
+func (c *CometCommands) AddIssueSignatures(signatures entities.IssueSignatures) {
+	c.issueSignatures = append(c.issueSignatures, signatures)
+}
+
+// FlushUpsert stores all added commands in a single transaction, and returns the number of stored rows.
+func (c *CometCommands) FlushUpsert(ctx context.Context) (int, error) {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		c.chainEvents = nil
+		c.nodeVotes = nil
+		c.stateVariableProposals = nil
+		c.issueSignatures = nil
+	}()
+
+	blockCtx, err := c.WithTransaction(blockCtx)
+	if err != nil {
+		return 0, NewUpsertErr(StoreCometCommands, ErrAcquireTx, err)
+	}
+
+	for _, event := range c.chainEvents {
+		if err := c.upsertChainEvent(blockCtx, event); err != nil {
+			return 0, NewUpsertErr(StoreCometCommands, ErrUpsertSingle, err)
+		}
+	}
+	for _, vote := range c.nodeVotes {
+		if err := c.upsertNodeVote(blockCtx, vote); err != nil {
+			return 0, NewUpsertErr(StoreCometCommands, ErrUpsertSingle, err)
+		}
+	}
+	for _, proposal := range c.stateVariableProposals {
+		if err := c.upsertStateVariableProposal(blockCtx, proposal); err != nil {
+			return 0, NewUpsertErr(StoreCometCommands, ErrUpsertSingle, err)
+		}
+	}
+	for _, signatures := range c.issueSignatures {
+		if err := c.upsertIssueSignatures(blockCtx, signatures); err != nil {
+			return 0, NewUpsertErr(StoreCometCommands, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := c.Commit(blockCtx); err != nil {
+		return 0, NewUpsertErr(StoreCometCommands, ErrUpsertCommit, err)
+	}
+
+	return len(c.chainEvents) + len(c.nodeVotes) + len(c.stateVariableProposals) + len(c.issueSignatures), nil
+}
+
+func (c *CometCommands) upsertChainEvent(ctx context.Context, event entities.ChainEvent) error {
+	_, err := c.Connection.Exec(ctx, `
+		INSERT INTO metrics.chain_events (
+			vega_time,
+			height,
+			height_idx,
+			code,
+			submitter,
+			tx_id,
+			nonce,
+			event_type)
+		VALUES
+			(
+				(SELECT vega_time FROM blocks WHERE height = $1),
+				$1,
+				$2,
+				$3,
+				$4,
+				$5,
+				$6::NUMERIC,
+				$7
+			)
+		ON CONFLICT (vega_time, height_idx) DO UPDATE
+		SET
+			height=EXCLUDED.height,
+			code=EXCLUDED.code,
+			submitter=EXCLUDED.submitter,
+			tx_id=EXCLUDED.tx_id,
+			nonce=EXCLUDED.nonce,
+			event_type=EXCLUDED.event_type`,
+		event.Height,
+		event.HeightIdx,
+		event.Code,
+		event.Submitter,
+		event.TxID,
+		event.Nonce,
+		event.EventType,
+	)
+
+	return err
+}
+
+func (c *CometCommands) upsertNodeVote(ctx context.Context, vote entities.NodeVote) error {
+	_, err := c.Connection.Exec(ctx, `
+		INSERT INTO metrics.node_votes (
+			vega_time,
+			height,
+			height_idx,
+			code,
+			submitter,
+			reference,
+			vote_type)
+		VALUES
+			(
+				(SELECT vega_time FROM blocks WHERE height = $1),
+				$1,
+				$2,
+				$3,
+				$4,
+				$5,
+				$6
+			)
+		ON CONFLICT (vega_time, height_idx) DO UPDATE
+		SET
+			height=EXCLUDED.height,
+			code=EXCLUDED.code,
+			submitter=EXCLUDED.submitter,
+			reference=EXCLUDED.reference,
+			vote_type=EXCLUDED.vote_type`,
+		vote.Height,
+		vote.HeightIdx,
+		vote.Code,
+		vote.Submitter,
+		vote.Reference,
+		vote.VoteType,
+	)
+
+	return err
+}
+
+func (c *CometCommands) upsertStateVariableProposal(ctx context.Context, proposal entities.StateVariableProposal) error {
+	_, err := c.Connection.Exec(ctx, `
+		INSERT INTO metrics.state_variable_proposals (
+			vega_time,
+			height,
+			height_idx,
+			code,
+			submitter,
+			state_var_id,
+			event_id,
+			key_value_bundles)
+		VALUES
+			(
+				(SELECT vega_time FROM blocks WHERE height = $1),
+				$1,
+				$2,
+				$3,
+				$4,
+				$5,
+				$6,
+				$7
+			)
+		ON CONFLICT (vega_time, height_idx) DO UPDATE
+		SET
+			height=EXCLUDED.height,
+			code=EXCLUDED.code,
+			submitter=EXCLUDED.submitter,
+			state_var_id=EXCLUDED.state_var_id,
+			event_id=EXCLUDED.event_id,
+			key_value_bundles=EXCLUDED.key_value_bundles`,
+		proposal.Height,
+		proposal.HeightIdx,
+		proposal.Code,
+		proposal.Submitter,
+		proposal.StateVarID,
+		proposal.EventID,
+		proposal.KeyValueBundles,
+	)
+
+	return err
+}
+
+func (c *CometCommands) upsertIssueSignatures(ctx context.Context, signatures entities.IssueSignatures) error {
+	_, err := c.Connection.Exec(ctx, `
+		INSERT INTO metrics.issue_signatures (
+			vega_time,
+			height,
+			height_idx,
+			code,
+			submitter,
+			signatures_submitter,
+			kind,
+			validator_node_id)
+		VALUES
+			(
+				(SELECT vega_time FROM blocks WHERE height = $1),
+				$1,
+				$2,
+				$3,
+				$4,
+				$5,
+				$6,
+				$7
+			)
+		ON CONFLICT (vega_time, height_idx) DO UPDATE
+		SET
+			height=EXCLUDED.height,
+			code=EXCLUDED.code,
+			submitter=EXCLUDED.submitter,
+			signatures_submitter=EXCLUDED.signatures_submitter,
+			kind=EXCLUDED.kind,
+			validator_node_id=EXCLUDED.validator_node_id`,
+		signatures.Height,
+		signatures.HeightIdx,
+		signatures.Code,
+		signatures.Submitter,
+		signatures.SignaturesSubmitter,
+		signatures.Kind,
+		signatures.ValidatorNodeID,
+	)
+
+	return err
+}
@@ -2,7 +2,9 @@ package sqlstore
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
 	"github.com/georgysavva/scany/pgxscan"
@@ -98,14 +100,17 @@ func (c *CometTxs) FlushUpsertWithoutTime(ctx context.Context) ([]comet.CometTx,
 	return flushed, nil
 }
 
+// GetLatestBlockInStore returns the last processed block, including the blocks without any stored tx.
 func (c *CometTxs) GetLatestBlockInStore(ctx context.Context) (int64, error) {
 	result := &struct {
 		Height *int64 `db:"height"`
 	}{}
 
 	if err := pgxscan.Get(ctx, c.Connection, result,
-		`SELECT MAX(height) as height
-		FROM metrics.comet_txs`,
+		`SELECT GREATEST(
+			(SELECT MAX(height) FROM metrics.comet_txs),
+			(SELECT MAX(to_height) FROM metrics.comet_txs_processed_blocks)
+		) as height`,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return 0, nil
@@ -118,3 +123,86 @@ func (c *CometTxs) GetLatestBlockInStore(ctx context.Context) (int64, error) {
 
 	return *result.Height, nil
 }
+
+// GetEarliestProcessedBlock returns the first processed block, or 0 if no block has been processed yet.
+func (c *CometTxs) GetEarliestProcessedBlock(ctx context.Context) (int64, error) {
+	result := &struct {
+		Height *int64 `db:"height"`
+	}{}
+
+	if err := pgxscan.Get(ctx, c.Connection, result,
+		`SELECT MIN(from_height) as height
+		FROM metrics.comet_txs_processed_blocks`,
+	); err != nil {
+		return 0, err
+	}
+	if result.Height == nil {
+		return 0, nil
+	}
+
+	return *result.Height, nil
+}
+
+// AddProcessedBlocks marks the range of blocks as processed with the filter, so it is not reported as a gap.
+func (c *CometTxs) AddProcessedBlocks(ctx context.Context, fromHeight int64, toHeight int64, filterID int32) error {
+	_, err := c.Connection.Exec(ctx, `
+		INSERT INTO metrics.comet_txs_processed_blocks (from_height, to_height, filter_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (from_height, to_height) DO UPDATE
+		SET
+			processed_at=NOW(),
+			filter_id=EXCLUDED.filter_id`,
+		fromHeight,
+		toHeight,
+		filterID,
+	)
+
+	return err
+}
+
+// AddFilter stores the filter, unless a filter with the same hash is already stored, and returns its id.
+// created is true when the filter has not been used before.
+func (c *CometTxs) AddFilter(ctx context.Context, filter *comet.TxFilter) (id int32, created bool, err error) {
+	cfg := filter.Config()
+	include, exclude := cfg.Include, cfg.Exclude
+	if include == nil {
+		include = []string{}
+	}
+	if exclude == nil {
+		exclude = []string{}
+	}
+	attributes, err := json.Marshal(cfg.Attributes)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to marshal comet txs filter attributes: %w", err)
+	}
+	if cfg.Attributes == nil {
+		attributes = []byte("[]")
+	}
+
+	if err := c.Connection.QueryRow(ctx, `
+		INSERT INTO metrics.comet_txs_filters (hash, include, exclude, attributes)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (hash) DO UPDATE
+		SET hash=EXCLUDED.hash
+		RETURNING id, (xmax = 0) AS created`,
+		filter.Hash(),
+		include,
+		exclude,
+		attributes,
+	).Scan(&id, &created); err != nil {
+		return 0, false, fmt.Errorf("failed to store comet txs filter: %w", err)
+	}
+
+	return id, created, nil
+}
+
+// DeleteProcessedBlocksBefore removes the processed ranges ending before the given height.
+func (c *CometTxs) DeleteProcessedBlocksBefore(ctx context.Context, height int64) error {
+	_, err := c.Connection.Exec(ctx, `
+		DELETE FROM metrics.comet_txs_processed_blocks
+		WHERE to_height < $1`,
+		height,
+	)
+
+	return err
+}
@@ -0,0 +1,80 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type EpochValidatorSet struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewEpochValidatorSet(connectionSource *vega_sqlstore.ConnectionSource) *EpochValidatorSet {
+	return &EpochValidatorSet{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// GetForEpoch returns the validator set stored for the epoch, or an empty list if it has not been stored yet.
+func (vs *EpochValidatorSet) GetForEpoch(ctx context.Context, epochSeq int64) ([]entities.ValidatorSetEntry, error) {
+	result := []entities.ValidatorSetEntry{}
+
+	if err := pgxscan.Select(ctx, vs.Connection, &result,
+		`SELECT epoch_seq, height, tendermint_pub_key, address, voting_power
+		FROM metrics.epoch_validator_set
+		WHERE epoch_seq = $1
+		ORDER BY voting_power DESC`,
+		epochSeq,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get validator set for epoch %d: %w", epochSeq, err)
+	}
+
+	return result, nil
+}
+
+// Upsert stores the whole validator set of an epoch in a single transaction.
+func (vs *EpochValidatorSet) Upsert(ctx context.Context, entries []entities.ValidatorSetEntry) error {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blockCtx, err := vs.WithTransaction(blockCtx)
+	if err != nil {
+		return NewUpsertErr(StoreEpochValidatorSet, ErrAcquireTx, err)
+	}
+
+	for _, entry := range entries {
+		if _, err := vs.Connection.Exec(blockCtx, `
+			INSERT INTO metrics.epoch_validator_set (
+				epoch_seq,
+				height,
+				tendermint_pub_key,
+				address,
+				voting_power)
+			VALUES
+				($1, $2, $3, $4, $5)
+			ON CONFLICT (epoch_seq, tendermint_pub_key) DO UPDATE
+			SET
+				height=EXCLUDED.height,
+				address=EXCLUDED.address,
+				voting_power=EXCLUDED.voting_power`,
+			entry.EpochSeq,
+			entry.Height,
+			entry.TmPubKey,
+			entry.Address,
+			entry.VotingPower,
+		); err != nil {
+			return NewUpsertErr(StoreEpochValidatorSet, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := vs.Commit(blockCtx); err != nil {
+		return NewUpsertErr(StoreEpochValidatorSet, ErrUpsertCommit, err)
+	}
+
+	return nil
+}
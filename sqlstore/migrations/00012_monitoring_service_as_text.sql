@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Scrapers report their status with their own service name, so the list of services is not fixed anymore
+ALTER TABLE metrics.monitoring_status
+    ALTER COLUMN monitoring_service TYPE TEXT USING monitoring_service::TEXT;
+
+DROP TYPE IF EXISTS metrics.monitoring_service_type;
+
+-- +goose Down
+
+CREATE TYPE metrics.monitoring_service_type AS ENUM (
+  'BLOCK_SIGNERS',
+  'SEGMENTS',
+  'COMET_TXS',
+  'NETWORK_BALANCES',
+  'ASSET_PRICES',
+  'PROMETHEUS_ETHEREUM_CALLS_SERVICE',
+  'PROMETHEUS_ETH_NODE_SCANNER',
+  'PROMETHEUS_NODE_SCANNER',
+  'PROMETHEUS_METAMONITORING',
+  'DATA_NODE'
+);
+
+DELETE FROM metrics.monitoring_status
+    WHERE monitoring_service NOT IN (SELECT unnest(enum_range(NULL::metrics.monitoring_service_type))::TEXT);
+
+ALTER TABLE metrics.monitoring_status
+    ALTER COLUMN monitoring_service TYPE metrics.monitoring_service_type USING monitoring_service::metrics.monitoring_service_type;
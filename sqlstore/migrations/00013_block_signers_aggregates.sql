@@ -0,0 +1,75 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+
+-- Continuous aggregates cannot be created with data inside a transaction
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS metrics.block_signers_hourly
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+  SELECT
+    time_bucket('1 hour', vega_time) AS bucket,
+    tendermint_pub_key,
+    SUM(CASE WHEN role = 'ROLE_SIGNER' THEN 1 ELSE 0 END) AS signed_blocks,
+    SUM(CASE WHEN role = 'ROLE_PROPOSER' THEN 1 ELSE 0 END) AS proposed_blocks
+  FROM metrics.block_signers
+  GROUP BY bucket, tendermint_pub_key
+WITH DATA;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS metrics.block_signers_daily
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+  SELECT
+    time_bucket('1 day', vega_time) AS bucket,
+    tendermint_pub_key,
+    SUM(CASE WHEN role = 'ROLE_SIGNER' THEN 1 ELSE 0 END) AS signed_blocks,
+    SUM(CASE WHEN role = 'ROLE_PROPOSER' THEN 1 ELSE 0 END) AS proposed_blocks
+  FROM metrics.block_signers
+  GROUP BY bucket, tendermint_pub_key
+WITH DATA;
+
+SELECT add_continuous_aggregate_policy('metrics.block_signers_hourly',
+  start_offset => INTERVAL '3 days',
+  end_offset => INTERVAL '1 hour',
+  schedule_interval => INTERVAL '30 minutes',
+  if_not_exists => true);
+
+SELECT add_continuous_aggregate_policy('metrics.block_signers_daily',
+  start_offset => INTERVAL '3 days',
+  end_offset => INTERVAL '1 hour',
+  schedule_interval => INTERVAL '1 hour',
+  if_not_exists => true);
+
+-- Every block has exactly one proposer, so the sum of proposed blocks is the number of blocks in the bucket.
+-- Validator without any signature in the bucket is missing in the view.
+CREATE OR REPLACE VIEW metrics.validator_uptime_hourly AS
+  SELECT
+    bucket,
+    tendermint_pub_key,
+    signed_blocks,
+    proposed_blocks,
+    total_blocks,
+    GREATEST(total_blocks - signed_blocks, 0) AS missed_blocks,
+    CASE WHEN total_blocks > 0 THEN ROUND(100.0 * signed_blocks / total_blocks, 2) END AS signing_percentage
+  FROM (
+    SELECT *, SUM(proposed_blocks) OVER (PARTITION BY bucket) AS total_blocks
+    FROM metrics.block_signers_hourly
+  ) AS s;
+
+CREATE OR REPLACE VIEW metrics.validator_uptime_daily AS
+  SELECT
+    bucket,
+    tendermint_pub_key,
+    signed_blocks,
+    proposed_blocks,
+    total_blocks,
+    GREATEST(total_blocks - signed_blocks, 0) AS missed_blocks,
+    CASE WHEN total_blocks > 0 THEN ROUND(100.0 * signed_blocks / total_blocks, 2) END AS signing_percentage
+  FROM (
+    SELECT *, SUM(proposed_blocks) OVER (PARTITION BY bucket) AS total_blocks
+    FROM metrics.block_signers_daily
+  ) AS s;
+
+-- +goose Down
+
+DROP VIEW IF EXISTS metrics.validator_uptime_daily;
+DROP VIEW IF EXISTS metrics.validator_uptime_hourly;
+DROP MATERIALIZED VIEW IF EXISTS metrics.block_signers_daily;
+DROP MATERIALIZED VIEW IF EXISTS metrics.block_signers_hourly;
@@ -0,0 +1,32 @@
+-- +goose Up
+
+-- Most of the blocks do not contain any of the scraped txs, so the processed block ranges are stored
+-- separately to find the blocks, which have never been processed.
+CREATE TABLE metrics.comet_txs_processed_blocks
+(
+  from_height   BIGINT                    NOT NULL,
+  to_height     BIGINT                    NOT NULL,
+  processed_at  TIMESTAMP WITH TIME ZONE  NOT NULL DEFAULT NOW(),
+  PRIMARY KEY(from_height, to_height)
+);
+CREATE INDEX ON metrics.comet_txs_processed_blocks (to_height);
+
+-- Assume everything between the first and the last stored tx has already been processed
+INSERT INTO metrics.comet_txs_processed_blocks (from_height, to_height)
+  SELECT MIN(height), MAX(height) FROM metrics.comet_txs HAVING COUNT(*) > 0;
+
+CREATE TABLE metrics.block_gaps
+(
+  table_name       TEXT                      NOT NULL,
+  from_height      BIGINT                    NOT NULL,
+  to_height        BIGINT                    NOT NULL,
+  gaps             INT                       NOT NULL,
+  missing_heights  BIGINT                    NOT NULL,
+  updated_at       TIMESTAMP WITH TIME ZONE  NOT NULL,
+  PRIMARY KEY(table_name)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS metrics.block_gaps;
+DROP TABLE IF EXISTS metrics.comet_txs_processed_blocks;
@@ -0,0 +1,64 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+
+-- Continuous aggregates cannot be created with data inside a transaction
+
+CREATE TABLE IF NOT EXISTS metrics.block_votes
+(
+  vega_time           TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height              BIGINT                    NOT NULL,
+  round               INT                       NOT NULL,
+  tendermint_pub_key  BYTEA                     NOT NULL,
+  vote_time           TIMESTAMP WITH TIME ZONE  NOT NULL,
+  PRIMARY KEY(vega_time, tendermint_pub_key)
+);
+SELECT create_hypertable('metrics.block_votes', 'vega_time', chunk_time_interval => INTERVAL '1 day', if_not_exists => true);
+CREATE INDEX IF NOT EXISTS block_votes_tendermint_pub_key_idx ON metrics.block_votes (tendermint_pub_key, vega_time);
+
+-- Latency is the time between the block time and the precommit of the validator.
+-- Non-zero round votes are votes in blocks, which were not committed in the first round.
+CREATE MATERIALIZED VIEW IF NOT EXISTS metrics.block_votes_hourly
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+  SELECT
+    time_bucket('1 hour', vega_time) AS bucket,
+    tendermint_pub_key,
+    COUNT(*) AS votes,
+    SUM(CASE WHEN round > 0 THEN 1 ELSE 0 END) AS non_zero_round_votes,
+    AVG(EXTRACT(EPOCH FROM (vote_time - vega_time)) * 1000) AS avg_latency_ms,
+    MIN(EXTRACT(EPOCH FROM (vote_time - vega_time)) * 1000) AS min_latency_ms,
+    MAX(EXTRACT(EPOCH FROM (vote_time - vega_time)) * 1000) AS max_latency_ms
+  FROM metrics.block_votes
+  GROUP BY bucket, tendermint_pub_key
+WITH DATA;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS metrics.block_votes_daily
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+  SELECT
+    time_bucket('1 day', vega_time) AS bucket,
+    tendermint_pub_key,
+    COUNT(*) AS votes,
+    SUM(CASE WHEN round > 0 THEN 1 ELSE 0 END) AS non_zero_round_votes,
+    AVG(EXTRACT(EPOCH FROM (vote_time - vega_time)) * 1000) AS avg_latency_ms,
+    MIN(EXTRACT(EPOCH FROM (vote_time - vega_time)) * 1000) AS min_latency_ms,
+    MAX(EXTRACT(EPOCH FROM (vote_time - vega_time)) * 1000) AS max_latency_ms
+  FROM metrics.block_votes
+  GROUP BY bucket, tendermint_pub_key
+WITH DATA;
+
+SELECT add_continuous_aggregate_policy('metrics.block_votes_hourly',
+  start_offset => INTERVAL '3 days',
+  end_offset => INTERVAL '1 hour',
+  schedule_interval => INTERVAL '30 minutes',
+  if_not_exists => true);
+
+SELECT add_continuous_aggregate_policy('metrics.block_votes_daily',
+  start_offset => INTERVAL '3 days',
+  end_offset => INTERVAL '1 hour',
+  schedule_interval => INTERVAL '1 hour',
+  if_not_exists => true);
+
+-- +goose Down
+
+DROP MATERIALIZED VIEW IF EXISTS metrics.block_votes_daily;
+DROP MATERIALIZED VIEW IF EXISTS metrics.block_votes_hourly;
+DROP TABLE IF EXISTS metrics.block_votes;
@@ -0,0 +1,35 @@
+-- +goose Up
+
+-- Validator set with the voting power of each epoch, at the first block of the epoch + 2, where CometBFT applies
+-- the validator updates. Validators join, leave and change the voting power only at the epoch boundaries.
+CREATE TABLE metrics.epoch_validator_set
+(
+  epoch_seq           BIGINT  NOT NULL,
+  height              BIGINT  NOT NULL,
+  tendermint_pub_key  BYTEA   NOT NULL,
+  address             TEXT    NOT NULL,
+  voting_power        BIGINT  NOT NULL,
+  PRIMARY KEY(epoch_seq, tendermint_pub_key)
+);
+
+-- Expected proposals are the number of blocks in the epoch multiplied by the share of the voting power.
+-- Deviation is (actual_proposals - expected_proposals) / expected_proposals.
+CREATE TABLE metrics.proposer_fairness
+(
+  epoch_seq           BIGINT                    NOT NULL,
+  tendermint_pub_key  BYTEA                     NOT NULL,
+  voting_power        BIGINT                    NOT NULL,
+  voting_power_share  DOUBLE PRECISION          NOT NULL,
+  total_blocks        BIGINT                    NOT NULL,
+  expected_proposals  DOUBLE PRECISION          NOT NULL,
+  actual_proposals    BIGINT                    NOT NULL,
+  deviation           DOUBLE PRECISION          NOT NULL,
+  complete            BOOLEAN                   NOT NULL,
+  updated_at          TIMESTAMP WITH TIME ZONE  NOT NULL,
+  PRIMARY KEY(epoch_seq, tendermint_pub_key)
+);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS metrics.proposer_fairness;
+DROP TABLE IF EXISTS metrics.epoch_validator_set;
@@ -0,0 +1,56 @@
+-- +goose Up
+
+CREATE TYPE metrics.validator_set_change_type AS enum('SNAPSHOT', 'JOINED', 'LEFT', 'VOTING_POWER_CHANGED');
+
+-- Changes of the CometBFT validator set. The first recorded set is stored as SNAPSHOT rows,
+-- and then only validators, which joined, left or changed the voting power at a height.
+-- Rows are never removed, as all of them are needed to reconstruct the set at any height.
+CREATE TABLE metrics.validator_set
+(
+  vega_time              TIMESTAMP WITH TIME ZONE           NOT NULL,
+  height                 BIGINT                             NOT NULL,
+  tendermint_pub_key     BYTEA                              NOT NULL,
+  address                TEXT                               NOT NULL,
+  change                 metrics.validator_set_change_type  NOT NULL,
+  voting_power           BIGINT                             NOT NULL,
+  previous_voting_power  BIGINT                             NOT NULL,
+  proposer_priority      BIGINT                             NOT NULL,
+  PRIMARY KEY(vega_time, tendermint_pub_key)
+);
+SELECT create_hypertable('metrics.validator_set', 'vega_time', chunk_time_interval => INTERVAL '1 month');
+CREATE INDEX ON metrics.validator_set (height);
+CREATE INDEX ON metrics.validator_set (tendermint_pub_key, height);
+
+-- The last height checked for changes. Heights up to it without rows have the same set as the previous height.
+CREATE TABLE metrics.validator_set_processed
+(
+  id          BOOLEAN                   NOT NULL DEFAULT true,
+  height      BIGINT                    NOT NULL,
+  updated_at  TIMESTAMP WITH TIME ZONE  NOT NULL DEFAULT NOW(),
+  PRIMARY KEY(id),
+  CHECK(id)
+);
+
+-- Validator set at the height, i.e. validators expected to sign the block
+-- +goose StatementBegin
+CREATE FUNCTION metrics.validator_set_at(at_height BIGINT)
+RETURNS TABLE (tendermint_pub_key BYTEA, address TEXT, voting_power BIGINT)
+LANGUAGE SQL STABLE AS $$
+  SELECT latest.tendermint_pub_key, latest.address, latest.voting_power
+  FROM (
+    SELECT DISTINCT ON (vs.tendermint_pub_key) vs.tendermint_pub_key, vs.address, vs.voting_power, vs.change
+    FROM metrics.validator_set vs
+    WHERE vs.height <= at_height
+    ORDER BY vs.tendermint_pub_key, vs.height DESC
+  ) latest
+  WHERE latest.change <> 'LEFT'
+  ORDER BY latest.voting_power DESC;
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+
+DROP FUNCTION IF EXISTS metrics.validator_set_at;
+DROP TABLE IF EXISTS metrics.validator_set_processed;
+DROP TABLE IF EXISTS metrics.validator_set;
+DROP TYPE IF EXISTS metrics.validator_set_change_type;
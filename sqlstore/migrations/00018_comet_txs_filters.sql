@@ -0,0 +1,46 @@
+-- +goose Up
+
+-- Filters applied to the comet txs. Every processed block range references the filter used for it,
+-- so it is known which command types have been stored for which blocks.
+CREATE TABLE metrics.comet_txs_filters
+(
+  id          SERIAL                    PRIMARY KEY,
+  hash        TEXT                      NOT NULL UNIQUE,
+  include     TEXT[]                    NOT NULL,
+  exclude     TEXT[]                    NOT NULL,
+  attributes  JSONB                     NOT NULL,
+  created_at  TIMESTAMP WITH TIME ZONE  NOT NULL DEFAULT NOW()
+);
+
+-- Blocks processed before the filter was configurable used the hard-coded list of excluded commands
+INSERT INTO metrics.comet_txs_filters (hash, include, exclude, attributes)
+VALUES (
+  'hard-coded',
+  '{}',
+  '{"Amend LiquidityProvision Order", "Amend Order", "Cancel LiquidityProvision Order", "Cancel Order", "Cancel Transfer Funds", "Delegate", "Ethereum Key Rotate Submission", "Key Rotate Submission", "Liquidity Provision Order", "Node Signature", "Proposal", "Protocol Upgrade", "Submit Oracle Data", "Submit Order", "Transfer Funds", "Undelegate", "Vote on Proposal", "Withdraw"}',
+  '[]'
+);
+
+ALTER TABLE metrics.comet_txs_processed_blocks ADD COLUMN filter_id INT REFERENCES metrics.comet_txs_filters(id);
+UPDATE metrics.comet_txs_processed_blocks SET filter_id = (SELECT id FROM metrics.comet_txs_filters WHERE hash = 'hard-coded');
+
+-- Processed block ranges, in which txs of the command type have been stored
+-- +goose StatementBegin
+CREATE FUNCTION metrics.comet_txs_command_coverage(command_type TEXT)
+RETURNS TABLE (from_height BIGINT, to_height BIGINT, filter_id INT)
+LANGUAGE SQL STABLE AS $$
+  SELECT pb.from_height, pb.to_height, pb.filter_id
+  FROM metrics.comet_txs_processed_blocks pb
+  JOIN metrics.comet_txs_filters f ON f.id = pb.filter_id
+  WHERE
+    (cardinality(f.include) = 0 OR '*' = ANY(f.include) OR command_type = ANY(f.include))
+    AND NOT command_type = ANY(f.exclude)
+  ORDER BY pb.from_height;
+$$;
+-- +goose StatementEnd
+
+-- +goose Down
+
+DROP FUNCTION IF EXISTS metrics.comet_txs_command_coverage;
+ALTER TABLE metrics.comet_txs_processed_blocks DROP COLUMN IF EXISTS filter_id;
+DROP TABLE IF EXISTS metrics.comet_txs_filters;
@@ -0,0 +1,74 @@
+-- +goose Up
+
+-- Fields of the comet txs decoded with the Vega protobuf command definitions. The txs have the same
+-- (vega_time, height_idx) as in metrics.comet_txs, and are stored only when they are kept by the comet txs filter.
+
+CREATE TABLE metrics.chain_events
+(
+  vega_time   TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height      BIGINT                    NOT NULL,
+  height_idx  SMALLINT                  NOT NULL,
+  code        INT                       NOT NULL,
+  submitter   TEXT                      NOT NULL,
+  tx_id       TEXT                      NOT NULL,
+  nonce       NUMERIC(20, 0)            NOT NULL,
+  event_type  TEXT                      NOT NULL,
+  PRIMARY KEY(vega_time, height_idx)
+);
+CREATE INDEX ON metrics.chain_events (tx_id);
+CREATE INDEX ON metrics.chain_events (vega_time, event_type, submitter);
+SELECT create_hypertable('metrics.chain_events', 'vega_time', chunk_time_interval => INTERVAL '1 day');
+
+CREATE TABLE metrics.node_votes
+(
+  vega_time   TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height      BIGINT                    NOT NULL,
+  height_idx  SMALLINT                  NOT NULL,
+  code        INT                       NOT NULL,
+  submitter   TEXT                      NOT NULL,
+  reference   TEXT                      NOT NULL,
+  vote_type   TEXT                      NOT NULL,
+  PRIMARY KEY(vega_time, height_idx)
+);
+CREATE INDEX ON metrics.node_votes (reference);
+CREATE INDEX ON metrics.node_votes (vega_time, vote_type, submitter);
+SELECT create_hypertable('metrics.node_votes', 'vega_time', chunk_time_interval => INTERVAL '1 day');
+
+CREATE TABLE metrics.state_variable_proposals
+(
+  vega_time          TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height             BIGINT                    NOT NULL,
+  height_idx         SMALLINT                  NOT NULL,
+  code               INT                       NOT NULL,
+  submitter          TEXT                      NOT NULL,
+  state_var_id       TEXT                      NOT NULL,
+  event_id           TEXT                      NOT NULL,
+  key_value_bundles  INT                       NOT NULL,
+  PRIMARY KEY(vega_time, height_idx)
+);
+CREATE INDEX ON metrics.state_variable_proposals (state_var_id, event_id);
+CREATE INDEX ON metrics.state_variable_proposals (vega_time, submitter);
+SELECT create_hypertable('metrics.state_variable_proposals', 'vega_time', chunk_time_interval => INTERVAL '1 day');
+
+CREATE TABLE metrics.issue_signatures
+(
+  vega_time             TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height                BIGINT                    NOT NULL,
+  height_idx            SMALLINT                  NOT NULL,
+  code                  INT                       NOT NULL,
+  submitter             TEXT                      NOT NULL,
+  signatures_submitter  TEXT                      NOT NULL,
+  kind                  TEXT                      NOT NULL,
+  validator_node_id     TEXT                      NOT NULL,
+  PRIMARY KEY(vega_time, height_idx)
+);
+CREATE INDEX ON metrics.issue_signatures (validator_node_id);
+CREATE INDEX ON metrics.issue_signatures (vega_time, kind, submitter);
+SELECT create_hypertable('metrics.issue_signatures', 'vega_time', chunk_time_interval => INTERVAL '1 day');
+
+-- +goose Down
+
+DROP TABLE IF EXISTS metrics.issue_signatures;
+DROP TABLE IF EXISTS metrics.state_variable_proposals;
+DROP TABLE IF EXISTS metrics.node_votes;
+DROP TABLE IF EXISTS metrics.chain_events;
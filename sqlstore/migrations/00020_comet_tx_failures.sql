@@ -0,0 +1,45 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+
+-- Continuous aggregates cannot be created with data inside a transaction
+
+-- Failed comet txs of all commands, regardless of the comet txs filter. Category is the error classified
+-- from the result code and info, e.g. spam_rejection or invalid_signature.
+CREATE TABLE IF NOT EXISTS metrics.comet_tx_failures
+(
+  vega_time   TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height      BIGINT                    NOT NULL,
+  height_idx  SMALLINT                  NOT NULL,
+  code        INT                       NOT NULL,
+  submitter   TEXT                      NOT NULL,
+  command     TEXT                      NOT NULL,
+  category    TEXT                      NOT NULL,
+  info        TEXT,
+  PRIMARY KEY(vega_time, height_idx)
+);
+SELECT create_hypertable('metrics.comet_tx_failures', 'vega_time', chunk_time_interval => INTERVAL '1 day', if_not_exists => true);
+CREATE INDEX IF NOT EXISTS comet_tx_failures_command_idx ON metrics.comet_tx_failures (command, vega_time);
+CREATE INDEX IF NOT EXISTS comet_tx_failures_submitter_idx ON metrics.comet_tx_failures (submitter, vega_time);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS metrics.comet_tx_failures_hourly
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+  SELECT
+    time_bucket('1 hour', vega_time) AS bucket,
+    command,
+    submitter,
+    category,
+    COUNT(*) AS failures
+  FROM metrics.comet_tx_failures
+  GROUP BY bucket, command, submitter, category
+WITH DATA;
+
+SELECT add_continuous_aggregate_policy('metrics.comet_tx_failures_hourly',
+  start_offset => INTERVAL '3 days',
+  end_offset => INTERVAL '1 hour',
+  schedule_interval => INTERVAL '30 minutes',
+  if_not_exists => true);
+
+-- +goose Down
+
+DROP MATERIALIZED VIEW IF EXISTS metrics.comet_tx_failures_hourly;
+DROP TABLE IF EXISTS metrics.comet_tx_failures;
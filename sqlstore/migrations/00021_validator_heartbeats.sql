@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- Heartbeat cadence of every validator node, derived from the Validator Heartbeat comet txs in the time window
+-- before vega_time. The intervals are between the successful heartbeats, and since_last_seconds is the time from
+-- the last successful heartbeat to vega_time. Status is OK, FAILING, LATE or MISSING
+-- for the nodes in the validator set, and NOT_VALIDATOR for the other submitters of heartbeats.
+CREATE TABLE metrics.validator_heartbeats
+(
+  vega_time              TIMESTAMP WITH TIME ZONE  NOT NULL,
+  submitter              TEXT                      NOT NULL,
+  name                   TEXT,
+  heartbeats             BIGINT                    NOT NULL,
+  failed_heartbeats      BIGINT                    NOT NULL,
+  last_heartbeat_at      TIMESTAMP WITH TIME ZONE,
+  last_heartbeat_height  BIGINT,
+  last_failed_code       INT,
+  avg_interval_seconds   DOUBLE PRECISION,
+  max_interval_seconds   DOUBLE PRECISION,
+  since_last_seconds     DOUBLE PRECISION,
+  status                 TEXT                      NOT NULL,
+  PRIMARY KEY(vega_time, submitter)
+);
+SELECT create_hypertable('metrics.validator_heartbeats', 'vega_time', chunk_time_interval => INTERVAL '1 day');
+CREATE INDEX ON metrics.validator_heartbeats (submitter, vega_time);
+
+-- +goose Down
+
+DROP TABLE IF EXISTS metrics.validator_heartbeats;
@@ -0,0 +1,81 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+
+-- Continuous aggregates cannot be created with data inside a transaction
+
+-- Statistics of every block. block_interval_seconds is the time since the previous block, round is the consensus
+-- round, in which the block was committed, and txs_per_command counts all txs of the block by command, regardless
+-- of the comet txs filter.
+CREATE TABLE IF NOT EXISTS metrics.blocks_stats
+(
+  vega_time               TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height                  BIGINT                    NOT NULL,
+  block_interval_seconds  DOUBLE PRECISION,
+  proposer                BYTEA                     NOT NULL,
+  round                   INT                       NOT NULL,
+  size_bytes              BIGINT                    NOT NULL,
+  tx_count                INT                       NOT NULL,
+  failed_tx_count         INT                       NOT NULL,
+  txs_per_command         JSONB                     NOT NULL,
+  PRIMARY KEY(vega_time, height)
+);
+SELECT create_hypertable('metrics.blocks_stats', 'vega_time', chunk_time_interval => INTERVAL '1 day', if_not_exists => true);
+CREATE INDEX IF NOT EXISTS blocks_stats_height_idx ON metrics.blocks_stats (height);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS metrics.blocks_stats_hourly
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+  SELECT
+    time_bucket('1 hour', vega_time) AS bucket,
+    COUNT(*) AS blocks,
+    AVG(block_interval_seconds) AS avg_block_interval_seconds,
+    MIN(block_interval_seconds) AS min_block_interval_seconds,
+    MAX(block_interval_seconds) AS max_block_interval_seconds,
+    SUM(CASE WHEN round > 0 THEN 1 ELSE 0 END) AS non_zero_round_blocks,
+    MAX(round) AS max_round,
+    SUM(tx_count) AS txs,
+    AVG(tx_count) AS avg_txs_per_block,
+    MAX(tx_count) AS max_txs_per_block,
+    SUM(failed_tx_count) AS failed_txs,
+    AVG(size_bytes) AS avg_size_bytes,
+    MAX(size_bytes) AS max_size_bytes
+  FROM metrics.blocks_stats
+  GROUP BY bucket
+WITH DATA;
+
+SELECT add_continuous_aggregate_policy('metrics.blocks_stats_hourly',
+  start_offset => INTERVAL '3 days',
+  end_offset => INTERVAL '1 hour',
+  schedule_interval => INTERVAL '30 minutes',
+  if_not_exists => true);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS metrics.blocks_stats_daily
+WITH (timescaledb.continuous, timescaledb.materialized_only = false) AS
+  SELECT
+    time_bucket('1 day', vega_time) AS bucket,
+    COUNT(*) AS blocks,
+    AVG(block_interval_seconds) AS avg_block_interval_seconds,
+    MIN(block_interval_seconds) AS min_block_interval_seconds,
+    MAX(block_interval_seconds) AS max_block_interval_seconds,
+    SUM(CASE WHEN round > 0 THEN 1 ELSE 0 END) AS non_zero_round_blocks,
+    MAX(round) AS max_round,
+    SUM(tx_count) AS txs,
+    AVG(tx_count) AS avg_txs_per_block,
+    MAX(tx_count) AS max_txs_per_block,
+    SUM(failed_tx_count) AS failed_txs,
+    AVG(size_bytes) AS avg_size_bytes,
+    MAX(size_bytes) AS max_size_bytes
+  FROM metrics.blocks_stats
+  GROUP BY bucket
+WITH DATA;
+
+SELECT add_continuous_aggregate_policy('metrics.blocks_stats_daily',
+  start_offset => INTERVAL '7 days',
+  end_offset => INTERVAL '1 day',
+  schedule_interval => INTERVAL '1 hour',
+  if_not_exists => true);
+
+-- +goose Down
+
+DROP MATERIALIZED VIEW IF EXISTS metrics.blocks_stats_daily;
+DROP MATERIALIZED VIEW IF EXISTS metrics.blocks_stats_hourly;
+DROP TABLE IF EXISTS metrics.blocks_stats;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Network history segments of data-nodes, which do not match the other data-nodes at the same height.
+-- Kind is DIVERGENT, when the segment differs from the majority, MISSING, when the data-node does not have
+-- the segment other data-nodes have, or NO_CONSENSUS, when no segment is produced by more than half of the
+-- data-nodes. majority_segment_id is NULL, when there is no majority. vega_time is the time of the segment.
+CREATE TABLE metrics.network_history_segment_divergences
+(
+  vega_time            TIMESTAMP WITH TIME ZONE  NOT NULL,
+  height               BIGINT                    NOT NULL,
+  data_node            TEXT                      NOT NULL,
+  kind                 TEXT                      NOT NULL,
+  segment_id           TEXT,
+  majority_segment_id  TEXT,
+  majority_nodes       INT                       NOT NULL,
+  total_nodes          INT                       NOT NULL,
+  detected_at          TIMESTAMP WITH TIME ZONE  NOT NULL,
+  PRIMARY KEY(vega_time, data_node)
+);
+SELECT create_hypertable('metrics.network_history_segment_divergences', 'vega_time', chunk_time_interval => INTERVAL '1 day');
+
+-- +goose Down
+
+DROP TABLE IF EXISTS metrics.network_history_segment_divergences;
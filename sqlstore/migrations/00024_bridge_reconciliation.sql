@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- Reconciliation of the asset pool on the bridge with the funds held by the network, per asset and chain at each
+-- balance_time of metrics.network_balances. Amounts are in the asset's smallest unit.
+-- discrepancy = asset_pool - (parties_total + unrealised_withdrawals - unfinalized_deposits)
+CREATE TABLE metrics.bridge_reconciliation
+(
+  balance_time            TIMESTAMP WITH TIME ZONE  NOT NULL,
+  asset_id                BYTEA                     NOT NULL,
+  chain_id                VARCHAR                   NOT NULL,
+  asset_pool              NUMERIC                   NOT NULL,
+  parties_total           NUMERIC                   NOT NULL,
+  unrealised_withdrawals  NUMERIC                   NOT NULL,
+  unfinalized_deposits    NUMERIC                   NOT NULL,
+  discrepancy             NUMERIC                   NOT NULL,
+  decimals                INT                       NOT NULL,
+  within_tolerance        BOOLEAN                   NOT NULL,
+  PRIMARY KEY(balance_time, asset_id, chain_id)
+);
+SELECT create_hypertable('metrics.bridge_reconciliation', 'balance_time', chunk_time_interval => INTERVAL '1 day');
+
+-- +goose Down
+
+DROP TABLE IF EXISTS metrics.bridge_reconciliation;
@@ -0,0 +1,33 @@
+-- +goose Up
+
+-- Withdrawals and deposits excluded from the network balances, e.g. stuck or known-bad ones.
+-- network is the chain ID of the Vega network the exclusion applies to, or empty for all networks, so the same
+-- withdrawal or deposit id can be excluded separately on every network. Expired exclusions are ignored.
+CREATE TABLE metrics.balance_exclusions
+(
+  kind        TEXT                      NOT NULL,
+  id          BYTEA                     NOT NULL,
+  network     TEXT                      NOT NULL DEFAULT '',
+  reason      TEXT                      NOT NULL,
+  author      TEXT                      NOT NULL,
+  created_at  TIMESTAMP WITH TIME ZONE  NOT NULL DEFAULT NOW(),
+  expires_at  TIMESTAMP WITH TIME ZONE,
+  PRIMARY KEY(kind, id, network),
+  CHECK (kind IN ('WITHDRAWAL', 'DEPOSIT'))
+);
+
+-- Exclusions previously hard-coded in the network balances queries
+INSERT INTO metrics.balance_exclusions (kind, id, reason, author) VALUES
+  ('WITHDRAWAL', decode('cf3d77a9e5767132a4da41d534c28ae0f9749372cfb1b902cc3439d1649d1d33', 'hex'), 'Exploit for LDO market', 'vega-monitoring'),
+  ('WITHDRAWAL', decode('664cece6d582e534f818002cd5d9fc84df9f66f040bcfec92929fd176ed8a6ec', 'hex'), 'Exploit for LDO market', 'vega-monitoring'),
+  ('WITHDRAWAL', decode('3e1c058594bdd27a7b7b4670305c5b11480cb223e7a791b015e0411a5530564d', 'hex'), 'Exploit for LDO market', 'vega-monitoring'),
+  ('DEPOSIT', decode('8808d9ddd6c09593a519f4ad1c7117c247783a22c57cf6d73448ff9094552e07', 'hex'), 'Duplicated deposit, USDT: 12000000000', 'vega-monitoring'),
+  ('DEPOSIT', decode('fdf81953b1c46b7bcac177d95ab849025a85bc3fbb664c5bcde64ccb7a63f24f', 'hex'), 'Duplicated deposit, USDC: 1000000000', 'vega-monitoring'),
+  ('DEPOSIT', decode('f8546c2e6b59d78603155d8f857b4ceefccb7330743398d677d7eb49c9ee08e5', 'hex'), 'Duplicated deposit, USDT: 186000000', 'vega-monitoring'),
+  ('DEPOSIT', decode('c759b728bd6b136c775dfc4eaf97ecb1ab78f0b08c91e76bef522004e6494dbd', 'hex'), 'Duplicated deposit, USDT: 87000000', 'vega-monitoring'),
+  ('DEPOSIT', decode('008b0e9738bd3eb84271149ba34426cc7e92f1649e4a4af689aa23f3362d37f6', 'hex'), 'Duplicated deposit, USDT: 462893962', 'vega-monitoring'),
+  ('DEPOSIT', decode('b461fed538c64a12a45e25b08b6da8e7f9edbe65af6d7418b4e97b4ebab8f753', 'hex'), 'Duplicated deposit, USDT: 12000000000', 'vega-monitoring');
+
+-- +goose Down
+
+DROP TABLE IF EXISTS metrics.balance_exclusions;
@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- Number of the block, at which the ASSET_POOL balance has been read. All asset pool balances of a chain stored at
+-- the same balance_time are read at the same block. NULL for the other balance sources.
+ALTER TABLE metrics.network_balances
+    ADD COLUMN IF NOT EXISTS block_number BIGINT;
+
+-- +goose Down
+
+ALTER TABLE metrics.network_balances
+    DROP COLUMN IF EXISTS block_number;
@@ -0,0 +1,113 @@
+-- +goose Up
+
+-- Validators in the validator set of the bucket without any signature are included with 0 signed blocks,
+-- so a fully offline validator has 0% uptime instead of no row.
+CREATE OR REPLACE VIEW metrics.validator_uptime_hourly AS
+  WITH buckets AS (
+    SELECT bucket, SUM(proposed_blocks) AS total_blocks
+    FROM metrics.block_signers_hourly
+    GROUP BY bucket
+  ),
+  validators AS (
+    -- Validators in the set at the start of the bucket
+    SELECT b.bucket, latest.tendermint_pub_key
+    FROM buckets b
+    CROSS JOIN LATERAL (
+      SELECT DISTINCT ON (vs.tendermint_pub_key) vs.tendermint_pub_key, vs.change
+      FROM metrics.validator_set vs
+      WHERE vs.vega_time <= b.bucket
+      ORDER BY vs.tendermint_pub_key, vs.vega_time DESC
+    ) latest
+    WHERE latest.change <> 'LEFT'
+    UNION
+    -- Validators joining the set during the bucket
+    SELECT b.bucket, vs.tendermint_pub_key
+    FROM buckets b
+    JOIN metrics.validator_set vs
+      ON vs.vega_time > b.bucket AND vs.vega_time < b.bucket + INTERVAL '1 hour' AND vs.change <> 'LEFT'
+    UNION
+    -- Validators with signatures, also when the validator set is not recorded
+    SELECT bucket, tendermint_pub_key
+    FROM metrics.block_signers_hourly
+  )
+  SELECT
+    v.bucket,
+    v.tendermint_pub_key,
+    COALESCE(s.signed_blocks, 0) AS signed_blocks,
+    COALESCE(s.proposed_blocks, 0) AS proposed_blocks,
+    b.total_blocks,
+    GREATEST(b.total_blocks - COALESCE(s.signed_blocks, 0), 0) AS missed_blocks,
+    CASE WHEN b.total_blocks > 0 THEN ROUND(100.0 * COALESCE(s.signed_blocks, 0) / b.total_blocks, 2) END AS signing_percentage
+  FROM validators v
+  JOIN buckets b ON b.bucket = v.bucket
+  LEFT JOIN metrics.block_signers_hourly s ON s.bucket = v.bucket AND s.tendermint_pub_key = v.tendermint_pub_key;
+
+CREATE OR REPLACE VIEW metrics.validator_uptime_daily AS
+  WITH buckets AS (
+    SELECT bucket, SUM(proposed_blocks) AS total_blocks
+    FROM metrics.block_signers_daily
+    GROUP BY bucket
+  ),
+  validators AS (
+    -- Validators in the set at the start of the bucket
+    SELECT b.bucket, latest.tendermint_pub_key
+    FROM buckets b
+    CROSS JOIN LATERAL (
+      SELECT DISTINCT ON (vs.tendermint_pub_key) vs.tendermint_pub_key, vs.change
+      FROM metrics.validator_set vs
+      WHERE vs.vega_time <= b.bucket
+      ORDER BY vs.tendermint_pub_key, vs.vega_time DESC
+    ) latest
+    WHERE latest.change <> 'LEFT'
+    UNION
+    -- Validators joining the set during the bucket
+    SELECT b.bucket, vs.tendermint_pub_key
+    FROM buckets b
+    JOIN metrics.validator_set vs
+      ON vs.vega_time > b.bucket AND vs.vega_time < b.bucket + INTERVAL '1 day' AND vs.change <> 'LEFT'
+    UNION
+    -- Validators with signatures, also when the validator set is not recorded
+    SELECT bucket, tendermint_pub_key
+    FROM metrics.block_signers_daily
+  )
+  SELECT
+    v.bucket,
+    v.tendermint_pub_key,
+    COALESCE(s.signed_blocks, 0) AS signed_blocks,
+    COALESCE(s.proposed_blocks, 0) AS proposed_blocks,
+    b.total_blocks,
+    GREATEST(b.total_blocks - COALESCE(s.signed_blocks, 0), 0) AS missed_blocks,
+    CASE WHEN b.total_blocks > 0 THEN ROUND(100.0 * COALESCE(s.signed_blocks, 0) / b.total_blocks, 2) END AS signing_percentage
+  FROM validators v
+  JOIN buckets b ON b.bucket = v.bucket
+  LEFT JOIN metrics.block_signers_daily s ON s.bucket = v.bucket AND s.tendermint_pub_key = v.tendermint_pub_key;
+
+-- +goose Down
+
+CREATE OR REPLACE VIEW metrics.validator_uptime_hourly AS
+  SELECT
+    bucket,
+    tendermint_pub_key,
+    signed_blocks,
+    proposed_blocks,
+    total_blocks,
+    GREATEST(total_blocks - signed_blocks, 0) AS missed_blocks,
+    CASE WHEN total_blocks > 0 THEN ROUND(100.0 * signed_blocks / total_blocks, 2) END AS signing_percentage
+  FROM (
+    SELECT *, SUM(proposed_blocks) OVER (PARTITION BY bucket) AS total_blocks
+    FROM metrics.block_signers_hourly
+  ) AS s;
+
+CREATE OR REPLACE VIEW metrics.validator_uptime_daily AS
+  SELECT
+    bucket,
+    tendermint_pub_key,
+    signed_blocks,
+    proposed_blocks,
+    total_blocks,
+    GREATEST(total_blocks - signed_blocks, 0) AS missed_blocks,
+    CASE WHEN total_blocks > 0 THEN ROUND(100.0 * signed_blocks / total_blocks, 2) END AS signing_percentage
+  FROM (
+    SELECT *, SUM(proposed_blocks) OVER (PARTITION BY bucket) AS total_blocks
+    FROM metrics.block_signers_daily
+  ) AS s;
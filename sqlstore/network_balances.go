@@ -2,6 +2,7 @@ package sqlstore
 
 import (
 	"context"
+	"time"
 
 	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
 
@@ -13,23 +14,6 @@ type NetworkBalances struct {
 	NetworkBalances []entities.NetworkBalance
 }
 
-var ignoredWithdrawalIDs = []string{
-	// Exploit for LDO market
-	"cf3d77a9e5767132a4da41d534c28ae0f9749372cfb1b902cc3439d1649d1d33",
-	"664cece6d582e534f818002cd5d9fc84df9f66f040bcfec92929fd176ed8a6ec",
-	"3e1c058594bdd27a7b7b4670305c5b11480cb223e7a791b015e0411a5530564d",
-}
-
-var ignoredDepositsIDs = []string{
-	// Duplicated deposits
-	"8808d9ddd6c09593a519f4ad1c7117c247783a22c57cf6d73448ff9094552e07", // USDT: 12000000000
-	"fdf81953b1c46b7bcac177d95ab849025a85bc3fbb664c5bcde64ccb7a63f24f", // USDC: 1000000000
-	"f8546c2e6b59d78603155d8f857b4ceefccb7330743398d677d7eb49c9ee08e5", // USDT: 186000000
-	"c759b728bd6b136c775dfc4eaf97ecb1ab78f0b08c91e76bef522004e6494dbd", // USDT: 87000000
-	"008b0e9738bd3eb84271149ba34426cc7e92f1649e4a4af689aa23f3362d37f6", // USDT: 462893962
-	"b461fed538c64a12a45e25b08b6da8e7f9edbe65af6d7418b4e97b4ebab8f753", // USDT: 12000000000
-}
-
 func NewNetworkBalances(connectionSource *vega_sqlstore.ConnectionSource) *NetworkBalances {
 	return &NetworkBalances{
 		ConnectionSource: connectionSource,
@@ -47,16 +31,19 @@ func (nhs *NetworkBalances) Upsert(ctx context.Context, newBalance entities.Netw
 			asset_id,
 		    chain_id,
 			balance_source,
-			balance)
-		VALUES ( $1, $2, $3, $4, $5 )
+			balance,
+			block_number)
+		VALUES ( $1, $2, $3, $4, $5, $6 )
 		ON CONFLICT (balance_time, asset_id, balance_source) DO UPDATE
 		SET
-			balance=EXCLUDED.balance`,
+			balance=EXCLUDED.balance,
+			block_number=EXCLUDED.block_number`,
 		newBalance.BalanceTime,
 		newBalance.AssetID,
 		newBalance.ChainID,
 		newBalance.BalanceSource,
 		newBalance.Balance,
+		newBalance.BlockNumber,
 	)
 
 	return err
@@ -89,7 +76,8 @@ func (c *NetworkBalances) FlushUpsert(ctx context.Context) ([]entities.NetworkBa
 	return flushed, nil
 }
 
-func (nhs *NetworkBalances) UpsertPartiesTotalBalance(ctx context.Context) error {
+// UpsertPartiesTotalBalance stores the total of the balances of all accounts at the balance time.
+func (nhs *NetworkBalances) UpsertPartiesTotalBalance(ctx context.Context, balanceTime time.Time) error {
 	_, err := nhs.Connection.Exec(ctx, `
 		WITH latest_balance AS (
 			SELECT accounts.asset_id, SUM(current_balances.balance) AS balance
@@ -103,47 +91,51 @@ func (nhs *NetworkBalances) UpsertPartiesTotalBalance(ctx context.Context) error
 			chain_id,
 			balance_source,
 			balance)
-		SELECT DATE_TRUNC('minute', NOW()), a.id, a.chain_id, 'PARTIES_TOTAL', COALESCE(b.balance, 0)
+		SELECT $1, a.id, a.chain_id, 'PARTIES_TOTAL', COALESCE(b.balance, 0)
 			FROM assets_current a
 			LEFT JOIN latest_balance b ON (b.asset_id = a.id)
 			GROUP BY a.id, a.chain_id, b.balance
 		ON CONFLICT (balance_time, asset_id, balance_source) DO UPDATE
 		SET
 			balance=EXCLUDED.balance`,
+		balanceTime,
 	)
 
 	return err
 }
 
-func (nhs *NetworkBalances) UpsertUnrealisedWithdrawalsBalance(ctx context.Context) error {
-	ignoredIDs := PrepareListForInCondition(ignoredWithdrawalIDs)
-	sqlQuery := `
+// UpsertUnrealisedWithdrawalsBalance stores the total of the finalized withdrawals, which have not been withdrawn
+// on the bridge yet, at the balance time. The withdrawals in metrics.balance_exclusions are skipped.
+func (nhs *NetworkBalances) UpsertUnrealisedWithdrawalsBalance(ctx context.Context, balanceTime time.Time) error {
+	_, err := nhs.Connection.Exec(ctx, `
 	INSERT INTO metrics.network_balances (
 		balance_time,
 		asset_id,
 	    chain_id,
 		balance_source,
 		balance)
-	SELECT DATE_TRUNC('minute', NOW()), a.id, a.chain_id, 'UNREALISED_WITHDRAWALS_TOTAL', COALESCE(SUM(w.amount), 0)
+	SELECT $2, a.id, a.chain_id, 'UNREALISED_WITHDRAWALS_TOTAL', COALESCE(SUM(w.amount), 0)
 		FROM assets_current a
 		LEFT JOIN withdrawals_current w ON (
 				w.asset = a.id
 			AND w.withdrawn_timestamp = '1970-01-01 00:00:00'::timestamptz
 			AND w.status = 'STATUS_FINALIZED'
-			AND encode(w.id::bytea, 'hex') NOT IN (` + ignoredIDs + `)
+			AND w.id NOT IN (`+activeBalanceExclusionsQuery+`)
 		)
 		GROUP BY a.id, a.chain_id
 	ON CONFLICT (balance_time, asset_id, balance_source) DO UPDATE
 	SET
-		balance=EXCLUDED.balance`
-
-	_, err := nhs.Connection.Exec(ctx, sqlQuery)
+		balance=EXCLUDED.balance`,
+		entities.WithdrawalExclusion,
+		balanceTime,
+	)
 
 	return err
 }
 
-func (nhs *NetworkBalances) UpsertUnfinalizedDeposits(ctx context.Context) error {
-	ignoredIDs := PrepareListForInCondition(ignoredDepositsIDs)
+// UpsertUnfinalizedDeposits stores the total of the deposits, which have not been finalized yet, at the balance
+// time. The deposits in metrics.balance_exclusions are skipped.
+func (nhs *NetworkBalances) UpsertUnfinalizedDeposits(ctx context.Context, balanceTime time.Time) error {
 	_, err := nhs.Connection.Exec(ctx, `
 		INSERT INTO metrics.network_balances (
 			balance_time,
@@ -151,14 +143,16 @@ func (nhs *NetworkBalances) UpsertUnfinalizedDeposits(ctx context.Context) error
 		    chain_id,
 			balance_source,
 			balance)
-		SELECT DATE_TRUNC('minute', NOW()), a.id, a.chain_id, 'UNFINALIZED_DEPOSITS', COALESCE(SUM(d.amount), 0)
+		SELECT $2, a.id, a.chain_id, 'UNFINALIZED_DEPOSITS', COALESCE(SUM(d.amount), 0)
 			FROM assets_current a
 			LEFT JOIN deposits_current d ON (d.asset = a.id AND d.status  NOT IN ('STATUS_FINALIZED', 'STATUS_DUPLICATE_REJECTED')) 
-				AND encode(d.id::bytea, 'hex') NOT IN (`+ignoredIDs+`)
+				AND d.id NOT IN (`+activeBalanceExclusionsQuery+`)
 			GROUP BY a.id, a.chain_id
 		ON CONFLICT (balance_time, asset_id, balance_source) DO UPDATE
 		SET
 			balance=EXCLUDED.balance`,
+		entities.DepositExclusion,
+		balanceTime,
 	)
 
 	return err
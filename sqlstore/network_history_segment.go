@@ -3,6 +3,7 @@ package sqlstore
 import (
 	"context"
 	"fmt"
+	"time"
 
 	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
 	"github.com/georgysavva/scany/pgxscan"
@@ -70,6 +71,23 @@ func (nhs *NetworkHistorySegment) GetLatestSegmentsPerDataNode(ctx context.Conte
 	return result, nil
 }
 
+// GetSegmentsSince returns the segments of all data-nodes since the given time, ordered by height.
+func (nhs *NetworkHistorySegment) GetSegmentsSince(ctx context.Context, since time.Time) ([]datanode.NetworkHistorySegment, error) {
+	result := []datanode.NetworkHistorySegment{}
+
+	if err := pgxscan.Select(ctx, nhs.Connection, &result,
+		`SELECT vega_time, height, data_node, segment_id
+		FROM metrics.network_history_segments
+		WHERE vega_time >= $1
+		ORDER BY height, data_node`,
+		since,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get network history segments since %s: %w", since, err)
+	}
+
+	return result, nil
+}
+
 func (nhs *NetworkHistorySegment) FlushUpsertWithoutTime(ctx context.Context) ([]*datanode.NetworkHistorySegment, error) {
 	blockCtx, cancel := context.WithCancel(ctx)
 	defer func() {
@@ -0,0 +1,182 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	vega_entities "code.vegaprotocol.io/vega/datanode/entities"
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type ProposerFairness struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewProposerFairness(connectionSource *vega_sqlstore.ConnectionSource) *ProposerFairness {
+	return &ProposerFairness{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// GetEpochs returns up to limit epochs starting from the given epoch, with the latest version of each epoch.
+func (pf *ProposerFairness) GetEpochs(ctx context.Context, fromEpoch int64, limit int) ([]entities.Epoch, error) {
+	result := []entities.Epoch{}
+
+	if err := pgxscan.Select(ctx, pf.Connection, &result,
+		`SELECT DISTINCT ON (id) id, first_block, last_block
+		FROM epochs
+		WHERE id >= $1
+		ORDER BY id, vega_time DESC
+		LIMIT $2`,
+		fromEpoch, limit,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get epochs from %d: %w", fromEpoch, err)
+	}
+
+	return result, nil
+}
+
+// GetLatestEpoch returns the current epoch, or 0 if there are no epochs.
+func (pf *ProposerFairness) GetLatestEpoch(ctx context.Context) (int64, error) {
+	var epoch *int64
+	if err := pgxscan.Get(ctx, pf.Connection, &epoch, `SELECT MAX(id) FROM epochs`); err != nil {
+		return 0, fmt.Errorf("failed to get latest epoch: %w", err)
+	}
+	if epoch == nil {
+		return 0, nil
+	}
+
+	return *epoch, nil
+}
+
+// GetLastCompleteEpoch returns the last epoch with the final results, or 0 if there is none.
+func (pf *ProposerFairness) GetLastCompleteEpoch(ctx context.Context) (int64, error) {
+	var epoch *int64
+	if err := pgxscan.Get(ctx, pf.Connection, &epoch,
+		`SELECT MAX(epoch_seq) FROM metrics.proposer_fairness WHERE complete`,
+	); err != nil {
+		return 0, fmt.Errorf("failed to get last complete proposer fairness epoch: %w", err)
+	}
+	if epoch == nil {
+		return 0, nil
+	}
+
+	return *epoch, nil
+}
+
+// GetProposals returns the number of blocks proposed by each validator between the given blocks, both inclusive.
+func (pf *ProposerFairness) GetProposals(ctx context.Context, fromHeight int64, toHeight int64) (map[vega_entities.TendermintPublicKey]int64, error) {
+	rows := []struct {
+		TmPubKey  vega_entities.TendermintPublicKey `db:"tendermint_pub_key"`
+		Proposals int64                             `db:"proposals"`
+	}{}
+
+	if err := pgxscan.Select(ctx, pf.Connection, &rows,
+		`SELECT metrics.block_signers.tendermint_pub_key, COUNT(*) AS proposals
+		FROM metrics.block_signers
+		WHERE
+			metrics.block_signers.role = 'ROLE_PROPOSER'
+			AND metrics.block_signers.vega_time >= (SELECT vega_time FROM blocks WHERE height = $1)
+			AND metrics.block_signers.vega_time <= (SELECT vega_time FROM blocks WHERE height = $2)
+		GROUP BY metrics.block_signers.tendermint_pub_key`,
+		fromHeight, toHeight,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get proposals from block %d to %d: %w", fromHeight, toHeight, err)
+	}
+
+	result := make(map[vega_entities.TendermintPublicKey]int64, len(rows))
+	for _, row := range rows {
+		result[row.TmPubKey] = row.Proposals
+	}
+
+	return result, nil
+}
+
+// Upsert stores the results of an epoch in a single transaction.
+func (pf *ProposerFairness) Upsert(ctx context.Context, results []entities.ProposerFairness) error {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blockCtx, err := pf.WithTransaction(blockCtx)
+	if err != nil {
+		return NewUpsertErr(StoreProposerFairness, ErrAcquireTx, err)
+	}
+
+	for _, result := range results {
+		if _, err := pf.Connection.Exec(blockCtx, `
+			INSERT INTO metrics.proposer_fairness (
+				epoch_seq,
+				tendermint_pub_key,
+				voting_power,
+				voting_power_share,
+				total_blocks,
+				expected_proposals,
+				actual_proposals,
+				deviation,
+				complete,
+				updated_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (epoch_seq, tendermint_pub_key) DO UPDATE
+			SET
+				voting_power=EXCLUDED.voting_power,
+				voting_power_share=EXCLUDED.voting_power_share,
+				total_blocks=EXCLUDED.total_blocks,
+				expected_proposals=EXCLUDED.expected_proposals,
+				actual_proposals=EXCLUDED.actual_proposals,
+				deviation=EXCLUDED.deviation,
+				complete=EXCLUDED.complete,
+				updated_at=EXCLUDED.updated_at`,
+			result.EpochSeq,
+			result.TmPubKey,
+			result.VotingPower,
+			result.VotingPowerShare,
+			result.TotalBlocks,
+			result.ExpectedProposals,
+			result.ActualProposals,
+			result.Deviation,
+			result.Complete,
+			result.UpdatedAt,
+		); err != nil {
+			return NewUpsertErr(StoreProposerFairness, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := pf.Commit(blockCtx); err != nil {
+		return NewUpsertErr(StoreProposerFairness, ErrUpsertCommit, err)
+	}
+
+	return nil
+}
+
+// GetReport returns the results of the given epochs with the validator names, sorted by epoch and deviation.
+func (pf *ProposerFairness) GetReport(ctx context.Context, fromEpoch int64, toEpoch int64) ([]entities.ProposerFairnessReport, error) {
+	result := []entities.ProposerFairnessReport{}
+
+	if err := pgxscan.Select(ctx, pf.Connection, &result,
+		`SELECT
+			pf.epoch_seq,
+			pf.tendermint_pub_key,
+			pf.voting_power,
+			pf.voting_power_share,
+			pf.total_blocks,
+			pf.expected_proposals,
+			pf.actual_proposals,
+			pf.deviation,
+			pf.complete,
+			pf.updated_at,
+			vn.name
+		FROM metrics.proposer_fairness pf
+		LEFT JOIN metrics.validator_nodes vn ON vn.tendermint_pub_key = pf.tendermint_pub_key
+		WHERE pf.epoch_seq BETWEEN $1 AND $2
+		ORDER BY pf.epoch_seq, pf.deviation`,
+		fromEpoch, toEpoch,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get proposer fairness report for epochs %d to %d: %w", fromEpoch, toEpoch, err)
+	}
+
+	return result, nil
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 
 	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
@@ -31,6 +32,13 @@ func (rp RetentionPolicy) AsString() string {
 
 type RetentionPolicies []RetentionPolicy
 
+// RetentionTable is a table, which is not in the base policies, e.g. filled in by a registered scraper.
+type RetentionTable struct {
+	Name string
+	// LongTerm tables, e.g. the daily aggregates, are kept as long as the daily aggregates of the base policy
+	LongTerm bool
+}
+
 var StandardRetentionPolicy = RetentionPolicies{
 	RetentionPolicy{
 		TableName: "metrics.block_signers",
@@ -112,16 +120,29 @@ var ArchivalRetentionPolicy = RetentionPolicies{
 	},
 }
 
-func RetentionPoliciesFromConfig(basePolicy string, overrides []config.RetentionPolicy) (RetentionPolicies, error) {
-	var basePolicyEntries RetentionPolicies
+// RetentionPoliciesFromConfig returns the base policy with the overrides applied. Extra tables, e.g. filled
+// in by the registered scrapers, get the same interval as the data tables of the base policy, or as the daily
+// aggregates for the long term tables.
+func RetentionPoliciesFromConfig(basePolicy string, overrides []config.RetentionPolicy, extraTables ...RetentionTable) (RetentionPolicies, error) {
+	var (
+		basePolicyEntries          RetentionPolicies
+		extraTableInterval         string
+		extraLongTermTableInterval string
+	)
 
 	switch basePolicy {
 	case RetentionPolicyArchival:
 		basePolicyEntries = ArchivalRetentionPolicy
+		extraTableInterval = InfiniteInterval
+		extraLongTermTableInterval = InfiniteInterval
 	case RetentionPolicyStandard:
 		basePolicyEntries = StandardRetentionPolicy
+		extraTableInterval = "4 months"
+		extraLongTermTableInterval = "1 year"
 	case RetentionPolicyLite:
 		basePolicyEntries = LiteRetentionPolicy
+		extraTableInterval = "7 days"
+		extraLongTermTableInterval = "1 month"
 	default:
 		return nil, fmt.Errorf(
 			"unknown base retention policy: expected one of %s, %s, %s, got %s",
@@ -132,6 +153,19 @@ func RetentionPoliciesFromConfig(basePolicy string, overrides []config.Retention
 		)
 	}
 
+	// Do not modify the base policies
+	basePolicyEntries = slices.Clone(basePolicyEntries)
+	for _, table := range extraTables {
+		if slices.ContainsFunc(basePolicyEntries, func(entry RetentionPolicy) bool { return entry.TableName == table.Name }) {
+			continue
+		}
+		interval := extraTableInterval
+		if table.LongTerm {
+			interval = extraLongTermTableInterval
+		}
+		basePolicyEntries = append(basePolicyEntries, RetentionPolicy{TableName: table.Name, Interval: interval})
+	}
+
 	for _, policy := range overrides {
 		for idx, basePolicyEntry := range basePolicyEntries {
 			if basePolicyEntry.TableName == policy.TableName {
@@ -183,8 +217,14 @@ func setRetentionPolicy(db *sql.DB, entity string, policy string) error {
 	return nil
 }
 
-func SetRetentionPolicies(connConfig vega_sqlstore.ConnectionConfig, basePolicy string, policyOverrides []config.RetentionPolicy, logger *logging.Logger) error {
-	policies, err := RetentionPoliciesFromConfig(basePolicy, policyOverrides)
+func SetRetentionPolicies(
+	connConfig vega_sqlstore.ConnectionConfig,
+	basePolicy string,
+	policyOverrides []config.RetentionPolicy,
+	logger *logging.Logger,
+	extraTables ...RetentionTable,
+) error {
+	policies, err := RetentionPoliciesFromConfig(basePolicy, policyOverrides, extraTables...)
 	if err != nil {
 		return fmt.Errorf("failed to prepare final retention policy: %w", err)
 	}
@@ -1,6 +1,7 @@
 package sqlstore_test
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -8,11 +9,25 @@ import (
 	"github.com/vegaprotocol/vega-monitoring/sqlstore"
 )
 
+// withOverrides returns the copy of the base policy with the intervals of the given tables changed, and the
+// extra policies appended.
+func withOverrides(base sqlstore.RetentionPolicies, intervals map[string]string, extra ...sqlstore.RetentionPolicy) sqlstore.RetentionPolicies {
+	result := slices.Clone(base)
+	for idx, policy := range result {
+		if interval, ok := intervals[policy.TableName]; ok {
+			result[idx].Interval = interval
+		}
+	}
+
+	return append(result, extra...)
+}
+
 func TestRetentionPoliciesFromConfig(t *testing.T) {
 	testScenarios := []struct {
 		name           string
 		basePolicyName string
 		overrides      []config.RetentionPolicy
+		extraTables    []sqlstore.RetentionTable
 		result         sqlstore.RetentionPolicies
 		errorMsg       string
 	}{
@@ -36,33 +51,18 @@ func TestRetentionPoliciesFromConfig(t *testing.T) {
 					TableName: "metrics.network_balances",
 					Interval:  "7 days",
 				},
-			},
-			result: sqlstore.RetentionPolicies{
-				{
-					TableName: "metrics.block_signers",
-					Interval:  sqlstore.InfiniteInterval,
-				},
 				{
-					TableName: "metrics.network_history_segments",
-					Interval:  sqlstore.InfiniteInterval,
-				},
-				{
-					TableName: "metrics.comet_txs",
-					Interval:  sqlstore.InfiniteInterval,
-				},
-				{
-					TableName: "metrics.network_balances",
+					TableName: "metrics.bridge_reconciliation",
 					Interval:  "7 days",
 				},
-				{
-					TableName: "metrics.asset_prices",
-					Interval:  sqlstore.InfiniteInterval,
-				},
-				{
-					TableName: "metrics.monitoring_status",
-					Interval:  sqlstore.InfiniteInterval,
-				},
 			},
+			extraTables: []sqlstore.RetentionTable{{Name: "metrics.bridge_reconciliation"}},
+			result: withOverrides(sqlstore.ArchivalRetentionPolicy, map[string]string{
+				"metrics.network_balances": "7 days",
+			}, sqlstore.RetentionPolicy{
+				TableName: "metrics.bridge_reconciliation",
+				Interval:  "7 days",
+			}),
 		},
 		{
 			name:           "archival policy, add one table, override one",
@@ -72,37 +72,55 @@ func TestRetentionPoliciesFromConfig(t *testing.T) {
 					TableName: "metrics.network_balances",
 					Interval:  "7 days",
 				},
+				{
+					TableName: "metrics.bridge_reconciliation",
+					Interval:  "7 days",
+				},
 				{
 					TableName: "custom_table",
 					Interval:  "14 days",
 				},
 			},
-			result: sqlstore.RetentionPolicies{
-				{
-					TableName: "metrics.block_signers",
-					Interval:  sqlstore.InfiniteInterval,
-				},
-				{
-					TableName: "metrics.network_history_segments",
-					Interval:  sqlstore.InfiniteInterval,
-				},
+			extraTables: []sqlstore.RetentionTable{{Name: "metrics.bridge_reconciliation"}},
+			result: withOverrides(sqlstore.ArchivalRetentionPolicy, map[string]string{
+				"metrics.network_balances": "7 days",
+			}, sqlstore.RetentionPolicy{
+				TableName: "metrics.bridge_reconciliation",
+				Interval:  "7 days",
+			}),
+		},
+		{
+			name:           "lite policy, extra scraper table, override it",
+			basePolicyName: sqlstore.RetentionPolicyLite,
+			overrides: []config.RetentionPolicy{
 				{
-					TableName: "metrics.comet_txs",
-					Interval:  sqlstore.InfiniteInterval,
+					TableName: "metrics.custom_scraper",
+					Interval:  "1 day",
 				},
-				{
-					TableName: "metrics.network_balances",
-					Interval:  "7 days",
-				},
-				{
-					TableName: "metrics.asset_prices",
-					Interval:  sqlstore.InfiniteInterval,
+			},
+			extraTables: []sqlstore.RetentionTable{{Name: "metrics.custom_scraper"}, {Name: "metrics.comet_txs"}},
+			result: withOverrides(sqlstore.LiteRetentionPolicy, nil, sqlstore.RetentionPolicy{
+				TableName: "metrics.custom_scraper",
+				Interval:  "1 day",
+			}),
+		},
+		{
+			name:           "standard policy, extra long term table",
+			basePolicyName: sqlstore.RetentionPolicyStandard,
+			extraTables: []sqlstore.RetentionTable{
+				{Name: "metrics.custom_scraper"},
+				{Name: "metrics.custom_scraper_daily", LongTerm: true},
+			},
+			result: withOverrides(sqlstore.StandardRetentionPolicy, nil,
+				sqlstore.RetentionPolicy{
+					TableName: "metrics.custom_scraper",
+					Interval:  "4 months",
 				},
-				{
-					TableName: "metrics.monitoring_status",
-					Interval:  sqlstore.InfiniteInterval,
+				sqlstore.RetentionPolicy{
+					TableName: "metrics.custom_scraper_daily",
+					Interval:  "1 year",
 				},
-			},
+			),
 		},
 	}
 
@@ -110,7 +128,7 @@ func TestRetentionPoliciesFromConfig(t *testing.T) {
 		scenario := scenario
 		t.Run(scenario.name, func(t *testing.T) {
 			t.Parallel()
-			result, err := sqlstore.RetentionPoliciesFromConfig(scenario.basePolicyName, scenario.overrides)
+			result, err := sqlstore.RetentionPoliciesFromConfig(scenario.basePolicyName, scenario.overrides, scenario.extraTables...)
 			if len(scenario.errorMsg) < 1 {
 				assert.NotNil(t, result)
 				assert.Equal(t, scenario.result, result)
@@ -0,0 +1,72 @@
+package sqlstore
+
+import (
+	"context"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type SegmentDivergences struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewSegmentDivergences(connectionSource *vega_sqlstore.ConnectionSource) *SegmentDivergences {
+	return &SegmentDivergences{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// Upsert stores the divergences in a single transaction. The divergences found again keep the time of the first
+// detection.
+func (sd *SegmentDivergences) Upsert(ctx context.Context, divergences []entities.SegmentDivergence) error {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blockCtx, err := sd.WithTransaction(blockCtx)
+	if err != nil {
+		return NewUpsertErr(StoreSegmentDivergences, ErrAcquireTx, err)
+	}
+
+	for _, divergence := range divergences {
+		if _, err := sd.Connection.Exec(blockCtx, `
+			INSERT INTO metrics.network_history_segment_divergences (
+				vega_time,
+				height,
+				data_node,
+				kind,
+				segment_id,
+				majority_segment_id,
+				majority_nodes,
+				total_nodes,
+				detected_at)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			ON CONFLICT (vega_time, data_node) DO UPDATE
+			SET
+				kind=EXCLUDED.kind,
+				segment_id=EXCLUDED.segment_id,
+				majority_segment_id=EXCLUDED.majority_segment_id,
+				majority_nodes=EXCLUDED.majority_nodes,
+				total_nodes=EXCLUDED.total_nodes`,
+			divergence.VegaTime,
+			divergence.Height,
+			divergence.DataNode,
+			divergence.Kind,
+			divergence.SegmentID,
+			divergence.MajoritySegmentID,
+			divergence.MajorityNodes,
+			divergence.TotalNodes,
+			divergence.DetectedAt,
+		); err != nil {
+			return NewUpsertErr(StoreSegmentDivergences, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := sd.Commit(blockCtx); err != nil {
+		return NewUpsertErr(StoreSegmentDivergences, ErrUpsertCommit, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type TxFailures struct {
+	*vega_sqlstore.ConnectionSource
+	failures []entities.TxFailure
+}
+
+func NewTxFailures(connectionSource *vega_sqlstore.ConnectionSource) *TxFailures {
+	return &TxFailures{
+		ConnectionSource: connectionSource,
+	}
+}
+
+func (tf *TxFailures) Add(failure entities.TxFailure) {
+	tf.failures = append(tf.failures, failure)
+}
+
+func (tf *TxFailures) Upsert(ctx context.Context, failure entities.TxFailure) error {
+	_, err := tf.Connection.Exec(ctx, `
+		INSERT INTO metrics.comet_tx_failures (
+			vega_time,
+			height,
+			height_idx,
+			code,
+			submitter,
+			command,
+			category,
+			info)
+		VALUES
+			(
+				(SELECT vega_time FROM blocks WHERE height = $1),
+				$1,
+				$2,
+				$3,
+				$4,
+				$5,
+				$6,
+				$7
+			)
+		ON CONFLICT (vega_time, height_idx) DO UPDATE
+		SET
+			height=EXCLUDED.height,
+			code=EXCLUDED.code,
+			submitter=EXCLUDED.submitter,
+			command=EXCLUDED.command,
+			category=EXCLUDED.category,
+			info=EXCLUDED.info`,
+		failure.Height,
+		failure.HeightIdx,
+		failure.Code,
+		failure.Submitter,
+		failure.Command,
+		failure.Category,
+		failure.Info,
+	)
+
+	return err
+}
+
+func (tf *TxFailures) FlushUpsert(ctx context.Context) ([]entities.TxFailure, error) {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		// We cannot keep those rows in memory because they will be added again
+		tf.failures = nil
+	}()
+
+	blockCtx, err := tf.WithTransaction(blockCtx)
+	if err != nil {
+		return nil, NewUpsertErr(StoreTxFailures, ErrAcquireTx, err)
+	}
+
+	for _, failure := range tf.failures {
+		if err := tf.Upsert(blockCtx, failure); err != nil {
+			return nil, NewUpsertErr(StoreTxFailures, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := tf.Commit(blockCtx); err != nil {
+		return nil, NewUpsertErr(StoreTxFailures, ErrUpsertCommit, err)
+	}
+
+	return tf.failures, nil
+}
+
+// GetCountsSince returns the number of failed txs of the commands per submitter since the given time.
+func (tf *TxFailures) GetCountsSince(ctx context.Context, since time.Time, commands []string) ([]entities.TxFailuresCount, error) {
+	result := []entities.TxFailuresCount{}
+
+	if err := pgxscan.Select(ctx, tf.Connection, &result,
+		`SELECT submitter, command, COUNT(*) AS failures
+		FROM metrics.comet_tx_failures
+		WHERE vega_time >= $1 AND command = ANY($2)
+		GROUP BY submitter, command
+		ORDER BY failures DESC, submitter, command`,
+		since,
+		commands,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get tx failures since %s: %w", since, err)
+	}
+
+	return result, nil
+}
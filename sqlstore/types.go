@@ -13,10 +13,20 @@ type StoreType string
 const (
 	StoreAssetPool             StoreType = "asset pool"
 	StoreBlockSigner           StoreType = "block signer"
+	StoreBlockVotes            StoreType = "block votes"
 	StoreCometTxs              StoreType = "comet txs"
+	StoreCometCommands         StoreType = "comet commands"
+	StoreTxFailures            StoreType = "tx failures"
+	StoreBlocksStats           StoreType = "blocks stats"
 	StoreNetworkBalances       StoreType = "network balances"
+	StoreBridgeReconciliation  StoreType = "bridge reconciliation"
 	StoreNetworkHistorySegment StoreType = "network history segment"
+	StoreSegmentDivergences    StoreType = "segment divergences"
 	StoreMonitoringStatus      StoreType = "monitoring status"
+	StoreEpochValidatorSet     StoreType = "epoch validator set"
+	StoreValidatorSet          StoreType = "validator set"
+	StoreProposerFairness      StoreType = "proposer fairness"
+	StoreValidatorHeartbeats   StoreType = "validator heartbeats"
 )
 
 var (
@@ -0,0 +1,128 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type ValidatorHeartbeats struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewValidatorHeartbeats(connectionSource *vega_sqlstore.ConnectionSource) *ValidatorHeartbeats {
+	return &ValidatorHeartbeats{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// GetLatestTxTime returns the time of the latest stored comet tx, or nil if there are no txs.
+func (vh *ValidatorHeartbeats) GetLatestTxTime(ctx context.Context) (*time.Time, error) {
+	var latest *time.Time
+	if err := pgxscan.Get(ctx, vh.Connection, &latest, `SELECT MAX(vega_time) FROM metrics.comet_txs`); err != nil {
+		return nil, fmt.Errorf("failed to get latest comet tx time: %w", err)
+	}
+
+	return latest, nil
+}
+
+// GetHeartbeatTxs returns the Validator Heartbeat txs between the given times, ordered by time.
+func (vh *ValidatorHeartbeats) GetHeartbeatTxs(ctx context.Context, from time.Time, to time.Time) ([]entities.HeartbeatTx, error) {
+	result := []entities.HeartbeatTx{}
+
+	if err := pgxscan.Select(ctx, vh.Connection, &result,
+		`SELECT vega_time, height, code, submitter
+		FROM metrics.comet_txs
+		WHERE command = $1 AND vega_time > $2 AND vega_time <= $3
+		ORDER BY vega_time, height_idx`,
+		entities.CometCommandValidatorHeartbeat, from, to,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get heartbeat txs from %s to %s: %w", from, to, err)
+	}
+
+	return result, nil
+}
+
+// GetValidators returns the validator nodes, which are expected to send heartbeats. They are the CometBFT validator
+// set at the last height processed by the validator set updater, so pending nodes are not expected.
+func (vh *ValidatorHeartbeats) GetValidators(ctx context.Context) ([]entities.HeartbeatValidator, error) {
+	result := []entities.HeartbeatValidator{}
+
+	if err := pgxscan.Select(ctx, vh.Connection, &result,
+		`SELECT encode(n.vega_pub_key, 'hex') AS submitter, n.name
+		FROM metrics.validator_set_at((SELECT height FROM metrics.validator_set_processed)) vs
+		JOIN nodes n ON n.tendermint_pub_key = vs.tendermint_pub_key`,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get heartbeat validators: %w", err)
+	}
+
+	return result, nil
+}
+
+// Upsert stores the heartbeats of a check in a single transaction.
+func (vh *ValidatorHeartbeats) Upsert(ctx context.Context, heartbeats []entities.ValidatorHeartbeat) error {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blockCtx, err := vh.WithTransaction(blockCtx)
+	if err != nil {
+		return NewUpsertErr(StoreValidatorHeartbeats, ErrAcquireTx, err)
+	}
+
+	for _, heartbeat := range heartbeats {
+		if _, err := vh.Connection.Exec(blockCtx, `
+			INSERT INTO metrics.validator_heartbeats (
+				vega_time,
+				submitter,
+				name,
+				heartbeats,
+				failed_heartbeats,
+				last_heartbeat_at,
+				last_heartbeat_height,
+				last_failed_code,
+				avg_interval_seconds,
+				max_interval_seconds,
+				since_last_seconds,
+				status)
+			VALUES
+				($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			ON CONFLICT (vega_time, submitter) DO UPDATE
+			SET
+				name=EXCLUDED.name,
+				heartbeats=EXCLUDED.heartbeats,
+				failed_heartbeats=EXCLUDED.failed_heartbeats,
+				last_heartbeat_at=EXCLUDED.last_heartbeat_at,
+				last_heartbeat_height=EXCLUDED.last_heartbeat_height,
+				last_failed_code=EXCLUDED.last_failed_code,
+				avg_interval_seconds=EXCLUDED.avg_interval_seconds,
+				max_interval_seconds=EXCLUDED.max_interval_seconds,
+				since_last_seconds=EXCLUDED.since_last_seconds,
+				status=EXCLUDED.status`,
+			heartbeat.CheckedAt,
+			heartbeat.Submitter,
+			heartbeat.Name,
+			heartbeat.Heartbeats,
+			heartbeat.FailedHeartbeats,
+			heartbeat.LastHeartbeatAt,
+			heartbeat.LastHeartbeatHeight,
+			heartbeat.LastFailedCode,
+			heartbeat.AvgIntervalSeconds,
+			heartbeat.MaxIntervalSeconds,
+			heartbeat.SinceLastSeconds,
+			heartbeat.Status,
+		); err != nil {
+			return NewUpsertErr(StoreValidatorHeartbeats, ErrUpsertSingle, err)
+		}
+	}
+
+	if err := vh.Commit(blockCtx); err != nil {
+		return NewUpsertErr(StoreValidatorHeartbeats, ErrUpsertCommit, err)
+	}
+
+	return nil
+}
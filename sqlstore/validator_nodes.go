@@ -0,0 +1,33 @@
+package sqlstore
+
+import (
+	"context"
+	"fmt"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type ValidatorNodes struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewValidatorNodes(connectionSource *vega_sqlstore.ConnectionSource) *ValidatorNodes {
+	return &ValidatorNodes{
+		ConnectionSource: connectionSource,
+	}
+}
+
+func (vn *ValidatorNodes) GetAll(ctx context.Context) ([]entities.ValidatorNode, error) {
+	result := []entities.ValidatorNode{}
+
+	if err := pgxscan.Select(ctx, vn.Connection, &result,
+		`SELECT name, tendermint_pub_key FROM metrics.validator_nodes`,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get validator nodes: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,155 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	vega_sqlstore "code.vegaprotocol.io/vega/datanode/sqlstore"
+	"github.com/georgysavva/scany/pgxscan"
+	"github.com/jackc/pgx/v4"
+
+	"github.com/vegaprotocol/vega-monitoring/entities"
+)
+
+type ValidatorSet struct {
+	*vega_sqlstore.ConnectionSource
+}
+
+func NewValidatorSet(connectionSource *vega_sqlstore.ConnectionSource) *ValidatorSet {
+	return &ValidatorSet{
+		ConnectionSource: connectionSource,
+	}
+}
+
+// GetLastProcessedHeight returns the last height checked for validator set changes, or 0 if there is none.
+func (vs *ValidatorSet) GetLastProcessedHeight(ctx context.Context) (int64, error) {
+	var height *int64
+	if err := pgxscan.Get(ctx, vs.Connection, &height,
+		`SELECT MAX(height) FROM metrics.validator_set_processed`,
+	); err != nil {
+		return 0, fmt.Errorf("failed to get last processed validator set height: %w", err)
+	}
+	if height == nil {
+		return 0, nil
+	}
+
+	return *height, nil
+}
+
+// GetAtHeight returns the validator set at the height reconstructed from the recorded changes.
+func (vs *ValidatorSet) GetAtHeight(ctx context.Context, height int64) ([]entities.ValidatorSetMember, error) {
+	result := []entities.ValidatorSetMember{}
+
+	if err := pgxscan.Select(ctx, vs.Connection, &result,
+		`SELECT tendermint_pub_key, address, voting_power
+		FROM metrics.validator_set_at($1)`,
+		height,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get validator set at height %d: %w", height, err)
+	}
+
+	return result, nil
+}
+
+// GetEpochFirstBlocks returns the first blocks of the epochs, which start between the given heights, both inclusive.
+func (vs *ValidatorSet) GetEpochFirstBlocks(ctx context.Context, fromHeight int64, toHeight int64) ([]int64, error) {
+	result := []int64{}
+
+	if err := pgxscan.Select(ctx, vs.Connection, &result,
+		`SELECT DISTINCT first_block
+		FROM epochs
+		WHERE first_block BETWEEN $1 AND $2
+		ORDER BY first_block`,
+		fromHeight, toHeight,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get epochs starting from %d to %d: %w", fromHeight, toHeight, err)
+	}
+
+	return result, nil
+}
+
+// GetChanges returns the changes between the given heights, both inclusive, ordered by height.
+func (vs *ValidatorSet) GetChanges(ctx context.Context, fromHeight int64, toHeight int64) ([]entities.ValidatorSetChange, error) {
+	result := []entities.ValidatorSetChange{}
+
+	if err := pgxscan.Select(ctx, vs.Connection, &result,
+		`SELECT vega_time, height, tendermint_pub_key, address, change, voting_power, previous_voting_power, proposer_priority
+		FROM metrics.validator_set
+		WHERE height BETWEEN $1 AND $2
+		ORDER BY height, tendermint_pub_key`,
+		fromHeight, toHeight,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get validator set changes from %d to %d: %w", fromHeight, toHeight, err)
+	}
+
+	return result, nil
+}
+
+// AddChanges stores the changes with the time of their block from the data-node, and marks all heights
+// up to processedHeight as checked, in a single transaction. VegaTime of the changes is set.
+func (vs *ValidatorSet) AddChanges(ctx context.Context, changes []entities.ValidatorSetChange, processedHeight int64) error {
+	blockCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	blockCtx, err := vs.WithTransaction(blockCtx)
+	if err != nil {
+		return NewUpsertErr(StoreValidatorSet, ErrAcquireTx, err)
+	}
+
+	for idx, change := range changes {
+		err := vs.Connection.QueryRow(blockCtx, `
+			INSERT INTO metrics.validator_set (
+				vega_time,
+				height,
+				tendermint_pub_key,
+				address,
+				change,
+				voting_power,
+				previous_voting_power,
+				proposer_priority)
+			SELECT vega_time, height, $2, $3, $4, $5, $6, $7
+			FROM blocks
+			WHERE height = $1
+			ON CONFLICT (vega_time, tendermint_pub_key) DO UPDATE
+			SET
+				address=EXCLUDED.address,
+				change=EXCLUDED.change,
+				voting_power=EXCLUDED.voting_power,
+				previous_voting_power=EXCLUDED.previous_voting_power,
+				proposer_priority=EXCLUDED.proposer_priority
+			RETURNING vega_time`,
+			change.Height,
+			change.TmPubKey,
+			change.Address,
+			change.Change,
+			change.VotingPower,
+			change.PreviousVotingPower,
+			change.ProposerPriority,
+		).Scan(&changes[idx].VegaTime)
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("block %d not found in the data-node", change.Height)
+		}
+		if err != nil {
+			return NewUpsertErr(StoreValidatorSet, ErrUpsertSingle, err)
+		}
+	}
+
+	if _, err := vs.Connection.Exec(blockCtx, `
+		INSERT INTO metrics.validator_set_processed (height)
+		VALUES ($1)
+		ON CONFLICT (id) DO UPDATE
+		SET
+			height=EXCLUDED.height,
+			updated_at=NOW()`,
+		processedHeight,
+	); err != nil {
+		return NewUpsertErr(StoreValidatorSet, ErrUpsertSingle, err)
+	}
+
+	if err := vs.Commit(blockCtx); err != nil {
+		return NewUpsertErr(StoreValidatorSet, ErrUpsertCommit, err)
+	}
+
+	return nil
+}